@@ -311,6 +311,9 @@ class BlockVisitor(algorithm.Visitor):
 
   def __init__(self):
     self.vars = collections.OrderedDict()
+    # Whether the block references locals or dir, in which case the generated
+    # code must make its local variables available to those builtins.
+    self.uses_locals = False
 
   def visit_Assign(self, node):
     for target in node.targets:
@@ -351,6 +354,10 @@ class BlockVisitor(algorithm.Visitor):
     for alias in node.names:
       self._register_local(alias.asname or alias.name)
 
+  def visit_Name(self, node):
+    if node.id in ('dir', 'locals'):
+      self.uses_locals = True
+
   def visit_With(self, node):
     for item in node.items:
       if item.optional_vars:
@@ -390,16 +397,36 @@ class FunctionBlockVisitor(BlockVisitor):
     BlockVisitor.__init__(self)
     self.is_generator = False
     node_args = node.args
-    args = [a.arg for a in node_args.args]
+    args = list(node_args.args)
     if node_args.vararg:
-      args.append(node_args.vararg.arg)
+      args.append(node_args.vararg)
     if node_args.kwarg:
-      args.append(node_args.kwarg.arg)
-    for i, name in enumerate(args):
-      if name in self.vars:
-        msg = "duplicate argument '{}' in function definition".format(name)
-        raise util.ParseError(node, msg)
-      self.vars[name] = Var(name, Var.TYPE_PARAM, arg_index=i)
-
-  def visit_Yield(self, unused_node): # pylint: disable=unused-argument
+      args.append(node_args.kwarg)
+    for i, arg in enumerate(args):
+      if isinstance(arg, ast.Tuple):
+        # Tuple parameters like def f((a, b)) are unpacked into locals when
+        # the body is entered.
+        for name in _tuple_param_names(arg):
+          self._register_param(node, name, Var(name, Var.TYPE_LOCAL))
+      else:
+        var = Var(arg.arg, Var.TYPE_PARAM, arg_index=i)
+        self._register_param(node, arg.arg, var)
+
+  def visit_Yield(self, node):
     self.is_generator = True
+    self.generic_visit(node)
+
+  def _register_param(self, node, name, var):
+    if name in self.vars:
+      msg = "duplicate argument '{}' in function definition".format(name)
+      raise util.ParseError(node, msg)
+    self.vars[name] = var
+
+
+def _tuple_param_names(param):
+  for elt in param.elts:
+    if isinstance(elt, ast.Tuple):
+      for name in _tuple_param_names(elt):
+        yield name
+    else:
+      yield elt.arg
@@ -211,6 +211,23 @@ class BlockVisitorTest(unittest.TestCase):
     self.assertRaisesRegexp(util.ParseError, 'used prior to global declaration',
                             visitor.generic_visit, node)
 
+  def testUsesLocals(self):
+    visitor = block.BlockVisitor()
+    visitor.visit(_ParseStmt('foo = 3'))
+    self.assertFalse(visitor.uses_locals)
+    visitor.visit(_ParseStmt('bar = sorted(locals())'))
+    self.assertTrue(visitor.uses_locals)
+
+  def testUsesLocalsDir(self):
+    visitor = block.BlockVisitor()
+    visitor.visit(_ParseStmt('print dir()'))
+    self.assertTrue(visitor.uses_locals)
+
+  def testUsesLocalsNestedFunction(self):
+    visitor = block.BlockVisitor()
+    visitor.visit(_ParseStmt('def foo(): return locals()'))
+    self.assertFalse(visitor.uses_locals)
+
 
 class FunctionBlockVisitorTest(unittest.TestCase):
 
@@ -243,6 +260,12 @@ class FunctionBlockVisitorTest(unittest.TestCase):
     self.assertEqual(sorted(visitor.vars.keys()), ['foo'])
     self.assertRegexpMatches(visitor.vars['foo'].init_expr, r'UnboundLocal')
 
+  def testYieldLocals(self):
+    visitor = block.FunctionBlockVisitor(_ParseStmt('def foo(): pass'))
+    visitor.visit(_ParseStmt('yield locals()'))
+    self.assertTrue(visitor.is_generator)
+    self.assertTrue(visitor.uses_locals)
+
 
 def _MakeModuleBlock():
   importer = imputil.Importer(None, '__main__', '/tmp/foo.py', False)
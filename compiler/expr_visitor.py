@@ -55,6 +55,9 @@ class ExprVisitor(algorithm.Visitor):
       op_type = type(node.op)
       if op_type in ExprVisitor._BIN_OP_TEMPLATES:
         tmpl = ExprVisitor._BIN_OP_TEMPLATES[op_type]
+        if (op_type is ast.Div and
+            self.block.root.future_features.division):
+          tmpl = 'πg.TrueDiv(πF, {lhs}, {rhs})'
         self.writer.write_checked_call2(
             result, tmpl, lhs=lhs.expr, rhs=rhs.expr)
       else:
@@ -195,7 +198,7 @@ class ExprVisitor(algorithm.Visitor):
 
   def visit_DictComp(self, node):
     result = self.block.alloc_temp()
-    elt = ast.Tuple(elts=[node.key, node.value])
+    elt = ast.Tuple(elts=[node.key, node.value], ctx=None)
     gen_node = ast.GeneratorExp(
         elt=elt, generators=node.generators, loc=node.loc)
     with self.visit(gen_node) as gen:
@@ -388,7 +391,6 @@ class ExprVisitor(algorithm.Visitor):
       ast.BitXor: 'πg.Xor(πF, {lhs}, {rhs})',
       ast.Add: 'πg.Add(πF, {lhs}, {rhs})',
       ast.Div: 'πg.Div(πF, {lhs}, {rhs})',
-      # TODO: Support "from __future__ import division".
       ast.FloorDiv: 'πg.FloorDiv(πF, {lhs}, {rhs})',
       ast.LShift: 'πg.LShift(πF, {lhs}, {rhs})',
       ast.Mod: 'πg.Mod(πF, {lhs}, {rhs})',
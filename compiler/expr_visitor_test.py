@@ -131,11 +131,26 @@ class ExprVisitorTest(unittest.TestCase):
   testCompareInStr = _MakeExprTest('"1" in "abc"')
   testCompareInTuple = _MakeExprTest('1 in (1, 2, 3)')
   testCompareNotInTuple = _MakeExprTest('10 < 12 not in (1, 2, 3)')
+  testCompareChainTrue = _MakeExprTest('1 < 2 < 3')
+  testCompareChainFalseFirst = _MakeExprTest('3 < 2 < 1 / 0')
+
+  def testCompareChainEvaluatesOnce(self):
+    self.assertEqual((0, "True\n['a', 'b', 'c']\n"),
+                     _GrumpRun(textwrap.dedent("""\
+        calls = []
+        def Operand(name, value):
+          calls.append(name)
+          return value
+        print Operand('a', 1) < Operand('b', 2) < Operand('c', 3)
+        print calls""")))
 
   testDictEmpty = _MakeLiteralTest('{}')
   testDictNonEmpty = _MakeLiteralTest("{'foo': 42, 'bar': 43}")
 
   testSetNonEmpty = _MakeLiteralTest("{'foo', 'bar'}", "set(['foo', 'bar'])")
+  testSetDuplicates = _MakeExprTest('{1, 2, 2, 3, 1} == set([1, 2, 3])')
+  testSetType = _MakeExprTest('type({1, 2}) is set')
+  testDictEmptyIsNotSet = _MakeExprTest('type({}) is dict')
 
   testDictCompFor = _MakeExprTest('{x: str(x) for x in range(3)}')
   testDictCompForIf = _MakeExprTest(
@@ -154,6 +169,13 @@ class ExprVisitorTest(unittest.TestCase):
   testIfExprNested = _MakeExprTest(
       '"foo" if "" else "bar" if 0 else "baz"')
 
+  def testIfExprShortCircuit(self):
+    self.assertEqual((0, 'foo\nbar\n'), _GrumpRun(textwrap.dedent("""\
+        def Boom():
+          raise AssertionError('unselected branch evaluated')
+        print Boom() if 0 else 'foo'
+        print 'bar' if 1 else Boom()""")))
+
   testLambda = _MakeExprTest('(lambda: 123)()')
   testLambda = _MakeExprTest('(lambda a, b: (a, b))("foo", "bar")')
   testLambda = _MakeExprTest('(lambda a, b=3: (a, b))("foo")')
@@ -181,6 +181,7 @@ _FUTURE_FEATURES = (
 
 _IMPLEMENTED_FUTURE_FEATURES = (
     'absolute_import',
+    'division',
     'print_function',
     'unicode_literals'
 )
@@ -263,6 +263,8 @@ class MakeFutureFeaturesTest(unittest.TestCase):
         ('from __future__ import generators', imputil.FutureFeatures()),
         ('from __future__ import generators, print_function',
          imputil.FutureFeatures(print_function=True)),
+        ('from __future__ import division',
+         imputil.FutureFeatures(division=True)),
     ]
 
     for tc in testcases:
@@ -274,10 +276,6 @@ class MakeFutureFeaturesTest(unittest.TestCase):
 
   def testImportFromFutureParseError(self):
     testcases = [
-        # NOTE: move this group to testImportFromFuture as they are implemented
-        # by grumpy
-        ('from __future__ import division',
-         r'future feature \w+ not yet implemented'),
         ('from __future__ import braces', 'not a chance'),
         ('from __future__ import nonexistant_feature',
          r'future feature \w+ is not defined'),
@@ -324,12 +322,6 @@ class ParseFutureFeaturesTest(unittest.TestCase):
       _, got = imputil.parse_future_features(mod)
       self.assertEqual(want.__dict__, got.__dict__)
 
-  def testUnimplementedFutureRaises(self):
-    mod = pythonparser.parse('from __future__ import division')
-    msg = 'future feature division not yet implemented by grumpy'
-    self.assertRaisesRegexp(util.ParseError, msg,
-                            imputil.parse_future_features, mod)
-
   def testUndefinedFutureRaises(self):
     mod = pythonparser.parse('from __future__ import foo')
     self.assertRaisesRegexp(
@@ -75,13 +75,32 @@ class StatementVisitor(algorithm.Visitor):
       fmt = 'augmented assignment op not implemented: {}'
       raise util.ParseError(node, fmt.format(op_type.__name__))
     self._write_py_context(node.lineno)
-    with self.visit_expr(node.target) as target,\
-        self.visit_expr(node.value) as value,\
-        self.block.alloc_temp() as temp:
-      self.writer.write_checked_call2(
-          temp, StatementVisitor._AUG_ASSIGN_TEMPLATES[op_type],
-          lhs=target.expr, rhs=value.expr)
-      self._assign_target(node.target, temp.expr)
+    target = node.target
+    if isinstance(target, ast.Attribute):
+      # As with subscripts below, the object must only be evaluated once.
+      with self.visit_expr(target.value) as obj,\
+          self.block.alloc_temp() as attr:
+        name = self.block.root.intern(target.attr)
+        self.writer.write_checked_call2(
+            attr, 'πg.GetAttr(πF, {}, {}, nil)', obj.expr, name)
+        with self._aug_assign_op(node, attr.expr) as result:
+          self.writer.write_checked_call1(
+              'πg.SetAttr(πF, {}, {}, {})', obj.expr, name, result.expr)
+    elif isinstance(target, ast.Subscript):
+      # The container and index must only be evaluated once, so hold on to
+      # them for the store rather than going through _assign_target.
+      with self.visit_expr(target.value) as mapping,\
+          self.visit_expr(target.slice) as index,\
+          self.block.alloc_temp() as item:
+        self.writer.write_checked_call2(
+            item, 'πg.GetItem(πF, {}, {})', mapping.expr, index.expr)
+        with self._aug_assign_op(node, item.expr) as result:
+          self.writer.write_checked_call1('πg.SetItem(πF, {}, {}, {})',
+                                          mapping.expr, index.expr, result.expr)
+    else:
+      with self.visit_expr(target) as lhs,\
+          self._aug_assign_op(node, lhs.expr) as result:
+        self._assign_target(target, result.expr)
 
   def visit_Assign(self, node):
     self._write_py_context(node.lineno)
@@ -109,6 +128,7 @@ class StatementVisitor(algorithm.Visitor):
         self.block, node.name, global_vars), self.future_node)
     # Indent so that the function body is aligned with the goto labels.
     with body_visitor.writer.indent_block():
+      body_visitor._write_docstring(node.body)  # pylint: disable=protected-access
       body_visitor._visit_each(node.body)  # pylint: disable=protected-access
 
     self._write_py_context(node.lineno)
@@ -136,6 +156,9 @@ class StatementVisitor(algorithm.Visitor):
                              filename=util.go_str(self.block.root.filename),
                              cls=cls.expr)
       with self.writer.indent_block():
+        if block_visitor.uses_locals:
+          self.writer.write('πF.SetLocals(func(*πg.Frame) (*πg.Dict, '
+                            '*πg.BaseException) { return πClass, nil })')
         self.writer.write_temp_decls(body_visitor.block)
         self.writer.write_block(body_visitor.block,
                                 body_visitor.writer.getvalue())
@@ -147,11 +170,19 @@ class StatementVisitor(algorithm.Visitor):
           if $meta, πE = $cls.GetItem(πF, $metaclass_str.ToObject()); πE != nil {
           \tcontinue
           }
+          if $meta == nil && len($bases) > 0 {
+          \t$meta = $bases[0].Type().ToObject()
+          }
+          if $meta == nil {
+          \tif $meta, πE = πF.Globals().GetItem(πF, $metaclass_str.ToObject()); πE != nil {
+          \t\tcontinue
+          \t}
+          }
           if $meta == nil {
           \t$meta = πg.TypeType.ToObject()
           }""")
       self.writer.write_tmpl(
-          tmpl, meta=meta.name, cls=cls.expr,
+          tmpl, meta=meta.name, cls=cls.expr, bases=bases.expr,
           metaclass_str=self.block.root.intern('__metaclass__'))
       with self.block.alloc_temp() as type_:
         type_expr = ('{}.Call(πF, []*πg.Object{{πg.NewStr({}).ToObject(), '
@@ -186,6 +217,14 @@ class StatementVisitor(algorithm.Visitor):
         msg = 'del target not implemented: {}'.format(type(target).__name__)
         raise util.ParseError(node, msg)
 
+  def visit_Exec(self, node):
+    self._write_py_context(node.lineno)
+    with self.visit_expr(node.body) as body,\
+        self.visit_expr(node.globals) if node.globals else _nil_expr as g,\
+        self.visit_expr(node.locals) if node.locals else _nil_expr as l:
+      self.writer.write_checked_call1(
+          'πg.Exec(πF, {}, {}, {})', body.expr, g.expr, l.expr)
+
   def visit_Expr(self, node):
     self._write_py_context(node.lineno)
     self.visit_expr(node.value).free()
@@ -196,8 +235,14 @@ class StatementVisitor(algorithm.Visitor):
     self._write_py_context(node.lineno)
     with self.visit_expr(node.iter) as iter_expr, \
         self.block.alloc_temp() as i, \
-        self.block.alloc_temp() as n:
+        self.block.alloc_temp() as n, \
+        self.block.alloc_temp('*πg.BaseException') as saved_exc, \
+        self.block.alloc_temp('*πg.Traceback') as saved_tb:
       self.writer.write_checked_call2(i, 'πg.Iter(πF, {})', iter_expr.expr)
+      # The StopIteration that terminates the loop clobbers the exc info so
+      # hang onto it in case we're running inside an except block.
+      self.writer.write('{}, {} = πF.ExcInfo()'.format(
+          saved_exc.name, saved_tb.name))
       self.writer.write_label(loop.start_label)
       tmpl = textwrap.dedent("""\
           if $n, πE = πg.Next(πF, $i); πE != nil {
@@ -210,10 +255,11 @@ class StatementVisitor(algorithm.Visitor):
           \t\tcontinue
           \t}
           \tπE = nil
-          \tπF.RestoreExc(nil, nil)
+          \tπF.RestoreExc($saved_exc, $saved_tb)
           \tgoto Label$orelse
           }""")
-      self.writer.write_tmpl(tmpl, n=n.name, i=i.expr, orelse=orelse_label)
+      self.writer.write_tmpl(tmpl, n=n.name, i=i.expr, orelse=orelse_label,
+                             saved_exc=saved_exc.expr, saved_tb=saved_tb.expr)
       self._tie_target(node.target, n.expr)
       self._visit_each(node.body)
       self.writer.write('goto Label{}'.format(loop.start_label))
@@ -317,6 +363,7 @@ class StatementVisitor(algorithm.Visitor):
         self._import_and_bind(imp)
 
   def visit_Module(self, node):
+    self._write_docstring(node.body)
     self._visit_each(node.body)
 
   def visit_Pass(self, node):
@@ -390,8 +437,16 @@ class StatementVisitor(algorithm.Visitor):
     if node.finalbody:
       self.writer.write('πF.PushCheckpoint({})'.format(finally_label))
     except_label = None
+    saved_exc = saved_tb = None
     if node.handlers:
       except_label = self.block.genlabel(is_checkpoint=True)
+      # Remember the exception being handled (if any) when the try statement
+      # is entered so that it can be restored once one of our handlers
+      # completes, e.g. when this try is nested inside another except block.
+      saved_exc = self.block.alloc_temp('*πg.BaseException')
+      saved_tb = self.block.alloc_temp('*πg.Traceback')
+      self.writer.write('{}, {} = πF.ExcInfo()'.format(
+          saved_exc.expr, saved_tb.expr))
       self.writer.write('πF.PushCheckpoint({})'.format(except_label))
     self._visit_each(node.body)
     if except_label:
@@ -404,10 +459,12 @@ class StatementVisitor(algorithm.Visitor):
 
     with self.block.alloc_temp('*πg.BaseException') as exc:
       if except_label:
+        saved = (saved_exc.expr, saved_tb.expr)
         if (len(node.handlers) == 1 and not node.handlers[0].type and
             not node.orelse):
           # When there's just a bare except, no dispatch is required.
-          self._write_except_block(except_label, exc.expr, node.handlers[0])
+          self._write_except_block(
+              except_label, exc.expr, node.handlers[0], saved)
           if node.finalbody:
             self.writer.write('πF.PopCheckpoint()')  # finally_label
           self.writer.write('goto Label{}'.format(finally_label))
@@ -420,18 +477,28 @@ class StatementVisitor(algorithm.Visitor):
 
           # Write the bodies of each of the except handlers.
           for handler_label, except_node in zip(handler_labels, node.handlers):
-            self._write_except_block(handler_label, exc.expr, except_node)
+            self._write_except_block(
+                handler_label, exc.expr, except_node, saved)
             if node.finalbody:
               self.writer.write('πF.PopCheckpoint()')  # finally_label
             self.writer.write('goto Label{}'.format(finally_label))
+        saved_exc.free()
+        saved_tb.free()
 
       # Write the finally body.
       self.writer.write_label(finally_label)
       if node.finalbody:
         with self.block.alloc_temp('*πg.Traceback') as tb:
-          self.writer.write('πE = nil')
-          self.writer.write('{}, {} = πF.RestoreExc(nil, nil)'.format(
-              exc.expr, tb.expr))
+          # The exc info is only cleared when the finally clause was reached
+          # because an exception is propagating. Otherwise it refers to an
+          # exception being handled by an enclosing except block and should
+          # be left alone.
+          self.writer.write_tmpl(textwrap.dedent("""\
+              $exc, $tb = nil, nil
+              if πE != nil {
+              \tπE = nil
+              \t$exc, $tb = πF.RestoreExc(nil, nil)
+              }"""), exc=exc.expr, tb=tb.expr)
           self._visit_each(node.finalbody)
           self.writer.write_tmpl(textwrap.dedent("""\
               if $exc != nil {
@@ -468,7 +535,9 @@ class StatementVisitor(algorithm.Visitor):
     # mgr := EXPR
     with self.visit_expr(item.context_expr) as mgr,\
         self.block.alloc_temp() as exit_func,\
-        self.block.alloc_temp() as value:
+        self.block.alloc_temp() as value,\
+        self.block.alloc_temp('*πg.BaseException') as saved_exc,\
+        self.block.alloc_temp('*πg.Traceback') as saved_tb:
       # The code here has a subtle twist: It gets the exit function attribute
       # from the class, not from the object. This matches the pseudo code from
       # PEP 343 exactly, and is very close to what CPython actually does.  (The
@@ -489,6 +558,8 @@ class StatementVisitor(algorithm.Visitor):
           value.expr, mgr.expr)
 
       finally_label = self.block.genlabel(is_checkpoint=True)
+      self.writer.write('{}, {} = πF.ExcInfo()'.format(
+          saved_exc.name, saved_tb.name))
       self.writer.write('πF.PushCheckpoint({})'.format(finally_label))
       if item.optional_vars:
         self._tie_target(item.optional_vars, value.expr)
@@ -502,8 +573,13 @@ class StatementVisitor(algorithm.Visitor):
           self.block.alloc_temp('*πg.Traceback') as tb,\
           self.block.alloc_temp('*πg.Type') as t:
         # temp := exit(mgr, *sys.exec_info())
+        # Only an exception propagating out of the body is passed to exit,
+        # not one being handled by an enclosing except block.
         tmpl = """\
-            $exc, $tb = πF.ExcInfo()
+            $exc, $tb = nil, nil
+            if πE != nil {
+            \t$exc, $tb = πF.ExcInfo()
+            }
             if $exc != nil {
             \t$t = $exc.Type()
             \tif $swallow_exc, πE = $exit_func.Call(πF, πg.Args{$mgr, $t.ToObject(), $exc.ToObject(), $tb.ToObject()}, nil); πE != nil {
@@ -529,7 +605,13 @@ class StatementVisitor(algorithm.Visitor):
             if $exc != nil && $swallow_exc != true {
             \tπE = πF.Raise(nil, nil, nil)
             \tcontinue
-            }"""), exc=exc.expr, swallow_exc=swallow_exc_bool.expr)
+            }
+            if $exc != nil {
+            \tπE = nil
+            \tπF.RestoreExc($saved_exc, $saved_tb)
+            }"""), exc=exc.expr, swallow_exc=swallow_exc_bool.expr,
+                               saved_exc=saved_exc.expr,
+                               saved_tb=saved_tb.expr)
 
   def visit_function_inline(self, node):
     """Returns an GeneratedExpr for a function with the given body."""
@@ -544,6 +626,10 @@ class StatementVisitor(algorithm.Visitor):
     visitor = StatementVisitor(func_block, self.future_node)
     # Indent so that the function body is aligned with the goto labels.
     with visitor.writer.indent_block():
+      for i, arg in enumerate(node.args.args):
+        if isinstance(arg, ast.Tuple):
+          # pylint: disable=protected-access
+          visitor._tie_target(_tuple_param_target(arg), 'πArgs[{}]'.format(i))
       visitor._visit_each(node.body)  # pylint: disable=protected-access
 
     result = self.block.alloc_temp()
@@ -557,9 +643,12 @@ class StatementVisitor(algorithm.Visitor):
       defaults = [None] * (argc - len(args.defaults)) + args.defaults
       for i, (a, d) in enumerate(zip(args.args, defaults)):
         with self.visit_expr(d) if d else expr.nil_expr as default:
+          # Like CPython, name tuple params by position so they can't be
+          # passed as keyword args.
+          name = '.{}'.format(i) if isinstance(a, ast.Tuple) else a.arg
           tmpl = '$args[$i] = πg.Param{Name: $name, Def: $default}'
           self.writer.write_tmpl(tmpl, args=func_args.expr, i=i,
-                                 name=util.go_str(a.arg), default=default.expr)
+                                 name=util.go_str(name), default=default.expr)
       flags = []
       if args.vararg:
         flags.append('πg.CodeFlagVarArg')
@@ -581,6 +670,14 @@ class StatementVisitor(algorithm.Visitor):
             self.writer.write(fmt.format(
                 util.adjust_local_name(var.name), var.init_expr))
         self.writer.write_temp_decls(func_block)
+        if func_visitor.uses_locals:
+          if node.name == '<generator>':
+            # Comprehensions are compiled as generator functions but like
+            # CPython 2 list comprehensions, locals() within them refers to
+            # the enclosing block.
+            self.writer.write('πF.InheritLocals()')
+          else:
+            self._write_set_locals(func_block)
         if func_block.is_generator:
           self.writer.write('return πg.NewGenerator(πF, func(πSent *πg.Object) '
                             '(*πg.Object, *πg.BaseException) {')
@@ -590,6 +687,12 @@ class StatementVisitor(algorithm.Visitor):
         else:
           self.writer.write_block(func_block, visitor.writer.getvalue())
       self.writer.write('}), πF.Globals()).ToObject()')
+    docstring = _get_docstring(node.body)
+    if docstring:
+      with self.visit_expr(docstring) as doc:
+        self.writer.write_checked_call1(
+            'πg.SetAttr(πF, {}, {}, {})', result.expr,
+            self.block.root.intern('__doc__'), doc.expr)
     return result
 
   _AUG_ASSIGN_TEMPLATES = {
@@ -607,6 +710,17 @@ class StatementVisitor(algorithm.Visitor):
       ast.BitXor: 'πg.IXor(πF, {lhs}, {rhs})',
   }
 
+  def _aug_assign_op(self, node, lhs):
+    """Writes the in-place operation of an AugAssign node applied to lhs."""
+    op_type = type(node.op)
+    tmpl = StatementVisitor._AUG_ASSIGN_TEMPLATES[op_type]
+    if op_type is ast.Div and self.block.root.future_features.division:
+      tmpl = 'πg.ITrueDiv(πF, {lhs}, {rhs})'
+    result = self.block.alloc_temp()
+    with self.visit_expr(node.value) as value:
+      self.writer.write_checked_call2(result, tmpl, lhs=lhs, rhs=value.expr)
+    return result
+
   def _assign_target(self, target, value):
     if isinstance(target, ast.Name):
       self.block.bind_var(self.writer, target.id, value)
@@ -733,7 +847,13 @@ class StatementVisitor(algorithm.Visitor):
     for node in nodes:
       self.visit(node)
 
-  def _write_except_block(self, label, exc, except_node):
+  def _write_docstring(self, body):
+    docstring = _get_docstring(body)
+    if docstring:
+      with self.visit_expr(docstring) as doc:
+        self.block.bind_var(self.writer, '__doc__', doc.expr)
+
+  def _write_except_block(self, label, exc, except_node, saved):
     self._write_py_context(except_node.lineno)
     self.writer.write_label(label)
     if except_node.name:
@@ -741,7 +861,9 @@ class StatementVisitor(algorithm.Visitor):
                           '{}.ToObject()'.format(exc))
     self._visit_each(except_node.body)
     self.writer.write('πE = nil')
-    self.writer.write('πF.RestoreExc(nil, nil)')
+    # Unlike CPython 2, which leaks the handled exception until the function
+    # returns, restore whatever was being handled before the try statement.
+    self.writer.write('πF.RestoreExc({}, {})'.format(*saved))
 
   def _write_except_dispatcher(self, exc, tb, handlers):
     """Outputs a Go code that jumps to the appropriate except handler.
@@ -787,3 +909,33 @@ class StatementVisitor(algorithm.Visitor):
       line = self.block.root.buffer.source_line(lineno).strip()
       self.writer.write('// line {}: {}'.format(lineno, line))
       self.writer.write('πF.SetLineno({})'.format(lineno))
+
+  def _write_set_locals(self, func_block):
+    names = [v.name for v in func_block.vars.values()
+             if v.type != block.Var.TYPE_GLOBAL]
+    self.writer.write(
+        'πF.SetLocals(πg.NewLocals([]string{{{}}}, func() []*πg.Object {{'
+        .format(', '.join(util.go_str(name) for name in names)))
+    with self.writer.indent_block():
+      self.writer.write('return []*πg.Object{{{}}}'.format(
+          ', '.join(util.adjust_local_name(name) for name in names)))
+    self.writer.write('}))')
+
+
+def _get_docstring(body):
+  """Returns the string literal node that begins body, if any."""
+  if body and isinstance(body[0], ast.Expr) and isinstance(body[0].value,
+                                                           ast.Str):
+    return body[0].value
+  return None
+
+
+def _tuple_param_target(param):
+  """Converts a tuple parameter like (a, (b, c)) into an assignment target."""
+  elts = []
+  for elt in param.elts:
+    if isinstance(elt, ast.Tuple):
+      elts.append(_tuple_param_target(elt))
+    else:
+      elts.append(ast.Name(id=elt.arg))
+  return ast.Tuple(elts=elts)
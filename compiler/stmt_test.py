@@ -109,18 +109,118 @@ class StatementVisitorTest(unittest.TestCase):
         foo **= 2
         print foo""")))
 
+  def testAugAssignAttr(self):
+    self.assertEqual((0, '[1, 2, 3]\n'), _GrumpRun(textwrap.dedent("""\
+        class Foo(object):
+          def __init__(self):
+            self.bar = [1]
+        foo = Foo()
+        foo.bar += [2, 3]
+        print foo.bar""")))
+
+  def testAugAssignAttrInPlace(self):
+    self.assertEqual((0, 'True\n'), _GrumpRun(textwrap.dedent("""\
+        class Foo(object):
+          pass
+        foo = Foo()
+        foo.bar = bar = [1]
+        foo.bar += [2]
+        print foo.bar is bar""")))
+
+  def testAugAssignAttrEvaluatedOnce(self):
+    self.assertEqual((0, "['obj', 'value']\n3\n"),
+                     _GrumpRun(textwrap.dedent("""\
+        calls = []
+        class Foo(object):
+          bar = 1
+        foo = Foo()
+        def obj():
+          calls.append('obj')
+          return foo
+        def value():
+          calls.append('value')
+          return 2
+        obj().bar += value()
+        print calls
+        print foo.bar""")))
+
+  def testAugAssignSubscriptDict(self):
+    self.assertEqual((0, "{'a': 3}\n"), _GrumpRun(textwrap.dedent("""\
+        foo = {'a': 1}
+        foo['a'] += 2
+        print foo""")))
+
+  def testAugAssignSubscriptList(self):
+    self.assertEqual((0, '[1, 6, 3]\n'), _GrumpRun(textwrap.dedent("""\
+        foo = [1, 2, 3]
+        foo[1] *= 3
+        print foo""")))
+
+  def testAugAssignSubscriptEvaluatedOnce(self):
+    self.assertEqual((0, "['obj', 'key', 'value']\n{'k': 2}\n"),
+                     _GrumpRun(textwrap.dedent("""\
+        calls = []
+        d = {'k': 1}
+        def obj():
+          calls.append('obj')
+          return d
+        def key():
+          calls.append('key')
+          return 'k'
+        def value():
+          calls.append('value')
+          return 1
+        obj()[key()] += value()
+        print calls
+        print d""")))
+
   def testClassDef(self):
     self.assertEqual((0, "<type 'type'>\n"), _GrumpRun(textwrap.dedent("""\
         class Foo(object):
           pass
         print type(Foo)""")))
 
+  def testClassDefDocstring(self):
+    self.assertEqual((0, "foo\nNone\n"), _GrumpRun(textwrap.dedent("""\
+        class Foo(object):
+          'foo'
+        class Bar(Foo):
+          pass
+        print Foo.__doc__
+        print Bar.__doc__""")))
+
   def testClassDefWithVar(self):
     self.assertEqual((0, 'abc\n'), _GrumpRun(textwrap.dedent("""\
         class Foo(object):
           bar = 'abc'
         print Foo.bar""")))
 
+  def testClassDefLocals(self):
+    self.assertEqual((0, "['__module__', 'bar']\nbaz\n"),
+                     _GrumpRun(textwrap.dedent("""\
+        class Foo(object):
+          bar = 42
+          print sorted(locals())
+          locals()['baz'] = 'baz'
+        print Foo.baz""")))
+
+  def testClassDefMetaclassFromBase(self):
+    self.assertEqual((0, 'Meta\n'), _GrumpRun(textwrap.dedent("""\
+        class Meta(type):
+          pass
+        class Foo(object):
+          __metaclass__ = Meta
+        class Bar(Foo):
+          pass
+        print type(Bar).__name__""")))
+
+  def testClassDefModuleMetaclass(self):
+    self.assertEqual((0, 'Foo\n'), _GrumpRun(textwrap.dedent("""\
+        __metaclass__ = lambda name, bases, dict: name
+        class Foo:
+          pass
+        print Foo""")))
+
   def testDeleteAttribute(self):
     self.assertEqual((0, 'False\n'), _GrumpRun(textwrap.dedent("""\
         class Foo(object):
@@ -164,6 +264,31 @@ class StatementVisitorTest(unittest.TestCase):
         del foo['bar']
         print foo""")))
 
+  def testExecCode(self):
+    self.assertEqual((0, '42 42\n'), _GrumpRun(textwrap.dedent("""\
+        def foo():
+          global bar
+          bar = 42
+        g = {}
+        exec foo.func_code in g
+        exec foo.func_code
+        print g['bar'], bar""")))
+
+  def testExecString(self):
+    self.assertEqual((0, 'NotImplementedError\n'), _GrumpRun(textwrap.dedent("""\
+        try:
+          exec 'foo = 42' in {}, {}
+        except NotImplementedError:
+          print 'NotImplementedError'""")))
+
+  def testExecBadGlobals(self):
+    self.assertEqual((0, 'exec: arg 2 must be a dictionary or None\n'),
+                     _GrumpRun(textwrap.dedent("""\
+        try:
+          exec 'foo = 42' in 123
+        except TypeError as e:
+          print e""")))
+
   def testExprCall(self):
     self.assertEqual((0, 'bar\n'), _GrumpRun(textwrap.dedent("""\
         def foo():
@@ -243,6 +368,16 @@ class StatementVisitorTest(unittest.TestCase):
           print a, b
         foo('bar', 'baz')""")))
 
+  def testFunctionDefDocstring(self):
+    self.assertEqual((0, "foo\nNone\n"), _GrumpRun(textwrap.dedent("""\
+        def foo():
+          '''foo'''
+        def bar():
+          pass
+          'bar'
+        print foo.__doc__
+        print bar.__doc__""")))
+
   def testFunctionDefGenerator(self):
     self.assertEqual((0, "['foo', 'bar']\n"), _GrumpRun(textwrap.dedent("""\
         def gen():
@@ -263,6 +398,39 @@ class StatementVisitorTest(unittest.TestCase):
           bar()
         foo()""")))
 
+  def testFunctionDefLocals(self):
+    self.assertEqual((0, "[('a', 1)]\n['a', 'b']\n"),
+                     _GrumpRun(textwrap.dedent("""\
+        def foo(a):
+          print sorted(locals().items())
+          b = 2
+          l = locals()
+          print sorted(l)
+        foo(1)""")))
+
+  def testFunctionDefTupleParam(self):
+    self.assertEqual((0, "1 2 3 4\n(5, 6) 7\n"), _GrumpRun(textwrap.dedent("""\
+        def foo((a, (b, c)), d):
+          print a, b, c, d
+        foo((1, [2, 3]), 4)
+        f = lambda (a, b)=(5, 6), c=7: ((a, b), c)
+        print f()[0], f()[1]""")))
+
+  def testFunctionDefTupleParamWrongLength(self):
+    self.assertEqual((0, 'need more than 1 value to unpack\n'), _GrumpRun(
+        textwrap.dedent("""\
+        def foo((a, b)):
+          pass
+        try:
+          foo((1,))
+        except ValueError as e:
+          print e""")))
+
+  def testFunctionDefTupleParamDuplicate(self):
+    self.assertRaisesRegexp(
+        util.ParseError, "duplicate argument 'a' in function definition",
+        _ParseAndVisit, 'def foo(a, (a, b)):\n  pass')
+
   def testIf(self):
     self.assertEqual((0, 'foo\n'), _GrumpRun(textwrap.dedent("""\
         if 123:
@@ -302,6 +470,19 @@ class StatementVisitorTest(unittest.TestCase):
     self.assertRaisesRegexp(util.ImportError, regexp, _ParseAndVisit,
                             'foo = bar\nfrom __future__ import print_function')
 
+  def testFutureDivision(self):
+    self.assertEqual((0, '2.5 2 2.5\n'), _GrumpRun(textwrap.dedent("""\
+        from __future__ import division
+        foo = 5
+        foo /= 2
+        print 5 / 2, 5 // 2, foo""")))
+
+  def testClassicDivision(self):
+    self.assertEqual((0, '2 2 2\n'), _GrumpRun(textwrap.dedent("""\
+        foo = 5
+        foo /= 2
+        print 5 / 2, 5 // 2, foo""")))
+
   def testFutureUnicodeLiterals(self):
     want = "u'foo'\n"
     self.assertEqual((0, want), _GrumpRun(textwrap.dedent("""\
@@ -347,6 +528,11 @@ class StatementVisitorTest(unittest.TestCase):
     self.assertRaisesRegexp(util.ImportError, regexp, _ParseAndVisit,
                             'from __go__.foo import *')
 
+  def testModuleDocstring(self):
+    self.assertEqual((0, 'foo\n'), _GrumpRun(textwrap.dedent("""\
+        'foo'
+        print __doc__""")))
+
   def testPrintStatement(self):
     self.assertEqual((0, 'abc 123\nfoo bar\n'), _GrumpRun(textwrap.dedent("""\
         print 'abc',
@@ -455,6 +641,48 @@ class StatementVisitorTest(unittest.TestCase):
     # Some platforms show "exit status 1" message so don't test strict equality.
     self.assertIn('foo bar\nfoo bar\nException\n', result[1])
 
+  def testTryExcInfoNested(self):
+    self.assertEqual((0, 'ValueError KeyError ValueError None\n'),
+                     _GrumpRun(textwrap.dedent("""\
+        import sys
+        def name():
+          t = sys.exc_info()[0]
+          return t.__name__ if t else None
+        def foo():
+          try:
+            raise ValueError
+          except ValueError:
+            print name(),
+            try:
+              raise KeyError
+            except KeyError:
+              print name(),
+            print name(),
+          print name()
+        foo()""")))
+
+  def testTryExcInfoRestoredByLoop(self):
+    self.assertEqual((0, 'ValueError\n'), _GrumpRun(textwrap.dedent("""\
+        try:
+          try:
+            raise ValueError
+          except ValueError:
+            for _ in (1, 2):
+              pass
+            raise
+        except Exception as e:
+          print type(e).__name__""")))
+
+  def testTryFinallyInExcept(self):
+    self.assertEqual((0, 'foo bar\n'), _GrumpRun(textwrap.dedent("""\
+        try:
+          raise ValueError
+        except ValueError:
+          try:
+            print 'foo',
+          finally:
+            print 'bar'""")))
+
   def testWhile(self):
     self.assertEqual((0, '2\n1\n'), _GrumpRun(textwrap.dedent("""\
         i = 2
@@ -492,6 +720,21 @@ class StatementVisitorTest(unittest.TestCase):
           print 3
         """)))
 
+  def testWithInExcept(self):
+    self.assertEqual((0, 'None\n'), _GrumpRun(textwrap.dedent("""\
+        class ContextManager(object):
+          def __enter__(self):
+            pass
+
+          def __exit__(self, exc_type, value, traceback):
+            print exc_type
+
+        try:
+          raise ValueError
+        except ValueError:
+          with ContextManager():
+            pass""")))
+
   def testWithAs(self):
     self.assertEqual((0, '1 2 3\n'),
                      _GrumpRun(textwrap.dedent("""\
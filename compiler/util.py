@@ -88,6 +88,13 @@ class Writer(object):
       body: String containing Go code making up the body of the code block.
     """
     self.write('var πE *πg.BaseException; _ = πE')
+    if block_.is_generator:
+      # A nil sent value means an exception was thrown into the generator
+      # and the frame has already been unwound to the innermost handler.
+      self.write('if πSent == nil {')
+      with self.indent_block():
+        self.write('πE, _ = πF.ExcInfo()')
+      self.write('}')
     self.write('for ; πF.State() >= 0; πF.PopCheckpoint() {')
     with self.indent_block():
       self.write('switch πF.State() {')
@@ -0,0 +1,51 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+"""Helpers for writing coroutines without the yield from statement."""
+
+import sys
+
+
+def _delegate(subgen):
+  """Yields from subgen, forwarding values and exceptions in both directions.
+
+  This is the PEP 380 expansion of ``yield from subgen``. Values sent to the
+  returned generator are sent to subgen and exceptions thrown into it are
+  thrown into subgen. Python 2 generators cannot return a value so by
+  convention subgen returns one by raising StopIteration(value). That
+  exception propagates out of the delegating generator untouched so callers,
+  including further _delegate layers, see the same value.
+  """
+  it = iter(subgen)
+  value = next(it)
+  while True:
+    try:
+      sent = yield value
+    except GeneratorExit:
+      close = getattr(it, 'close', None)
+      if close is not None:
+        close()
+      raise
+    except BaseException:
+      exc_info = sys.exc_info()
+      throw = getattr(it, 'throw', None)
+      if throw is None:
+        raise exc_info[0], exc_info[1], exc_info[2]
+      value = throw(*exc_info)
+    else:
+      if sent is None:
+        value = next(it)
+      else:
+        value = it.send(sent)
@@ -0,0 +1,27 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""Fast implementation of the pickle module.
+
+Grumpy has no C accelerated pickler so this simply re-exports pickle.
+"""
+
+import pickle
+
+# TODO: Support from foo import * syntax.
+for name in pickle.__all__:
+  globals()[name] = getattr(pickle, name)
+del name
+
+__all__ = pickle.__all__
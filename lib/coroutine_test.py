@@ -0,0 +1,97 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+import _coroutine
+import weetest
+
+
+def _Accumulate(log):
+  total = 0
+  try:
+    while True:
+      try:
+        value = yield total
+      except ValueError as e:
+        log.append(('caught', str(e)))
+        continue
+      if value is None:
+        raise StopIteration(total)
+      total += value
+  finally:
+    log.append('closed')
+
+
+def TestDelegateValues():
+  g = _coroutine._delegate(iter([1, 2, 3]))
+  assert list(g) == [1, 2, 3]
+  assert list(g) == []
+
+
+def TestDelegateSend():
+  log = []
+  g = _coroutine._delegate(_coroutine._delegate(_Accumulate(log)))
+  assert g.next() == 0
+  assert g.send(3) == 3
+  assert g.send(4) == 7
+  try:
+    g.next()
+  except StopIteration as e:
+    assert e.args == (7,), e.args
+  else:
+    raise AssertionError
+  assert log == ['closed']
+
+
+def TestDelegateThrow():
+  log = []
+  g = _coroutine._delegate(_coroutine._delegate(_Accumulate(log)))
+  g.next()
+  g.send(5)
+  assert g.throw(ValueError, 'foo') == 5
+  assert g.throw(ValueError('bar')) == 5
+  assert g.send(1) == 6
+  try:
+    g.throw(KeyError, 'baz')
+  except KeyError as e:
+    assert e.args == ('baz',), e.args
+  else:
+    raise AssertionError
+  assert log == [('caught', 'foo'), ('caught', 'bar'), 'closed']
+  assert list(g) == []
+
+
+def TestDelegateClose():
+  log = []
+  g = _coroutine._delegate(_coroutine._delegate(_Accumulate(log)))
+  g.next()
+  assert g.close() is None
+  assert log == ['closed']
+  assert list(g) == []
+
+
+def TestDelegateThrowNoThrowMethod():
+  g = _coroutine._delegate(iter([1, 2]))
+  g.next()
+  try:
+    g.throw(ValueError)
+  except ValueError:
+    pass
+  else:
+    raise AssertionError
+  assert list(g) == []
+
+
+if __name__ == '__main__':
+  weetest.RunTests()
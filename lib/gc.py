@@ -0,0 +1,65 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""Interface to the Go garbage collector.
+
+Grumpy objects are managed by the Go runtime so there are no reference counts
+or generations to inspect. This module maps the subset of CPython's gc API that
+makes sense onto Go's collector.
+"""
+
+from __go__.runtime import GC
+from __go__.runtime.debug import SetGCPercent
+
+
+_gc_percent = None
+
+
+def collect(generation=2):
+  """Run a full collection. The number of unreachable objects is unknown."""
+  if not 0 <= generation <= 2:
+    raise ValueError('invalid generation')
+  GC()
+  return 0
+
+
+def disable():
+  """Disable automatic garbage collection."""
+  global _gc_percent
+  if _gc_percent is None:
+    _gc_percent = SetGCPercent(-1)
+
+
+def enable():
+  """Enable automatic garbage collection."""
+  global _gc_percent
+  if _gc_percent is not None:
+    SetGCPercent(_gc_percent)
+    _gc_percent = None
+
+
+def isenabled():
+  """Returns true if automatic garbage collection is enabled."""
+  return _gc_percent is None
+
+
+def get_count():
+  """Returns the current collection counts. These are always zero."""
+  return (0, 0, 0)
+
+
+def get_threshold():
+  """Returns the current collection thresholds. These are CPython's defaults
+  and have no effect."""
+  return (700, 10, 10)
@@ -0,0 +1,57 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import gc
+
+import weetest
+
+
+def TestCollect():
+  assert gc.collect() >= 0
+  assert gc.collect(0) >= 0
+  try:
+    gc.collect(3)
+  except ValueError:
+    pass
+  else:
+    raise AssertionError
+
+
+def TestDisableEnable():
+  assert gc.isenabled()
+  gc.disable()
+  try:
+    assert not gc.isenabled()
+    gc.disable()
+    assert not gc.isenabled()
+    assert gc.collect() >= 0
+  finally:
+    gc.enable()
+  assert gc.isenabled()
+  gc.enable()
+  assert gc.isenabled()
+
+
+def TestGetCount():
+  count = gc.get_count()
+  assert isinstance(count, tuple) and len(count) == 3
+
+
+def TestGetThreshold():
+  threshold = gc.get_threshold()
+  assert isinstance(threshold, tuple) and len(threshold) == 3
+
+
+if __name__ == '__main__':
+  weetest.RunTests()
@@ -0,0 +1,104 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""Secure hash and message digest algorithms backed by Go's crypto packages."""
+
+from __go__.bytes import NewBuffer
+from __go__.crypto.md5 import New as _new_md5
+from __go__.crypto.sha1 import New as _new_sha1
+from __go__.crypto.sha256 import New as _new_sha256, New224 as _new_sha224
+from __go__.crypto.sha512 import New as _new_sha512, New384 as _new_sha384
+from __go__.encoding.hex import EncodeToString
+
+
+_constructors = {
+    'md5': _new_md5,
+    'sha1': _new_sha1,
+    'sha224': _new_sha224,
+    'sha256': _new_sha256,
+    'sha384': _new_sha384,
+    'sha512': _new_sha512,
+}
+
+algorithms = ('md5', 'sha1', 'sha224', 'sha256', 'sha384', 'sha512')
+
+__all__ = ('new', 'algorithms') + algorithms
+
+
+class _Hash(object):
+  """A hash object wrapping a Go hash.Hash."""
+
+  def __init__(self, name, h):
+    self.name = name
+    self._h = h
+    self.digest_size = h.Size()
+    self.block_size = h.BlockSize()
+
+  def update(self, data):
+    if isinstance(data, unicode):
+      data = str(data)
+    elif not isinstance(data, str):
+      raise TypeError('must be string or buffer, not %s' % type(data).__name__)
+    self._h.Write(data)
+
+  def digest(self):
+    return NewBuffer(self._h.Sum(None)).String()
+
+  def hexdigest(self):
+    return EncodeToString(self._h.Sum(None))
+
+  def copy(self):
+    h = _constructors[self.name]()
+    state, err = self._h.MarshalBinary()
+    if err:
+      raise ValueError(err.Error())
+    h.UnmarshalBinary(state)
+    return _Hash(self.name, h)
+
+
+def new(name, string=''):
+  """new(name, string='') - Return a new hashing object using the named
+  algorithm, optionally initialized with a string.
+  """
+  constructor = _constructors.get(name.lower())
+  if constructor is None:
+    raise ValueError('unsupported hash type ' + name)
+  h = _Hash(name.lower(), constructor())
+  if string:
+    h.update(string)
+  return h
+
+
+def md5(string=''):
+  return new('md5', string)
+
+
+def sha1(string=''):
+  return new('sha1', string)
+
+
+def sha224(string=''):
+  return new('sha224', string)
+
+
+def sha256(string=''):
+  return new('sha256', string)
+
+
+def sha384(string=''):
+  return new('sha384', string)
+
+
+def sha512(string=''):
+  return new('sha512', string)
@@ -0,0 +1,105 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import binascii
+import hashlib
+
+import weetest
+
+# Test vectors from FIPS 180-2 and RFC 1321.
+_ABC = 'abc'
+_LONG = 'abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq'
+_VECTORS = {
+    'md5': {
+        '': 'd41d8cd98f00b204e9800998ecf8427e',
+        _ABC: '900150983cd24fb0d6963f7d28e17f72',
+    },
+    'sha1': {
+        '': 'da39a3ee5e6b4b0d3255bfef95601890afd80709',
+        _ABC: 'a9993e364706816aba3e25717850c26c9cd0d89d',
+        _LONG: '84983e441c3bd26ebaae4aa1f95129e5e54670f1',
+    },
+    'sha224': {
+        _ABC: '23097d223405d8228642a477bda255b32aadbce4bda0b3f7e36c9da7',
+        _LONG: '75388b16512776cc5dba5da1fd890150b0c6455cb4f58b1952522525',
+    },
+    'sha256': {
+        _ABC: ('ba7816bf8f01cfea414140de5dae2223'
+               'b00361a396177a9cb410ff61f20015ad'),
+        _LONG: ('248d6a61d20638b8e5c026930c3e6039'
+                'a33ce45964ff2167f6ecedd419db06c1'),
+    },
+    'sha384': {
+        _ABC: ('cb00753f45a35e8bb5a03d699ac65007272c32ab0eded163'
+               '1a8b605a43ff5bed8086072ba1e7cc2358baeca134c825a7'),
+    },
+    'sha512': {
+        _ABC: ('ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a'
+               '2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f'),
+    },
+}
+
+
+def TestHexDigest():
+  for name, vectors in _VECTORS.iteritems():
+    for data, want in vectors.iteritems():
+      got = getattr(hashlib, name)(data).hexdigest()
+      assert got == want, (name, data, got)
+      got = hashlib.new(name, data).hexdigest()
+      assert got == want, (name, data, got)
+
+
+def TestDigest():
+  for name, vectors in _VECTORS.iteritems():
+    for data, want in vectors.iteritems():
+      h = hashlib.new(name, data)
+      assert binascii.hexlify(h.digest()) == want, name
+      assert len(h.digest()) == h.digest_size
+
+
+def TestUpdate():
+  h = hashlib.sha256()
+  for c in _LONG:
+    h.update(c)
+  assert h.hexdigest() == _VECTORS['sha256'][_LONG]
+
+
+def TestCopy():
+  h = hashlib.sha1('ab')
+  c = h.copy()
+  c.update('c')
+  assert h.hexdigest() == hashlib.sha1('ab').hexdigest()
+  assert c.hexdigest() == _VECTORS['sha1'][_ABC]
+
+
+def TestAttributes():
+  h = hashlib.md5()
+  assert h.name == 'md5'
+  assert h.digest_size == 16
+  assert h.block_size == 64
+  assert hashlib.sha512().digest_size == 64
+  assert hashlib.sha512().block_size == 128
+
+
+def TestNewUnsupported():
+  try:
+    hashlib.new('foo')
+  except ValueError:
+    pass
+  else:
+    raise AssertionError
+
+
+if __name__ == '__main__':
+  weetest.RunTests()
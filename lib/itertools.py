@@ -124,7 +124,7 @@ def ifilterfalse(predicate, iterable):
 def imap(function, *iterables):
   iterables = map(iter, iterables)
   while True:
-    args = [next(it) for it in iterables]
+    args = map(next, iterables)
     if function is None:
       yield tuple(args)
     else:
@@ -133,7 +133,8 @@ def imap(function, *iterables):
 
 def islice(iterable, *args):
   s = slice(*args)
-  it = iter(xrange(s.start or 0, s.stop or sys.maxint, s.step or 1))
+  stop = sys.maxint if s.stop is None else s.stop
+  it = iter(xrange(s.start or 0, stop, s.step or 1))
   nexti = next(it)
   for i, element in enumerate(iterable):
     if i == nexti:
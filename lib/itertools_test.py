@@ -103,6 +103,7 @@ def TestISlice():
   cases = [
       ((r, 5), (0, 1, 2, 3, 4)),
       ((r, 25, 30), ()),
+      ((r, 0), ()),
       ((r, 1, None, 3), (1, 4, 7)),
   ]
   for args, want in cases:
@@ -78,8 +78,8 @@ def floor(x):
     return Floor(float(x))
 
 
-def fmod(x):
-    return Mod(float(x))
+def fmod(x, y):
+    return Mod(float(x), float(y))
 
 
 def frexp(x):
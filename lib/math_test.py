@@ -45,6 +45,15 @@ def TestFactorialError():
     raise AssertionError
 
 
+def TestFmod():
+  # Unlike %, the result of fmod takes the sign of the dividend.
+  assert math.fmod(7, 3) == 1.0
+  assert math.fmod(-7, 3) == -1.0
+  assert math.fmod(7, -3) == 1.0
+  assert math.fmod(-7, -3) == -1.0
+  assert math.fmod(7.5, 2) == 1.5
+
+
 def TestLdexp():
   assert math.ldexp(1,1) == 2
   assert math.ldexp(1,2) == 4
@@ -68,5 +68,15 @@ def exc_info():
   return t, e, tb
 
 
+def _getframe(depth=0):
+  f = __frame__().f_back  # pylint: disable=undefined-variable
+  while depth > 0 and f is not None:
+    f = f.f_back
+    depth -= 1
+  if f is None:
+    raise ValueError('call stack is not deep enough')
+  return f
+
+
 def exit(code=None):  # pylint: disable=redefined-builtin
   raise SystemExit(code)
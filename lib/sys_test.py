@@ -32,6 +32,29 @@ def TestSysModules():
   assert sys.modules['sys'] is not None
 
 
+def TestSysModulesStub():
+  import stat  # pylint: disable=g-import-not-at-top
+  stub = types.ModuleType('stat')
+  sys.modules['stat'] = stub
+  try:
+    import stat as stat2  # pylint: disable=g-import-not-at-top
+    assert stat2 is stub
+  finally:
+    sys.modules['stat'] = stat
+
+
+def TestSysModulesDeleteReimports():
+  import stat  # pylint: disable=g-import-not-at-top
+  del sys.modules['stat']
+  try:
+    import stat as stat2  # pylint: disable=g-import-not-at-top
+    assert stat2 is not stat
+    assert sys.modules['stat'] is stat2
+    assert stat2.S_ISDIR is not stat.S_ISDIR
+  finally:
+    sys.modules['stat'] = stat
+
+
 def TestExcClear():
   try:
     raise RuntimeError
@@ -86,6 +109,22 @@ def TestExitInvalidArgs():
     assert False
 
 
+def TestGetFrame():
+  f = sys._getframe()  # pylint: disable=protected-access
+  assert f.f_code.co_name == 'TestGetFrame', f.f_code.co_name
+  assert f.f_globals is globals()
+  assert sys._getframe(1) is f.f_back  # pylint: disable=protected-access
+
+
+def TestGetFrameTooDeep():
+  try:
+    sys._getframe(sys.maxint)  # pylint: disable=protected-access
+  except ValueError as e:
+    assert str(e) == 'call stack is not deep enough', str(e)
+  else:
+    assert False
+
+
 if __name__ == '__main__':
   # This call will incidentally test sys.exit().
   weetest.RunTests()
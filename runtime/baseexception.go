@@ -21,7 +21,7 @@ import (
 // BaseException represents Python 'BaseException' objects.
 type BaseException struct {
 	Object
-	args *Tuple
+	args *Tuple `attr:"args"`
 }
 
 func toBaseExceptionUnsafe(o *Object) *BaseException {
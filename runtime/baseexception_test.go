@@ -39,6 +39,21 @@ func TestBaseExceptionCreate(t *testing.T) {
 	}
 }
 
+func TestBaseExceptionArgs(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, o *Object) (*Object, *BaseException) {
+		return GetAttr(f, o, NewStr("args"), nil)
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(mustCreateException(ExceptionType, "")), want: NewTuple().ToObject()},
+		{args: wrapArgs(mustNotRaise(StopIterationType.Call(NewRootFrame(), wrapArgs(123, "foo"), nil))), want: newTestTuple(123, "foo").ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestBaseExceptionInitRaise(t *testing.T) {
 	cas := invokeTestCase{
 		args:    nil,
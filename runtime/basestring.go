@@ -15,7 +15,12 @@
 package grumpy
 
 import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -45,8 +50,18 @@ var (
 		'\r': `\r`,
 		'\t': `\t`,
 	}
+	// hashSecret perturbs the hashes of str and unicode objects, like
+	// CPython's _Py_HashSecret. It is zero, disabling randomization, unless
+	// PYTHONHASHSEED is set.
+	hashSecret = mustLoadHashSecret()
 )
 
+// basestringHashSecret is the pair of values mixed into the hashes of str and
+// unicode objects.
+type basestringHashSecret struct {
+	prefix, suffix int
+}
+
 func initBaseStringType(map[string]*Object) {
 	BaseStringType.flags &^= typeFlagInstantiable
 }
@@ -74,3 +89,48 @@ func escapeRune(r rune) []byte {
 		hexTable[r>>12&0x0F], hexTable[r>>8&0x0F],
 		hexTable[r>>4&0x0F], hexTable[r&0x0F]}
 }
+
+func mustLoadHashSecret() basestringHashSecret {
+	secret, err := newHashSecret(os.Getenv("PYTHONHASHSEED"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Fatal Python error: %s\n", err)
+		os.Exit(1)
+	}
+	return secret
+}
+
+// newHashSecret returns the hash secret for the given PYTHONHASHSEED value.
+// An empty seed or "0" disables randomization, "random" draws the secret from
+// the OS's random source and any other integer up to 2**32-1 seeds the same
+// generator CPython uses so that hashes match those of CPython.
+func newHashSecret(seed string) (basestringHashSecret, error) {
+	var secret basestringHashSecret
+	if seed == "" {
+		return secret, nil
+	}
+	buf := make([]byte, 2*strconv.IntSize/8)
+	if seed == "random" {
+		if _, err := rand.Read(buf); err != nil {
+			return secret, err
+		}
+	} else {
+		x, err := strconv.ParseUint(seed, 10, 32)
+		if err != nil {
+			return secret, errors.New(`PYTHONHASHSEED must be "random" or an integer in range [0; 4294967295]`)
+		}
+		if x == 0 {
+			return secret, nil
+		}
+		// Borrowed from lcg_urandom() in CPython's random.c.
+		for i := range buf {
+			x = (x*214013 + 2531011) & 0xffffffff
+			buf[i] = byte(x >> 16)
+		}
+	}
+	half := len(buf) / 2
+	for i := half - 1; i >= 0; i-- {
+		secret.prefix = secret.prefix<<8 | int(buf[i])
+		secret.suffix = secret.suffix<<8 | int(buf[half+i])
+	}
+	return secret, nil
+}
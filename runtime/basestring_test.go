@@ -15,6 +15,7 @@
 package grumpy
 
 import (
+	"strconv"
 	"testing"
 )
 
@@ -55,3 +56,66 @@ func BenchmarkEscapeRune(b *testing.B) {
 		}
 	})
 }
+
+func TestNewHashSecret(t *testing.T) {
+	cases := []struct {
+		seed    string
+		want    basestringHashSecret
+		wantErr bool
+	}{
+		{seed: ""},
+		{seed: "0"},
+		{seed: "-1", wantErr: true},
+		{seed: "4294967296", wantErr: true},
+		{seed: "foo", wantErr: true},
+	}
+	for _, cas := range cases {
+		got, err := newHashSecret(cas.seed)
+		if (err != nil) != cas.wantErr || got != cas.want {
+			t.Errorf("newHashSecret(%q) = (%v, %v), want %v with error %v", cas.seed, got, err, cas.want, cas.wantErr)
+		}
+	}
+	a, err := newHashSecret("random")
+	if err != nil {
+		t.Fatalf(`newHashSecret("random") failed: %v`, err)
+	}
+	b, err := newHashSecret("random")
+	if err != nil {
+		t.Fatalf(`newHashSecret("random") failed: %v`, err)
+	}
+	if a == b {
+		t.Errorf(`newHashSecret("random") returned %v twice`, a)
+	}
+}
+
+func TestHashSecretPerturbsStrHash(t *testing.T) {
+	saved := hashSecret
+	defer func() { hashSecret = saved }()
+	// Expected values come from CPython 2.7 on a 64 bit platform run with
+	// the same PYTHONHASHSEED.
+	cases := []struct {
+		seed string
+		s    string
+		want int64
+	}{
+		{"0", "abc", 1453079729188098211},
+		{"0", "", 0},
+		{"42", "abc", -4410911502303878509},
+		{"42", "a", -5486454195961207828},
+		{"42", "", 0},
+		{"4294967295", "abc", -4091943417118692},
+	}
+	for _, cas := range cases {
+		var err error
+		if hashSecret, err = newHashSecret(cas.seed); err != nil {
+			t.Fatalf("newHashSecret(%q) failed: %v", cas.seed, err)
+		}
+		if got := hashString(cas.s); strconv.IntSize == 64 && int64(got) != cas.want {
+			t.Errorf("with PYTHONHASHSEED=%s hash(%q) = %d, want %d", cas.seed, cas.s, got, cas.want)
+		}
+		h, raised := Hash(NewRootFrame(), NewUnicode(cas.s).ToObject())
+		if raised != nil || h.Value() != hashString(cas.s) {
+			t.Errorf("with PYTHONHASHSEED=%s hash(u%q) = (%v, %v), want %d", cas.seed, cas.s, h, raised, hashString(cas.s))
+		}
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grumpy
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BufferType is the object representing the Python 'buffer' type.
+var BufferType = newBasisType("buffer", reflect.TypeOf(Buffer{}), toBufferUnsafe, ObjectType)
+
+// Buffer represents Python 'buffer' objects, read-only views over a range of
+// the bytes of another object. Currently only str objects are supported as the
+// underlying object.
+type Buffer struct {
+	Object
+	base   *Object
+	offset int
+	// size is the requested size of the view or -1 if the view extends to
+	// the end of base.
+	size int
+}
+
+func toBufferUnsafe(o *Object) *Buffer {
+	return (*Buffer)(o.toPointer())
+}
+
+// ToObject upcasts b to an Object.
+func (b *Buffer) ToObject() *Object {
+	return &b.Object
+}
+
+// Value returns the bytes viewed by b.
+func (b *Buffer) Value() string {
+	s := toStrUnsafe(b.base).Value()
+	if b.offset >= len(s) {
+		return ""
+	}
+	s = s[b.offset:]
+	if b.size >= 0 && b.size < len(s) {
+		s = s[:b.size]
+	}
+	return s
+}
+
+func bufferAdd(f *Frame, v, w *Object) (*Object, *BaseException) {
+	var s string
+	switch {
+	case w.isInstance(StrType):
+		s = toStrUnsafe(w).Value()
+	case w.isInstance(BufferType):
+		s = toBufferUnsafe(w).Value()
+	default:
+		return nil, f.RaiseType(TypeErrorType, "bad argument type for built-in operation")
+	}
+	return NewStr(toBufferUnsafe(v).Value() + s).ToObject(), nil
+}
+
+func bufferEq(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return bufferCompare(v, w, False, True, False), nil
+}
+
+func bufferGE(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return bufferCompare(v, w, False, True, True), nil
+}
+
+func bufferGetItem(f *Frame, o, key *Object) (*Object, *BaseException) {
+	s := toBufferUnsafe(o).Value()
+	if key.typ.slots.Index != nil {
+		index, raised := IndexInt(f, key)
+		if raised != nil {
+			return nil, raised
+		}
+		if index < 0 {
+			index += len(s)
+		}
+		if index < 0 || index >= len(s) {
+			return nil, f.RaiseType(IndexErrorType, "buffer index out of range")
+		}
+		return NewStr(s[index : index+1]).ToObject(), nil
+	}
+	return strGetItem(f, NewStr(s).ToObject(), key)
+}
+
+func bufferGT(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return bufferCompare(v, w, False, False, True), nil
+}
+
+func bufferHash(f *Frame, o *Object) (*Object, *BaseException) {
+	return NewInt(hashString(toBufferUnsafe(o).Value())).ToObject(), nil
+}
+
+func bufferLE(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return bufferCompare(v, w, True, True, False), nil
+}
+
+func bufferLen(f *Frame, o *Object) (*Object, *BaseException) {
+	return NewInt(len(toBufferUnsafe(o).Value())).ToObject(), nil
+}
+
+func bufferLT(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return bufferCompare(v, w, True, False, False), nil
+}
+
+func bufferMul(f *Frame, v, w *Object) (*Object, *BaseException) {
+	s := toBufferUnsafe(v).Value()
+	n, ok, raised := strRepeatCount(f, len(s), w)
+	if raised != nil {
+		return nil, raised
+	}
+	if !ok {
+		return NotImplemented, nil
+	}
+	return NewStr(strings.Repeat(s, n)).ToObject(), nil
+}
+
+func bufferNE(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return bufferCompare(v, w, True, False, True), nil
+}
+
+func bufferNew(f *Frame, t *Type, args Args, _ KWArgs) (*Object, *BaseException) {
+	expectedTypes := []*Type{ObjectType, IntType, IntType}
+	argc := len(args)
+	if argc >= 1 && argc < 3 {
+		expectedTypes = expectedTypes[:argc]
+	}
+	if raised := checkFunctionArgs(f, "buffer", args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	base, offset, size := args[0], 0, -1
+	if argc > 1 {
+		offset = toIntUnsafe(args[1]).Value()
+		if offset < 0 {
+			return nil, f.RaiseType(ValueErrorType, "offset must be zero or positive")
+		}
+	}
+	if argc > 2 {
+		size = toIntUnsafe(args[2]).Value()
+		if size < -1 {
+			return nil, f.RaiseType(ValueErrorType, "size must be zero or positive")
+		}
+	}
+	switch {
+	case base.isInstance(BufferType):
+		// Views of views refer directly to the innermost object.
+		b := toBufferUnsafe(base)
+		if b.size >= 0 {
+			avail := b.size - offset
+			if avail < 0 {
+				avail = 0
+			}
+			if size < 0 || size > avail {
+				size = avail
+			}
+		}
+		base, offset = b.base, b.offset+offset
+	case !base.isInstance(StrType):
+		return nil, f.RaiseType(TypeErrorType, "buffer object expected")
+	}
+	b := &Buffer{Object: Object{typ: t}, base: base, offset: offset, size: size}
+	return b.ToObject(), nil
+}
+
+func bufferRepr(f *Frame, o *Object) (*Object, *BaseException) {
+	b := toBufferUnsafe(o)
+	s := fmt.Sprintf("<read-only buffer for %p, size %d, offset %d at %p>", b.base, b.size, b.offset, b)
+	return NewStr(s).ToObject(), nil
+}
+
+func bufferSetItem(f *Frame, o, key, value *Object) *BaseException {
+	return f.RaiseType(TypeErrorType, "buffer is read-only")
+}
+
+func bufferStr(f *Frame, o *Object) (*Object, *BaseException) {
+	return NewStr(toBufferUnsafe(o).Value()).ToObject(), nil
+}
+
+func initBufferType(map[string]*Object) {
+	BufferType.flags &^= typeFlagBasetype
+	BufferType.slots.Add = &binaryOpSlot{bufferAdd}
+	BufferType.slots.Eq = &binaryOpSlot{bufferEq}
+	BufferType.slots.GE = &binaryOpSlot{bufferGE}
+	BufferType.slots.GetItem = &binaryOpSlot{bufferGetItem}
+	BufferType.slots.GT = &binaryOpSlot{bufferGT}
+	BufferType.slots.Hash = &unaryOpSlot{bufferHash}
+	BufferType.slots.LE = &binaryOpSlot{bufferLE}
+	BufferType.slots.Len = &unaryOpSlot{bufferLen}
+	BufferType.slots.LT = &binaryOpSlot{bufferLT}
+	BufferType.slots.Mul = &binaryOpSlot{bufferMul}
+	BufferType.slots.NE = &binaryOpSlot{bufferNE}
+	BufferType.slots.New = &newSlot{bufferNew}
+	BufferType.slots.Repr = &unaryOpSlot{bufferRepr}
+	BufferType.slots.SetItem = &setItemSlot{bufferSetItem}
+	BufferType.slots.Str = &unaryOpSlot{bufferStr}
+}
+
+func bufferCompare(v, w *Object, ltResult, eqResult, gtResult *Int) *Object {
+	if v == w {
+		return eqResult.ToObject()
+	}
+	if !w.isInstance(BufferType) {
+		return NotImplemented
+	}
+	s1 := toBufferUnsafe(v).Value()
+	s2 := toBufferUnsafe(w).Value()
+	if s1 < s2 {
+		return ltResult.ToObject()
+	}
+	if s1 == s2 {
+		return eqResult.ToObject()
+	}
+	return gtResult.ToObject()
+}
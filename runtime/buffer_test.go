@@ -0,0 +1,116 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grumpy
+
+import (
+	"testing"
+)
+
+func TestBufferNew(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, args ...*Object) (*Object, *BaseException) {
+		b, raised := BufferType.Call(f, args, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		s, raised := ToStr(f, b)
+		if raised != nil {
+			return nil, raised
+		}
+		return s.ToObject(), nil
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs("hello world"), want: NewStr("hello world").ToObject()},
+		{args: wrapArgs("hello world", 6), want: NewStr("world").ToObject()},
+		{args: wrapArgs("hello world", 0, 5), want: NewStr("hello").ToObject()},
+		{args: wrapArgs("hello world", 6, 100), want: NewStr("world").ToObject()},
+		{args: wrapArgs("abc", 10), want: NewStr("").ToObject()},
+		{args: wrapArgs(newBuffer("abcdef", 1, -1), 1, 2), want: NewStr("cd").ToObject()},
+		{args: wrapArgs(newBuffer("abcdef", 1, 3), 1), want: NewStr("cd").ToObject()},
+		{args: wrapArgs("abc", -1), wantExc: mustCreateException(ValueErrorType, "offset must be zero or positive")},
+		{args: wrapArgs("abc", 0, -2), wantExc: mustCreateException(ValueErrorType, "size must be zero or positive")},
+		{args: wrapArgs(123), wantExc: mustCreateException(TypeErrorType, "buffer object expected")},
+		{args: wrapArgs(NewList()), wantExc: mustCreateException(TypeErrorType, "buffer object expected")},
+		{args: wrapArgs("abc", "foo"), wantExc: mustCreateException(TypeErrorType, "'buffer' requires a 'int' object but received a \"str\"")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestBufferBinaryOps(t *testing.T) {
+	cases := []struct {
+		fun     func(f *Frame, v, w *Object) (*Object, *BaseException)
+		v, w    *Object
+		want    *Object
+		wantExc *BaseException
+	}{
+		{Add, newBuffer("hello world", 6, -1).ToObject(), NewStr("!").ToObject(), NewStr("world!").ToObject(), nil},
+		{Add, newBuffer("foo", 0, -1).ToObject(), newBuffer("bar", 1, -1).ToObject(), NewStr("fooar").ToObject(), nil},
+		{Add, newBuffer("foo", 0, -1).ToObject(), NewInt(1).ToObject(), nil, mustCreateException(TypeErrorType, "bad argument type for built-in operation")},
+		{Add, NewStr("foo").ToObject(), newBuffer("bar", 0, -1).ToObject(), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for +: 'str' and 'buffer'")},
+		{Mul, newBuffer("abc", 1, -1).ToObject(), NewInt(2).ToObject(), NewStr("bcbc").ToObject(), nil},
+		{Eq, newBuffer("hello", 0, -1).ToObject(), newBuffer("hello world", 0, 5).ToObject(), True.ToObject(), nil},
+		{Eq, newBuffer("hello", 0, -1).ToObject(), NewStr("hello").ToObject(), False.ToObject(), nil},
+		{LT, newBuffer("abc", 0, -1).ToObject(), newBuffer("abd", 0, -1).ToObject(), True.ToObject(), nil},
+	}
+	for _, cas := range cases {
+		testCase := invokeTestCase{args: wrapArgs(cas.v, cas.w), want: cas.want, wantExc: cas.wantExc}
+		if err := runInvokeTestCase(wrapFuncForTest(cas.fun), &testCase); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestBufferGetItem(t *testing.T) {
+	b := newBuffer("hello world", 6, -1)
+	cases := []invokeTestCase{
+		{args: wrapArgs(b, 0), want: NewStr("w").ToObject()},
+		{args: wrapArgs(b, -1), want: NewStr("d").ToObject()},
+		{args: wrapArgs(b, newTestSlice(1, 3)), want: NewStr("or").ToObject()},
+		{args: wrapArgs(b, newTestSlice(None, None, -1)), want: NewStr("dlrow").ToObject()},
+		{args: wrapArgs(b, 5), wantExc: mustCreateException(IndexErrorType, "buffer index out of range")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(wrapFuncForTest(GetItem), &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestBufferLen(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(newBuffer("hello world", 0, -1)), want: NewInt(11).ToObject()},
+		{args: wrapArgs(newBuffer("hello world", 6, 2)), want: NewInt(2).ToObject()},
+		{args: wrapArgs(newBuffer("abc", 5, -1)), want: NewInt(0).ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(BufferType, "__len__", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestBufferSetItem(t *testing.T) {
+	cas := invokeTestCase{args: wrapArgs(newBuffer("abc", 0, -1), 0, "x"), wantExc: mustCreateException(TypeErrorType, "buffer is read-only")}
+	if err := runInvokeMethodTestCase(BufferType, "__setitem__", &cas); err != "" {
+		t.Error(err)
+	}
+}
+
+func newBuffer(s string, offset, size int) *Buffer {
+	return &Buffer{Object: Object{typ: BufferType}, base: NewStr(s).ToObject(), offset: offset, size: size}
+}
@@ -20,6 +20,7 @@ import (
 	"math/big"
 	"strings"
 	"unicode"
+	"unsafe"
 )
 
 var (
@@ -104,6 +105,8 @@ var builtinTypes = map[*Type]*builtinTypeInfo{
 	BaseExceptionType:             {init: initBaseExceptionType, global: true},
 	BaseStringType:                {init: initBaseStringType, global: true},
 	BoolType:                      {init: initBoolType, global: true},
+	BufferType:                    {init: initBufferType, global: true},
+	ByteArrayType:                 {init: initByteArrayType, global: true},
 	BytesWarningType:              {global: true},
 	CodeType:                      {},
 	ComplexType:                   {init: initComplexType, global: true},
@@ -124,6 +127,7 @@ var builtinTypes = map[*Type]*builtinTypeInfo{
 	FrozenSetType:                 {init: initFrozenSetType, global: true},
 	FunctionType:                  {init: initFunctionType},
 	FutureWarningType:             {global: true},
+	GeneratorExitType:             {global: true},
 	GeneratorType:                 {init: initGeneratorType},
 	ImportErrorType:               {global: true},
 	ImportWarningType:             {global: true},
@@ -153,6 +157,7 @@ var builtinTypes = map[*Type]*builtinTypeInfo{
 	PendingDeprecationWarningType: {global: true},
 	PropertyType:                  {init: initPropertyType, global: true},
 	rangeIteratorType:             {init: initRangeIteratorType, global: true},
+	reversedType:                  {init: initReversedType, global: true},
 	ReferenceErrorType:            {global: true},
 	RuntimeErrorType:              {global: true},
 	RuntimeWarningType:            {global: true},
@@ -282,6 +287,36 @@ func builtinAny(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	return GetBool(foundTrueItem).ToObject(), raised
 }
 
+func builtinApply(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	argc := len(args)
+	if argc < 1 || argc > 3 {
+		bound := "least 1"
+		if argc > 3 {
+			bound = "most 3"
+		}
+		format := "apply expected at %s arguments, got %d"
+		return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, bound, argc))
+	}
+	var varargs, kwargs *Object
+	if argc > 1 {
+		varargs = args[1]
+		// Like CPython, anything other than a tuple must be a sequence,
+		// which rules out dicts, sets and other plain iterables.
+		if t := varargs.typ; !varargs.isInstance(TupleType) && (t.slots.GetItem == nil || varargs.isInstance(DictType)) {
+			format := "apply() arg 2 expected sequence, found %s"
+			return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, t.Name()))
+		}
+	}
+	if argc > 2 {
+		kwargs = args[2]
+		if !kwargs.isInstance(DictType) {
+			format := "apply() arg 3 expected dictionary, found %s"
+			return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, kwargs.typ.Name()))
+		}
+	}
+	return Invoke(f, args[0], nil, varargs, nil, kwargs)
+}
+
 func builtinBin(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	if raised := checkFunctionArgs(f, "bin", args, ObjectType); raised != nil {
 		return nil, raised
@@ -309,10 +344,16 @@ func builtinCallable(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 }
 
 func builtinChr(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
-	if raised := checkFunctionArgs(f, "chr", args, IntType); raised != nil {
+	if raised := checkFunctionArgs(f, "chr", args, ObjectType); raised != nil {
+		return nil, raised
+	}
+	if args[0].isInstance(FloatType) {
+		return nil, f.RaiseType(TypeErrorType, "integer argument expected, got float")
+	}
+	i, raised := ToIntValue(f, args[0])
+	if raised != nil {
 		return nil, raised
 	}
-	i := toIntUnsafe(args[0]).Value()
 	if i < 0 || i > 255 {
 		return nil, f.RaiseType(ValueErrorType, "chr() arg not in range(256)")
 	}
@@ -326,6 +367,79 @@ func builtinCmp(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	return Compare(f, args[0], args[1])
 }
 
+// builtinCoerce implements the coerce() builtin. x.__coerce__(y) and then
+// y.__coerce__(x) are tried in turn. Operands of the same type that don't
+// define __coerce__ are returned unchanged.
+func builtinCoerce(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkFunctionArgs(f, "coerce", args, ObjectType, ObjectType); raised != nil {
+		return nil, raised
+	}
+	x, y := args[0], args[1]
+	for _, swapped := range []bool{false, true} {
+		v, w := x, y
+		if swapped {
+			v, w = y, x
+		}
+		coerce, raised := v.typ.mroLookup(f, NewStr("__coerce__"))
+		if raised != nil {
+			return nil, raised
+		}
+		if coerce == nil {
+			if x.typ == y.typ {
+				return NewTuple2(x, y).ToObject(), nil
+			}
+			continue
+		}
+		r, raised := coerce.Call(f, Args{v, w}, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		if r == NotImplemented {
+			continue
+		}
+		if !r.isInstance(TupleType) || len(toTupleUnsafe(r).elems) != 2 {
+			return nil, f.RaiseType(TypeErrorType, "__coerce__ didn't return a 2-tuple")
+		}
+		if swapped {
+			elems := toTupleUnsafe(r).elems
+			r = NewTuple2(elems[1], elems[0]).ToObject()
+		}
+		return r, nil
+	}
+	return nil, f.RaiseType(TypeErrorType, "number coercion failed")
+}
+
+// builtinCompile implements the compile() builtin. Grumpy translates Python
+// to Go ahead of time and a running program has neither the compiler nor a Go
+// toolchain, so source can't be turned into a code object at runtime. The
+// arguments are still validated like CPython before NotImplementedError is
+// raised.
+func builtinCompile(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	argc := len(args)
+	expectedTypes := []*Type{ObjectType, StrType, StrType, IntType, IntType}
+	if argc < 5 {
+		// The flags and dont_inherit args are optional.
+		expectedTypes = expectedTypes[:argc]
+	}
+	if raised := checkFunctionArgs(f, "compile", args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	if argc < 3 {
+		names := []string{"source", "filename", "mode"}
+		format := "Required argument '%s' (pos %d) not found"
+		return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, names[argc], argc+1))
+	}
+	if !args[0].isInstance(StrType) && !args[0].isInstance(UnicodeType) {
+		return nil, f.RaiseType(TypeErrorType, "compile() expected string without null bytes")
+	}
+	switch toStrUnsafe(args[2]).Value() {
+	case "eval", "exec", "single":
+	default:
+		return nil, f.RaiseType(ValueErrorType, "compile() arg 3 must be 'exec', 'eval' or 'single'")
+	}
+	return nil, raiseDynamicCompile(f, "compile()")
+}
+
 func builtinDelAttr(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	if raised := checkFunctionArgs(f, "delattr", args, ObjectType, StrType); raised != nil {
 		return nil, raised
@@ -334,22 +448,62 @@ func builtinDelAttr(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 }
 
 func builtinDir(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
-	// TODO: Support __dir__.
-	if raised := checkFunctionArgs(f, "dir", args, ObjectType); raised != nil {
+	expectedTypes := []*Type{ObjectType}
+	if len(args) == 0 {
+		expectedTypes = nil
+	}
+	if raised := checkFunctionArgs(f, "dir", args, expectedTypes...); raised != nil {
 		return nil, raised
 	}
-	d := NewDict()
+	if len(args) == 0 {
+		locals, raised := builtinLocals(f, nil, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		l := toDictUnsafe(locals).Keys(f)
+		if raised := l.Sort(f); raised != nil {
+			return nil, raised
+		}
+		return l.ToObject(), nil
+	}
 	o := args[0]
-	if o.dict != nil {
-		raised := seqForEach(f, o.dict.ToObject(), func(k *Object) *BaseException {
-			return d.SetItem(f, k, None)
-		})
+	dir, raised := o.typ.mroLookup(f, NewStr("__dir__"))
+	if raised != nil {
+		return nil, raised
+	}
+	if dir != nil {
+		result, raised := dir.Call(f, Args{o}, nil)
 		if raised != nil {
 			return nil, raised
 		}
+		if !result.isInstance(ListType) {
+			format := "__dir__() must return a list, not %s"
+			return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, result.typ.Name()))
+		}
+		if raised := toListUnsafe(result).Sort(f); raised != nil {
+			return nil, raised
+		}
+		return result, nil
+	}
+	// Like CPython, a module's attributes are the contents of its dict, a
+	// type's are gathered from its MRO and any other object's are those of
+	// its dict and its type.
+	dicts := []*Dict{}
+	if o.dict != nil {
+		dicts = append(dicts, o.dict)
+	}
+	var mro []*Type
+	if o.isInstance(TypeType) {
+		mro = toTypeUnsafe(o).mro[1:]
+	} else if !o.isInstance(ModuleType) {
+		mro = o.typ.mro
 	}
-	for _, t := range o.typ.mro {
-		raised := seqForEach(f, t.dict.ToObject(), func(k *Object) *BaseException {
+	for _, t := range mro {
+		dicts = append(dicts, t.dict)
+	}
+	d := NewDict()
+	for _, dict := range dicts {
+		raised := seqForEach(f, dict.ToObject(), func(k *Object) *BaseException {
 			return d.SetItem(f, k, None)
 		})
 		if raised != nil {
@@ -370,6 +524,111 @@ func builtinDivMod(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException)
 	return DivMod(f, args[0], args[1])
 }
 
+func builtinEval(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	argc := len(args)
+	expectedTypes := []*Type{ObjectType, DictType, ObjectType}
+	if argc > 0 && argc < 3 {
+		expectedTypes = expectedTypes[:argc]
+	}
+	if raised := checkFunctionArgs(f, "eval", args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	source := args[0]
+	if source.isInstance(CodeType) {
+		// Code objects are already compiled so they can be run directly.
+		// They bind names in their globals, so a distinct locals mapping
+		// can't be honored.
+		globals := f.Globals()
+		if argc > 1 {
+			globals = toDictUnsafe(args[1])
+		}
+		if argc > 2 && args[2] != None && args[2] != globals.ToObject() {
+			return nil, f.RaiseType(NotImplementedErrorType, "eval() of a code object with a separate locals mapping is not supported")
+		}
+		return toCodeUnsafe(source).Eval(f, globals, nil, nil)
+	}
+	if !source.isInstance(StrType) && !source.isInstance(UnicodeType) {
+		return nil, f.RaiseType(TypeErrorType, "eval() arg 1 must be a string or code object")
+	}
+	return nil, raiseDynamicCompile(f, "eval() of a string")
+}
+
+func builtinExecFile(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	argc := len(args)
+	expectedTypes := []*Type{StrType, DictType, ObjectType}
+	if argc > 0 && argc < 3 {
+		expectedTypes = expectedTypes[:argc]
+	}
+	if raised := checkFunctionArgs(f, "execfile", args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	return nil, raiseDynamicCompile(f, "execfile()")
+}
+
+func builtinFilter(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkFunctionArgs(f, "filter", args, ObjectType, ObjectType); raised != nil {
+		return nil, raised
+	}
+	fn, seq := args[0], args[1]
+	pred := func(o *Object) (bool, *BaseException) {
+		if fn != None {
+			ret, raised := fn.Call(f, Args{o}, nil)
+			if raised != nil {
+				return false, raised
+			}
+			o = ret
+		}
+		return IsTrue(f, o)
+	}
+	// Like CPython, filtering a str, unicode or tuple produces a result of
+	// the same type. Anything else produces a list.
+	switch {
+	case seq.isInstance(StrType):
+		s := toStrUnsafe(seq).Value()
+		result := make([]byte, 0, len(s))
+		for i := 0; i < len(s); i++ {
+			keep, raised := pred(NewStr(s[i : i+1]).ToObject())
+			if raised != nil {
+				return nil, raised
+			}
+			if keep {
+				result = append(result, s[i])
+			}
+		}
+		return NewStr(string(result)).ToObject(), nil
+	case seq.isInstance(UnicodeType):
+		var result []rune
+		for _, r := range toUnicodeUnsafe(seq).Value() {
+			keep, raised := pred(NewUnicodeFromRunes([]rune{r}).ToObject())
+			if raised != nil {
+				return nil, raised
+			}
+			if keep {
+				result = append(result, r)
+			}
+		}
+		return NewUnicodeFromRunes(result).ToObject(), nil
+	}
+	var result []*Object
+	raised := seqForEach(f, seq, func(o *Object) *BaseException {
+		keep, raised := pred(o)
+		if raised != nil {
+			return raised
+		}
+		if keep {
+			result = append(result, o)
+		}
+		return nil
+	})
+	if raised != nil {
+		return nil, raised
+	}
+	if seq.isInstance(TupleType) {
+		return NewTuple(result...).ToObject(), nil
+	}
+	return NewList(result...).ToObject(), nil
+}
+
 func builtinFrame(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	if raised := checkFunctionArgs(f, "__frame__", args); raised != nil {
 		return nil, raised
@@ -441,6 +700,52 @@ func builtinID(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 	return NewInt(int(uintptr(args[0].toPointer()))).ToObject(), nil
 }
 
+func builtinImport(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
+	if raised := checkFunctionVarArgs(f, "__import__", args, StrType); raised != nil {
+		return nil, raised
+	}
+	argc := len(args)
+	if argc > 5 {
+		return nil, f.RaiseType(TypeErrorType, fmt.Sprintf("__import__() takes at most 5 arguments (%d given)", argc))
+	}
+	// Arguments after the module name may also be passed by keyword.
+	names := []string{"globals", "locals", "fromlist", "level"}
+	values := []*Object{None, None, None, NewInt(-1).ToObject()}
+	for _, kwarg := range kwargs {
+		i := 0
+		for i < len(names) && names[i] != kwarg.Name {
+			i++
+		}
+		if i == len(names) {
+			return nil, f.RaiseType(TypeErrorType, fmt.Sprintf("'%s' is an invalid keyword argument for this function", kwarg.Name))
+		}
+		if i+1 < argc {
+			return nil, f.RaiseType(TypeErrorType, fmt.Sprintf("Argument given by name ('%s') and position (%d)", kwarg.Name, i+2))
+		}
+		values[i] = kwarg.Value
+	}
+	copy(values, args[1:])
+	var globals *Dict
+	if values[0].isInstance(DictType) {
+		globals = toDictUnsafe(values[0])
+	}
+	if !values[3].isInstance(IntType) {
+		return nil, f.RaiseType(TypeErrorType, "an integer is required")
+	}
+	return importModuleLevel(f, toStrUnsafe(args[0]).Value(), globals, values[2], toIntUnsafe(values[3]).Value())
+}
+
+func builtinIntern(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkFunctionArgs(f, "intern", args, StrType); raised != nil {
+		return nil, raised
+	}
+	s := toStrUnsafe(args[0])
+	if s.typ != StrType {
+		return nil, f.RaiseType(TypeErrorType, "can't intern subclass of string")
+	}
+	return internStrValue(s).ToObject(), nil
+}
+
 func builtinIsInstance(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 	if raised := checkFunctionArgs(f, "isinstance", args, ObjectType, ObjectType); raised != nil {
 		return nil, raised
@@ -481,6 +786,20 @@ func builtinLen(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 	return ret.ToObject(), nil
 }
 
+func builtinLocals(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkFunctionArgs(f, "locals", args); raised != nil {
+		return nil, raised
+	}
+	if f.locals == nil {
+		return f.globals.ToObject(), nil
+	}
+	d, raised := f.locals(f)
+	if raised != nil {
+		return nil, raised
+	}
+	return d.ToObject(), nil
+}
+
 func builtinMax(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 	return builtinMinMax(f, true, args, kwargs)
 }
@@ -517,27 +836,71 @@ func builtinOpen(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 
 func builtinOrd(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	const lenMsg = "ord() expected a character, but string of length %d found"
-	if raised := checkFunctionArgs(f, "ord", args, BaseStringType); raised != nil {
+	if raised := checkFunctionArgs(f, "ord", args, ObjectType); raised != nil {
 		return nil, raised
 	}
 	o := args[0]
 	var result int
-	if o.isInstance(StrType) {
+	switch {
+	case o.isInstance(StrType):
 		s := toStrUnsafe(o).Value()
 		if numChars := len(s); numChars != 1 {
-			return nil, f.RaiseType(ValueErrorType, fmt.Sprintf(lenMsg, numChars))
+			return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(lenMsg, numChars))
 		}
 		result = int(([]byte(s))[0])
-	} else {
+	case o.isInstance(UnicodeType):
 		s := toUnicodeUnsafe(o).Value()
 		if numChars := len(s); numChars != 1 {
-			return nil, f.RaiseType(ValueErrorType, fmt.Sprintf(lenMsg, numChars))
+			return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(lenMsg, numChars))
 		}
 		result = int(s[0])
+	default:
+		return nil, f.RaiseType(TypeErrorType, fmt.Sprintf("ord() expected string of length 1, but %s found", o.typ.Name()))
 	}
 	return NewInt(result).ToObject(), nil
 }
 
+func builtinPow(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	argc := len(args)
+	expectedTypes := []*Type{ObjectType, ObjectType, ObjectType}
+	if argc == 2 {
+		expectedTypes = expectedTypes[:2]
+	}
+	if raised := checkFunctionArgs(f, "pow", args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	if argc == 2 {
+		return Pow(f, args[0], args[1])
+	}
+	v, w, z := args[0], args[1], args[2]
+	allInts := true
+	for _, arg := range args {
+		if !arg.isInstance(IntType) && !arg.isInstance(LongType) {
+			allInts = false
+		}
+	}
+	if allInts {
+		return longPowMod(f, v, w, z)
+	}
+	if v.isInstance(FloatType) || w.isInstance(FloatType) || z.isInstance(FloatType) {
+		return nil, f.RaiseType(TypeErrorType, "pow() 3rd argument not allowed unless all arguments are integers")
+	}
+	// Ternary pow has no slot, so defer to a user defined __pow__ that
+	// accepts the modulus as a third argument.
+	pow, raised := v.typ.mroLookup(f, NewStr("__pow__"))
+	if raised != nil {
+		return nil, raised
+	}
+	if pow != nil {
+		r, raised := pow.Call(f, Args{v, w, z}, nil)
+		if raised != nil || r != NotImplemented {
+			return r, raised
+		}
+	}
+	format := "unsupported operand type(s) for pow(): '%s', '%s', '%s'"
+	return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, v.typ.Name(), w.typ.Name(), z.typ.Name()))
+}
+
 func builtinPrint(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 	sep := " "
 	end := "\n"
@@ -566,11 +929,64 @@ func builtinPrint(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException)
 }
 
 func builtinRange(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
-	r, raised := xrangeType.Call(f, args, nil)
+	argc := len(args)
+	if argc < 1 || argc > 3 {
+		bound := "least 1"
+		if argc > 3 {
+			bound = "most 3"
+		}
+		format := "range expected at %s arguments, got %d"
+		return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, bound, argc))
+	}
+	names := []string{"start", "end", "step"}
+	if argc == 1 {
+		names = names[1:]
+	}
+	bounds := []int{0, 0, 1}
+	for i, arg := range args {
+		b, raised := rangeArg(f, names[i], arg)
+		if raised != nil {
+			return nil, raised
+		}
+		bounds[i] = b
+	}
+	if argc == 1 {
+		bounds[0], bounds[1] = 0, bounds[0]
+	}
+	start, step := bounds[0], bounds[2]
+	_, n, result := seqRange(start, bounds[1], step)
+	switch {
+	case result == seqRangeZeroStep:
+		return nil, f.RaiseType(ValueErrorType, "range() step argument must not be zero")
+	case result == seqRangeOverflow || uint64(n) > maxAllocSize/uint64(unsafe.Sizeof((*Object)(nil))):
+		return nil, f.RaiseType(OverflowErrorType, "range() result has too many items")
+	}
+	elems := make([]*Object, n)
+	for i := range elems {
+		elems[i] = NewInt(start + i*step).ToObject()
+	}
+	return NewList(elems...).ToObject(), nil
+}
+
+// rangeArg converts the named argument o of range() to an int. Like CPython,
+// only ints, longs and objects with __index__ are accepted.
+func rangeArg(f *Frame, name string, o *Object) (int, *BaseException) {
+	i, raised := Index(f, o)
 	if raised != nil {
-		return nil, raised
+		return 0, raised
+	}
+	if i == nil {
+		format := "range() integer %s argument expected, got %s."
+		return 0, f.RaiseType(TypeErrorType, fmt.Sprintf(format, name, o.typ.Name()))
+	}
+	if i.isInstance(IntType) {
+		return toIntUnsafe(i).Value(), nil
 	}
-	return ListType.Call(f, []*Object{r}, nil)
+	l := toLongUnsafe(i).Value()
+	if !numInIntRange(l) {
+		return 0, f.RaiseType(OverflowErrorType, "range() result has too many items")
+	}
+	return int(l.Int64()), nil
 }
 
 func builtinRawInput(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
@@ -604,6 +1020,13 @@ func builtinRawInput(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseExceptio
 	return NewStr(line).ToObject(), nil
 }
 
+func builtinReload(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
+	if raised := checkFunctionArgs(f, "reload", args, ModuleType); raised != nil {
+		return nil, raised
+	}
+	return reloadModule(f, toModuleUnsafe(args[0]))
+}
+
 func builtinRepr(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 	if raised := checkFunctionArgs(f, "repr", args, ObjectType); raised != nil {
 		return nil, raised
@@ -661,16 +1084,21 @@ func builtinSetAttr(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	return None, SetAttr(f, args[0], toStrUnsafe(args[1]), args[2])
 }
 
-func builtinSorted(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
-	// TODO: Support (cmp=None, key=None, reverse=False)
-	if raised := checkFunctionArgs(f, "sorted", args, ObjectType); raised != nil {
+func builtinSorted(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
+	if raised := checkFunctionVarArgs(f, "sorted", args, ObjectType); raised != nil {
+		return nil, raised
+	}
+	cmp, key, reverse, raised := listSortArgs(f, "sorted", 1, args[1:], kwargs)
+	if raised != nil {
 		return nil, raised
 	}
 	result, raised := ListType.Call(f, Args{args[0]}, nil)
 	if raised != nil {
 		return nil, raised
 	}
-	toListUnsafe(result).Sort(f)
+	if raised := toListUnsafe(result).sort(f, cmp, key, reverse); raised != nil {
+		return nil, raised
+	}
 	return result, nil
 }
 
@@ -685,7 +1113,7 @@ func builtinSum(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	}
 	var result *Object
 	if argc > 1 {
-		if args[1].typ == StrType {
+		if args[1].isInstance(BaseStringType) {
 			return nil, f.RaiseType(TypeErrorType, "sum() can't sum strings [use ''.join(seq) instead]")
 		}
 		result = args[1]
@@ -747,28 +1175,38 @@ Outer:
 func init() {
 	builtinMap := map[string]*Object{
 		"__frame__":      newBuiltinFunction("__frame__", builtinFrame).ToObject(),
+		"__import__":     newBuiltinFunction("__import__", builtinImport).ToObject(),
 		"abs":            newBuiltinFunction("abs", builtinAbs).ToObject(),
 		"all":            newBuiltinFunction("all", builtinAll).ToObject(),
 		"any":            newBuiltinFunction("any", builtinAny).ToObject(),
+		"apply":          newBuiltinFunction("apply", builtinApply).ToObject(),
 		"bin":            newBuiltinFunction("bin", builtinBin).ToObject(),
+		"bytes":          StrType.ToObject(),
 		"callable":       newBuiltinFunction("callable", builtinCallable).ToObject(),
 		"chr":            newBuiltinFunction("chr", builtinChr).ToObject(),
 		"cmp":            newBuiltinFunction("cmp", builtinCmp).ToObject(),
+		"coerce":         newBuiltinFunction("coerce", builtinCoerce).ToObject(),
+		"compile":        newBuiltinFunction("compile", builtinCompile).ToObject(),
 		"delattr":        newBuiltinFunction("delattr", builtinDelAttr).ToObject(),
 		"dir":            newBuiltinFunction("dir", builtinDir).ToObject(),
 		"divmod":         newBuiltinFunction("divmod", builtinDivMod).ToObject(),
 		"Ellipsis":       Ellipsis,
+		"eval":           newBuiltinFunction("eval", builtinEval).ToObject(),
+		"execfile":       newBuiltinFunction("execfile", builtinExecFile).ToObject(),
 		"False":          False.ToObject(),
+		"filter":         newBuiltinFunction("filter", builtinFilter).ToObject(),
 		"getattr":        newBuiltinFunction("getattr", builtinGetAttr).ToObject(),
 		"globals":        newBuiltinFunction("globals", builtinGlobals).ToObject(),
 		"hasattr":        newBuiltinFunction("hasattr", builtinHasAttr).ToObject(),
 		"hash":           newBuiltinFunction("hash", builtinHash).ToObject(),
 		"hex":            newBuiltinFunction("hex", builtinHex).ToObject(),
 		"id":             newBuiltinFunction("id", builtinID).ToObject(),
+		"intern":         newBuiltinFunction("intern", builtinIntern).ToObject(),
 		"isinstance":     newBuiltinFunction("isinstance", builtinIsInstance).ToObject(),
 		"issubclass":     newBuiltinFunction("issubclass", builtinIsSubclass).ToObject(),
 		"iter":           newBuiltinFunction("iter", builtinIter).ToObject(),
 		"len":            newBuiltinFunction("len", builtinLen).ToObject(),
+		"locals":         newBuiltinFunction("locals", builtinLocals).ToObject(),
 		"map":            newBuiltinFunction("map", builtinMapFn).ToObject(),
 		"max":            newBuiltinFunction("max", builtinMax).ToObject(),
 		"min":            newBuiltinFunction("min", builtinMin).ToObject(),
@@ -778,9 +1216,11 @@ func init() {
 		"oct":            newBuiltinFunction("oct", builtinOct).ToObject(),
 		"open":           newBuiltinFunction("open", builtinOpen).ToObject(),
 		"ord":            newBuiltinFunction("ord", builtinOrd).ToObject(),
+		"pow":            newBuiltinFunction("pow", builtinPow).ToObject(),
 		"print":          newBuiltinFunction("print", builtinPrint).ToObject(),
 		"range":          newBuiltinFunction("range", builtinRange).ToObject(),
 		"raw_input":      newBuiltinFunction("raw_input", builtinRawInput).ToObject(),
+		"reload":         newBuiltinFunction("reload", builtinReload).ToObject(),
 		"repr":           newBuiltinFunction("repr", builtinRepr).ToObject(),
 		"round":          newBuiltinFunction("round", builtinRound).ToObject(),
 		"setattr":        newBuiltinFunction("setattr", builtinSetAttr).ToObject(),
@@ -819,6 +1259,12 @@ func builtinMinMax(f *Frame, doMax bool, args Args, kwargs KWArgs) (*Object, *Ba
 		return nil, raised
 	}
 	keyFunc := kwargs.get("key", nil)
+	// Like Python 3, default is returned when the iterable is empty.
+	defaultValue := kwargs.get("default", nil)
+	if defaultValue != nil && len(args) > 1 {
+		format := "Cannot specify a default for %s() with multiple positional arguments"
+		return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, name))
+	}
 	// selected is the min/max element found so far.
 	var selected, selectedKey *Object
 	partialFunc := func(o *Object) (raised *BaseException) {
@@ -833,17 +1279,20 @@ func builtinMinMax(f *Frame, doMax bool, args Args, kwargs KWArgs) (*Object, *Ba
 		// true when selected == nil (we don't yet have a selection).
 		sel := true
 		if selected != nil {
-			result, raised := LT(f, selectedKey, oKey)
+			// Select o when looking for max and selection < o, or
+			// when looking for min and o < selection. Ties keep the
+			// earlier element.
+			lhs, rhs := oKey, selectedKey
+			if doMax {
+				lhs, rhs = selectedKey, oKey
+			}
+			result, raised := LT(f, lhs, rhs)
 			if raised != nil {
 				return raised
 			}
-			lt, raised := IsTrue(f, result)
-			if raised != nil {
+			if sel, raised = IsTrue(f, result); raised != nil {
 				return raised
 			}
-			// Select o when looking for max and selection < o, or
-			// when looking for min and o < selection.
-			sel = doMax && lt || !doMax && !lt
 		}
 		if sel {
 			selected = o
@@ -857,6 +1306,9 @@ func builtinMinMax(f *Frame, doMax bool, args Args, kwargs KWArgs) (*Object, *Ba
 			return nil, raised
 		}
 		if selected == nil {
+			if defaultValue != nil {
+				return defaultValue, nil
+			}
 			return nil, f.RaiseType(ValueErrorType, fmt.Sprintf("%s() arg is an empty sequence", name))
 		}
 	} else {
@@ -870,6 +1322,14 @@ func builtinMinMax(f *Frame, doMax bool, args Args, kwargs KWArgs) (*Object, *Ba
 	return selected, nil
 }
 
+// raiseDynamicCompile raises NotImplementedError for features that need to
+// compile Python source at runtime. Grumpy translates Python to Go ahead of
+// time so running programs have no compiler available to them.
+func raiseDynamicCompile(f *Frame, feature string) *BaseException {
+	format := "%s is not supported: Grumpy compiles Python ahead of time, move the code into a module and import it instead"
+	return f.RaiseType(NotImplementedErrorType, fmt.Sprintf(format, feature))
+}
+
 // numberToBase implements the builtins "bin", "hex", and "oct".
 // base must be between 2 and 36, and o must be an instance of
 // IntType or LongType.
@@ -922,6 +1382,7 @@ func zipLongest(f *Frame, args Args) ([][]*Object, *BaseException) {
 		elems := make([]*Object, argc)
 		for i, iter := range iters {
 			if iter == nil {
+				elems[i] = None
 				continue
 			}
 			elem, raised := Next(f, iter)
@@ -66,6 +66,8 @@ func TestBuiltinFuncs(t *testing.T) {
 	fooDir.Sort(f)
 	iter := mustNotRaise(Iter(f, mustNotRaise(xrangeType.Call(f, wrapArgs(5), nil))))
 	neg := wrapFuncForTest(func(f *Frame, i int) int { return -i })
+	first := wrapFuncForTest(func(f *Frame, t *Tuple) *Object { return t.GetItem(0) })
+	notB := wrapFuncForTest(func(f *Frame, s string) bool { return s != "b" })
 	raiseKey := wrapFuncForTest(func(f *Frame, o *Object) *BaseException { return f.RaiseType(RuntimeErrorType, "foo") })
 	hexOctType := newTestClass("HexOct", []*Type{ObjectType}, newStringDict(map[string]*Object{
 		"__hex__": newBuiltinFunction("__hex__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
@@ -95,12 +97,54 @@ func TestBuiltinFuncs(t *testing.T) {
 			return NewInt(1).ToObject(), nil
 		}).ToObject(),
 	}))
+	callableType := newTestClass("Callable", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__call__": newBuiltinFunction("__call__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return None, nil
+		}).ToObject(),
+	}))
+	powType := newTestClass("Pow", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__pow__": newBuiltinFunction("__pow__", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+			return NewTuple(args[1:]...).ToObject(), nil
+		}).ToObject(),
+	}))
+	modExpMod := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	dirType := newTestClass("Dir", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__dir__": newBuiltinFunction("__dir__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return newTestList("b", "a").ToObject(), nil
+		}).ToObject(),
+	}))
+	badDirType := newTestClass("BadDir", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__dir__": newBuiltinFunction("__dir__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return newTestTuple("a").ToObject(), nil
+		}).ToObject(),
+	}))
+	absType := newTestClass("Abs", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__abs__": newBuiltinFunction("__abs__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return NewStr("abs").ToObject(), nil
+		}).ToObject(),
+	}))
+	coerceType := newTestClass("Coerce", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__coerce__": newBuiltinFunction("__coerce__", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+			return NewTuple2(args[0], NewStr("coerced").ToObject()).ToObject(), nil
+		}).ToObject(),
+	}))
+	coerceObj := newObject(coerceType)
+	badCoerceType := newTestClass("BadCoerce", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__coerce__": newBuiltinFunction("__coerce__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return NewInt(1).ToObject(), nil
+		}).ToObject(),
+	}))
+	strSubType := newTestClass("StrSub", []*Type{StrType}, NewDict())
 	fooBuiltinFunc := newBuiltinFunction("foo", func(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 		return newTestTuple(NewTuple(args.makeCopy()...), kwargs.makeDict()).ToObject(), nil
 	}).ToObject()
 	fooFunc := NewFunction(NewCode("foo", "foo.py", nil, CodeFlagVarArg, func(f *Frame, args []*Object) (*Object, *BaseException) {
 		return args[0], nil
 	}), nil)
+	getFooCode := NewCode("<module>", "foo.py", nil, 0, func(f *Frame, _ []*Object) (*Object, *BaseException) {
+		return f.Globals().GetItemString(f, "foo")
+	})
+	evalGlobals := newTestDict("foo", 42)
 	cases := []struct {
 		f       string
 		args    Args
@@ -116,12 +160,16 @@ func TestBuiltinFuncs(t *testing.T) {
 		{f: "abs", args: wrapArgs(NewFloat(3.4)), want: NewFloat(3.4).ToObject()},
 		{f: "abs", args: wrapArgs(NewFloat(-3.4)), want: NewFloat(3.4).ToObject()},
 		{f: "abs", args: wrapArgs(MinInt), want: NewLong(big.NewInt(MinInt).Neg(minIntBig)).ToObject()},
+		{f: "abs", args: wrapArgs(true), want: NewInt(1).ToObject()},
+		{f: "abs", args: wrapArgs(complex(3, -4)), want: NewFloat(5).ToObject()},
+		{f: "abs", args: wrapArgs(newObject(absType)), want: NewStr("abs").ToObject()},
 		{f: "abs", args: wrapArgs(NewStr("a")), wantExc: mustCreateException(TypeErrorType, "bad operand type for abs(): 'str'")},
 		{f: "all", args: wrapArgs(newTestList()), want: True.ToObject()},
 		{f: "all", args: wrapArgs(newTestList(1, 2, 3)), want: True.ToObject()},
 		{f: "all", args: wrapArgs(newTestList(1, 0, 1)), want: False.ToObject()},
 		{f: "all", args: wrapArgs(13), wantExc: mustCreateException(TypeErrorType, "'int' object is not iterable")},
 		{f: "all", args: wrapArgs(newTestList(newObject(badNonZeroType))), wantExc: mustCreateException(RuntimeErrorType, "foo")},
+		{f: "all", args: wrapArgs(newTestList(0, newObject(badNonZeroType))), want: False.ToObject()},
 		{f: "all", args: wrapArgs(newObject(badIterType)), wantExc: mustCreateException(RuntimeErrorType, "foo")},
 		{f: "any", args: wrapArgs(newTestList()), want: False.ToObject()},
 		{f: "any", args: wrapArgs(newTestList(1, 2, 3)), want: True.ToObject()},
@@ -130,7 +178,22 @@ func TestBuiltinFuncs(t *testing.T) {
 		{f: "any", args: wrapArgs(newTestList(False.ToObject(), False.ToObject())), want: False.ToObject()},
 		{f: "any", args: wrapArgs(13), wantExc: mustCreateException(TypeErrorType, "'int' object is not iterable")},
 		{f: "any", args: wrapArgs(newTestList(newObject(badNonZeroType))), wantExc: mustCreateException(RuntimeErrorType, "foo")},
+		{f: "any", args: wrapArgs(newTestList(1, newObject(badNonZeroType))), want: True.ToObject()},
 		{f: "any", args: wrapArgs(newObject(badIterType)), wantExc: mustCreateException(RuntimeErrorType, "foo")},
+		{f: "apply", args: wrapArgs(fooBuiltinFunc), want: newTestTuple(NewTuple(), NewDict()).ToObject()},
+		{f: "apply", args: wrapArgs(fooBuiltinFunc, newTestTuple(1, 2)), want: newTestTuple(newTestTuple(1, 2), NewDict()).ToObject()},
+		{f: "apply", args: wrapArgs(fooBuiltinFunc, newTestList("foo")), want: newTestTuple(newTestTuple("foo"), NewDict()).ToObject()},
+		{f: "apply", args: wrapArgs(fooBuiltinFunc, NewTuple(), newTestDict("a", 1)), want: newTestTuple(NewTuple(), newTestDict("a", 1)).ToObject()},
+		{f: "apply", args: wrapArgs(fooBuiltinFunc, newTestTuple(3), newTestDict("b", "c")), want: newTestTuple(newTestTuple(3), newTestDict("b", "c")).ToObject()},
+		{f: "apply", args: wrapArgs(fooBuiltinFunc, 123), wantExc: mustCreateException(TypeErrorType, "apply() arg 2 expected sequence, found int")},
+		{f: "apply", args: wrapArgs(fooBuiltinFunc, NewTuple(), newTestList()), wantExc: mustCreateException(TypeErrorType, "apply() arg 3 expected dictionary, found list")},
+		{f: "apply", args: wrapArgs(fooBuiltinFunc, NewTuple(), newTestDict(1, 2)), wantExc: mustCreateException(TypeErrorType, "foo() keywords must be strings")},
+		{f: "apply", args: wrapArgs(123), wantExc: mustCreateException(TypeErrorType, "'int' object is not callable")},
+		{f: "apply", args: wrapArgs(fooBuiltinFunc, "ab"), want: newTestTuple(newTestTuple("a", "b"), NewDict()).ToObject()},
+		{f: "apply", args: wrapArgs(fooBuiltinFunc, newTestDict("a", 1)), wantExc: mustCreateException(TypeErrorType, "apply() arg 2 expected sequence, found dict")},
+		{f: "apply", args: wrapArgs(fooBuiltinFunc, NewSet()), wantExc: mustCreateException(TypeErrorType, "apply() arg 2 expected sequence, found set")},
+		{f: "apply", args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "apply expected at least 1 arguments, got 0")},
+		{f: "apply", args: wrapArgs(fooBuiltinFunc, NewTuple(), NewDict(), 4), wantExc: mustCreateException(TypeErrorType, "apply expected at most 3 arguments, got 4")},
 		{f: "bin", args: wrapArgs(64 + 8 + 1), want: NewStr("0b1001001").ToObject()},
 		{f: "bin", args: wrapArgs(MinInt), want: NewStr(fmt.Sprintf("-0b%b0", -(MinInt >> 1))).ToObject()},
 		{f: "bin", args: wrapArgs(0), want: NewStr("0b0").ToObject()},
@@ -141,8 +204,15 @@ func TestBuiltinFuncs(t *testing.T) {
 		{f: "bin", args: wrapArgs(0.1), wantExc: mustCreateException(TypeErrorType, "float object cannot be interpreted as an index")},
 		{f: "bin", args: wrapArgs(1, 2, 3), wantExc: mustCreateException(TypeErrorType, "'bin' requires 1 arguments")},
 		{f: "bin", args: wrapArgs(newTestIndexObject(123)), want: NewStr("0b1111011").ToObject()},
+		{f: "bytes", args: wrapArgs(97), want: NewStr("97").ToObject()},
+		{f: "bytes", args: wrapArgs("foo"), want: NewStr("foo").ToObject()},
 		{f: "callable", args: wrapArgs(fooBuiltinFunc), want: True.ToObject()},
 		{f: "callable", args: wrapArgs(fooFunc), want: True.ToObject()},
+		{f: "callable", args: wrapArgs(NewMethod(fooFunc, None, NoneType)), want: True.ToObject()},
+		{f: "callable", args: wrapArgs(callableType), want: True.ToObject()},
+		{f: "callable", args: wrapArgs(IntType), want: True.ToObject()},
+		{f: "callable", args: wrapArgs(newObject(callableType)), want: True.ToObject()},
+		{f: "callable", args: wrapArgs(newObject(ObjectType)), want: False.ToObject()},
 		{f: "callable", args: wrapArgs(0), want: False.ToObject()},
 		{f: "callable", args: wrapArgs(0.1), want: False.ToObject()},
 		{f: "callable", args: wrapArgs("foo"), want: False.ToObject()},
@@ -154,11 +224,42 @@ func TestBuiltinFuncs(t *testing.T) {
 		{f: "chr", args: wrapArgs(65), want: NewStr("A").ToObject()},
 		{f: "chr", args: wrapArgs(300), wantExc: mustCreateException(ValueErrorType, "chr() arg not in range(256)")},
 		{f: "chr", args: wrapArgs(-1), wantExc: mustCreateException(ValueErrorType, "chr() arg not in range(256)")},
+		{f: "chr", args: wrapArgs(255), want: NewStr("\xff").ToObject()},
+		{f: "chr", args: wrapArgs(256), wantExc: mustCreateException(ValueErrorType, "chr() arg not in range(256)")},
+		{f: "chr", args: wrapArgs(NewLong(big.NewInt(66))), want: NewStr("B").ToObject()},
+		{f: "chr", args: wrapArgs(true), want: NewStr("\x01").ToObject()},
+		{f: "chr", args: wrapArgs(1.5), wantExc: mustCreateException(TypeErrorType, "integer argument expected, got float")},
+		{f: "chr", args: wrapArgs("1"), wantExc: mustCreateException(TypeErrorType, "an integer is required")},
 		{f: "chr", args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "'chr' requires 1 arguments")},
+		{f: "coerce", args: wrapArgs(1, 2), want: newTestTuple(1, 2).ToObject()},
+		{f: "coerce", args: wrapArgs(1, 2.5), want: newTestTuple(1.0, 2.5).ToObject()},
+		{f: "coerce", args: wrapArgs(2.5, 1), want: newTestTuple(2.5, 1.0).ToObject()},
+		{f: "coerce", args: wrapArgs(1, big.NewInt(2)), want: newTestTuple(big.NewInt(1), big.NewInt(2)).ToObject()},
+		{f: "coerce", args: wrapArgs(1, NewComplex(1i)), want: newTestTuple(NewComplex(1), NewComplex(1i)).ToObject()},
+		{f: "coerce", args: wrapArgs(NewComplex(1i), 2.5), want: newTestTuple(NewComplex(1i), NewComplex(2.5)).ToObject()},
+		{f: "coerce", args: wrapArgs(None, None), want: newTestTuple(None, None).ToObject()},
+		{f: "coerce", args: wrapArgs(coerceObj, 1), want: newTestTuple(coerceObj, "coerced").ToObject()},
+		{f: "coerce", args: wrapArgs(1, coerceObj), want: newTestTuple("coerced", coerceObj).ToObject()},
+		{f: "coerce", args: wrapArgs(1, "a"), wantExc: mustCreateException(TypeErrorType, "number coercion failed")},
+		{f: "coerce", args: wrapArgs(newObject(badCoerceType), 1), wantExc: mustCreateException(TypeErrorType, "__coerce__ didn't return a 2-tuple")},
+		{f: "coerce", args: wrapArgs(new(big.Int).Lsh(big.NewInt(1), 2000), 1.0), wantExc: mustCreateException(OverflowErrorType, "long int too large to convert to float")},
+		{f: "compile", args: wrapArgs("1 + 2", "<string>", "eval"), wantExc: mustCreateException(NotImplementedErrorType, "compile() is not supported: Grumpy compiles Python ahead of time, move the code into a module and import it instead")},
+		{f: "compile", args: wrapArgs(NewUnicode("x = 1"), "foo.py", "exec", 0, 1), wantExc: mustCreateException(NotImplementedErrorType, "compile() is not supported: Grumpy compiles Python ahead of time, move the code into a module and import it instead")},
+		{f: "compile", args: wrapArgs("1 + 2", "<string>", "foo"), wantExc: mustCreateException(ValueErrorType, "compile() arg 3 must be 'exec', 'eval' or 'single'")},
+		{f: "compile", args: wrapArgs(123, "<string>", "eval"), wantExc: mustCreateException(TypeErrorType, "compile() expected string without null bytes")},
+		{f: "compile", args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "Required argument 'source' (pos 1) not found")},
+		{f: "compile", args: wrapArgs("x"), wantExc: mustCreateException(TypeErrorType, "Required argument 'filename' (pos 2) not found")},
+		{f: "compile", args: wrapArgs("1 + 2", "<string>"), wantExc: mustCreateException(TypeErrorType, "Required argument 'mode' (pos 3) not found")},
+		{f: "compile", args: wrapArgs("1 + 2", 3, "eval"), wantExc: mustCreateException(TypeErrorType, "'compile' requires a 'str' object but received a \"int\"")},
+		{f: "compile", args: wrapArgs("1 + 2", "<string>", "eval", 0, 1, 2), wantExc: mustCreateException(TypeErrorType, "'compile' requires 5 arguments")},
 		{f: "dir", args: wrapArgs(newObject(ObjectType)), want: objectDir.ToObject()},
 		{f: "dir", args: wrapArgs(newObject(fooType)), want: fooTypeDir.ToObject()},
 		{f: "dir", args: wrapArgs(foo), want: fooDir.ToObject()},
-		{f: "dir", args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "'dir' requires 1 arguments")},
+		{f: "dir", args: wrapArgs(fooType), want: fooTypeDir.ToObject()},
+		{f: "dir", args: wrapArgs(newTestModule("foo", "foo.py")), want: newTestList("__file__", "__name__").ToObject()},
+		{f: "dir", args: wrapArgs(newObject(dirType)), want: newTestList("a", "b").ToObject()},
+		{f: "dir", args: wrapArgs(newObject(badDirType)), wantExc: mustCreateException(TypeErrorType, "__dir__() must return a list, not tuple")},
+		{f: "dir", args: wrapArgs(1, 2), wantExc: mustCreateException(TypeErrorType, "'dir' requires 1 arguments")},
 		{f: "divmod", args: wrapArgs(12, 7), want: NewTuple2(NewInt(1).ToObject(), NewInt(5).ToObject()).ToObject()},
 		{f: "divmod", args: wrapArgs(-12, 7), want: NewTuple2(NewInt(-2).ToObject(), NewInt(2).ToObject()).ToObject()},
 		{f: "divmod", args: wrapArgs(12, -7), want: NewTuple2(NewInt(-2).ToObject(), NewInt(-2).ToObject()).ToObject()},
@@ -176,6 +277,28 @@ func TestBuiltinFuncs(t *testing.T) {
 		{f: "divmod", args: wrapArgs(-3.25, -1.0), want: NewTuple2(NewFloat(3.0).ToObject(), NewFloat(-0.25).ToObject()).ToObject()},
 		{f: "divmod", args: wrapArgs(NewStr("a"), NewStr("b")), wantExc: mustCreateException(TypeErrorType, "unsupported operand type(s) for divmod(): 'str' and 'str'")},
 		{f: "divmod", args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "'divmod' requires 2 arguments")},
+		{f: "eval", args: wrapArgs(getFooCode, newStringDict(map[string]*Object{"foo": NewInt(42).ToObject()})), want: NewInt(42).ToObject()},
+		{f: "eval", args: wrapArgs(getFooCode, NewDict(), newStringDict(map[string]*Object{"foo": NewInt(42).ToObject()})), wantExc: mustCreateException(NotImplementedErrorType, "eval() of a code object with a separate locals mapping is not supported")},
+		{f: "eval", args: wrapArgs(getFooCode, evalGlobals, evalGlobals), want: NewInt(42).ToObject()},
+		{f: "eval", args: wrapArgs(getFooCode, evalGlobals, None), want: NewInt(42).ToObject()},
+		{f: "eval", args: wrapArgs("1 + 2"), wantExc: mustCreateException(NotImplementedErrorType, "eval() of a string is not supported: Grumpy compiles Python ahead of time, move the code into a module and import it instead")},
+		{f: "eval", args: wrapArgs(NewUnicode("1 + 2"), NewDict()), wantExc: mustCreateException(NotImplementedErrorType, "eval() of a string is not supported: Grumpy compiles Python ahead of time, move the code into a module and import it instead")},
+		{f: "eval", args: wrapArgs(123), wantExc: mustCreateException(TypeErrorType, "eval() arg 1 must be a string or code object")},
+		{f: "eval", args: wrapArgs("1 + 2", 123), wantExc: mustCreateException(TypeErrorType, "'eval' requires a 'dict' object but received a \"int\"")},
+		{f: "execfile", args: wrapArgs("foo.py"), wantExc: mustCreateException(NotImplementedErrorType, "execfile() is not supported: Grumpy compiles Python ahead of time, move the code into a module and import it instead")},
+		{f: "execfile", args: wrapArgs("foo.py", NewDict(), NewDict()), wantExc: mustCreateException(NotImplementedErrorType, "execfile() is not supported: Grumpy compiles Python ahead of time, move the code into a module and import it instead")},
+		{f: "execfile", args: wrapArgs(123), wantExc: mustCreateException(TypeErrorType, "'execfile' requires a 'str' object but received a \"int\"")},
+		{f: "execfile", args: wrapArgs("foo.py", newTestList()), wantExc: mustCreateException(TypeErrorType, "'execfile' requires a 'dict' object but received a \"list\"")},
+		{f: "execfile", args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "'execfile' requires 3 arguments")},
+		{f: "filter", args: wrapArgs(None, newTestList(0, 1, "", "a", None)), want: newTestList(1, "a").ToObject()},
+		{f: "filter", args: wrapArgs(neg, newTestTuple(0, 1, 2)), want: newTestTuple(1, 2).ToObject()},
+		{f: "filter", args: wrapArgs(notB, "abcb"), want: NewStr("ac").ToObject()},
+		{f: "filter", args: wrapArgs(None, NewUnicode("ab")), want: NewUnicode("ab").ToObject()},
+		{f: "filter", args: wrapArgs(None, newTestDict("foo", 1)), want: newTestList("foo").ToObject()},
+		{f: "filter", args: wrapArgs(None, ""), want: NewStr("").ToObject()},
+		{f: "filter", args: wrapArgs(raiseKey, newTestList(1)), wantExc: mustCreateException(RuntimeErrorType, "foo")},
+		{f: "filter", args: wrapArgs(None, 1), wantExc: mustCreateException(TypeErrorType, "'int' object is not iterable")},
+		{f: "filter", args: wrapArgs(None), wantExc: mustCreateException(TypeErrorType, "'filter' requires 2 arguments")},
 		{f: "getattr", args: wrapArgs(None, NewStr("foo").ToObject(), NewStr("bar").ToObject()), want: NewStr("bar").ToObject()},
 		{f: "getattr", args: wrapArgs(None, NewStr("foo").ToObject()), wantExc: mustCreateException(AttributeErrorType, "'NoneType' object has no attribute 'foo'")},
 		{f: "hasattr", args: wrapArgs(newObject(ObjectType), NewStr("foo").ToObject()), want: False.ToObject()},
@@ -196,6 +319,10 @@ func TestBuiltinFuncs(t *testing.T) {
 		{f: "hex", args: wrapArgs(newObject(hexOctType)), want: NewStr("0xhexadecimal").ToObject()},
 		{f: "id", args: wrapArgs(foo), want: NewInt(int(uintptr(foo.toPointer()))).ToObject()},
 		{f: "id", args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "'id' requires 1 arguments")},
+		{f: "intern", args: wrapArgs("foo"), want: NewStr("foo").ToObject()},
+		{f: "intern", args: wrapArgs(123), wantExc: mustCreateException(TypeErrorType, "'intern' requires a 'str' object but received a \"int\"")},
+		{f: "intern", args: wrapArgs(newObject(strSubType)), wantExc: mustCreateException(TypeErrorType, "can't intern subclass of string")},
+		{f: "intern", args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "'intern' requires 1 arguments")},
 		{f: "isinstance", args: wrapArgs(NewInt(42).ToObject(), IntType.ToObject()), want: True.ToObject()},
 		{f: "isinstance", args: wrapArgs(NewStr("foo").ToObject(), TupleType.ToObject()), want: False.ToObject()},
 		{f: "isinstance", args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "'isinstance' requires 2 arguments")},
@@ -228,6 +355,9 @@ func TestBuiltinFuncs(t *testing.T) {
 		{f: "map", args: wrapArgs(IntType, 1), wantExc: mustCreateException(TypeErrorType, "'int' object is not iterable")},
 		{f: "map", args: wrapArgs(1, newTestList(1, 2, 3)), wantExc: mustCreateException(TypeErrorType, "'int' object is not callable")},
 		{f: "map", args: wrapArgs(StrType, newTestList(), 1), wantExc: mustCreateException(TypeErrorType, "'int' object is not iterable")},
+		{f: "map", args: wrapArgs(None, newTestList(1, 2, 3), "ab"), want: newTestList(newTestTuple(1, "a"), newTestTuple(2, "b"), newTestTuple(3, None)).ToObject()},
+		{f: "map", args: wrapArgs(None, "ab", newTestList(1), newTestTuple(5, 6, 7)), want: newTestList(newTestTuple("a", 1, 5), newTestTuple("b", None, 6), newTestTuple(None, None, 7)).ToObject()},
+		{f: "map", args: wrapArgs(None, newTestList(), newTestTuple()), want: newTestList().ToObject()},
 		{f: "max", args: wrapArgs(2, 3, 1), want: NewInt(3).ToObject()},
 		{f: "max", args: wrapArgs("bar", "foo"), want: NewStr("foo").ToObject()},
 		{f: "max", args: wrapArgs(newTestList(2, 3, 1)), want: NewInt(3).ToObject()},
@@ -248,6 +378,11 @@ func TestBuiltinFuncs(t *testing.T) {
 		{f: "max", args: wrapArgs(1), wantExc: mustCreateException(TypeErrorType, "'int' object is not iterable")},
 		{f: "max", args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "'max' requires 1 arguments")},
 		{f: "max", args: wrapArgs(newTestList()), wantExc: mustCreateException(ValueErrorType, "max() arg is an empty sequence")},
+		{f: "max", args: wrapArgs(newTestTuple(1, "a"), newTestTuple(1, "b")), kwargs: wrapKWArgs("key", first), want: newTestTuple(1, "a").ToObject()},
+		{f: "max", args: wrapArgs(newTestList()), kwargs: wrapKWArgs("default", "foo"), want: NewStr("foo").ToObject()},
+		{f: "max", args: wrapArgs(newTestList(1, 3)), kwargs: wrapKWArgs("default", 2), want: NewInt(3).ToObject()},
+		{f: "max", args: wrapArgs(newTestList()), kwargs: wrapKWArgs("key", neg, "default", None), want: None},
+		{f: "max", args: wrapArgs(1, 2), kwargs: wrapKWArgs("default", 3), wantExc: mustCreateException(TypeErrorType, "Cannot specify a default for max() with multiple positional arguments")},
 		{f: "max", args: wrapArgs(1, 2), kwargs: wrapKWArgs("key", raiseKey), wantExc: mustCreateException(RuntimeErrorType, "foo")},
 		{f: "min", args: wrapArgs(2, 3, 1), want: NewInt(1).ToObject()},
 		{f: "min", args: wrapArgs("bar", "foo"), want: NewStr("bar").ToObject()},
@@ -269,6 +404,11 @@ func TestBuiltinFuncs(t *testing.T) {
 		{f: "min", args: wrapArgs(1), wantExc: mustCreateException(TypeErrorType, "'int' object is not iterable")},
 		{f: "min", args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "'min' requires 1 arguments")},
 		{f: "min", args: wrapArgs(newTestList()), wantExc: mustCreateException(ValueErrorType, "min() arg is an empty sequence")},
+		{f: "min", args: wrapArgs(newTestTuple(1, "a"), newTestTuple(1, "b")), kwargs: wrapKWArgs("key", first), want: newTestTuple(1, "a").ToObject()},
+		{f: "min", args: wrapArgs(newTestList()), kwargs: wrapKWArgs("default", "foo"), want: NewStr("foo").ToObject()},
+		{f: "min", args: wrapArgs(newTestList(1, 3)), kwargs: wrapKWArgs("default", 2), want: NewInt(1).ToObject()},
+		{f: "min", args: wrapArgs(newTestList()), kwargs: wrapKWArgs("key", neg, "default", None), want: None},
+		{f: "min", args: wrapArgs(1, 2), kwargs: wrapKWArgs("default", 3), wantExc: mustCreateException(TypeErrorType, "Cannot specify a default for min() with multiple positional arguments")},
 		{f: "min", args: wrapArgs(1, 2), kwargs: wrapKWArgs("key", raiseKey), wantExc: mustCreateException(RuntimeErrorType, "foo")},
 		{f: "oct", args: wrapArgs(077), want: NewStr("077").ToObject()},
 		{f: "oct", args: wrapArgs(0), want: NewStr("0").ToObject()},
@@ -281,10 +421,39 @@ func TestBuiltinFuncs(t *testing.T) {
 		{f: "oct", args: wrapArgs(newObject(hexOctType)), want: NewStr("0octal").ToObject()},
 		{f: "ord", args: wrapArgs("a"), want: NewInt(97).ToObject()},
 		{f: "ord", args: wrapArgs(NewUnicode("樂")), want: NewInt(63764).ToObject()},
-		{f: "ord", args: wrapArgs("foo"), wantExc: mustCreateException(ValueErrorType, "ord() expected a character, but string of length 3 found")},
-		{f: "ord", args: wrapArgs(NewUnicode("волн")), wantExc: mustCreateException(ValueErrorType, "ord() expected a character, but string of length 4 found")},
+		{f: "ord", args: wrapArgs("\xff"), want: NewInt(255).ToObject()},
+		{f: "ord", args: wrapArgs("\x00"), want: NewInt(0).ToObject()},
+		{f: "ord", args: wrapArgs("foo"), wantExc: mustCreateException(TypeErrorType, "ord() expected a character, but string of length 3 found")},
+		{f: "ord", args: wrapArgs(""), wantExc: mustCreateException(TypeErrorType, "ord() expected a character, but string of length 0 found")},
+		{f: "ord", args: wrapArgs(NewUnicode("волн")), wantExc: mustCreateException(TypeErrorType, "ord() expected a character, but string of length 4 found")},
+		{f: "ord", args: wrapArgs(1), wantExc: mustCreateException(TypeErrorType, "ord() expected string of length 1, but int found")},
 		{f: "ord", args: wrapArgs(1, 2, 3), wantExc: mustCreateException(TypeErrorType, "'ord' requires 1 arguments")},
-		{f: "range", args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "'__new__' of 'int' requires 3 arguments")},
+		{f: "pow", args: wrapArgs(2, 10), want: NewInt(1024).ToObject()},
+		{f: "pow", args: wrapArgs(2, -1), want: NewFloat(0.5).ToObject()},
+		{f: "pow", args: wrapArgs(2, 10, 7), want: NewInt(2).ToObject()},
+		{f: "pow", args: wrapArgs(2, 3, -5), want: NewInt(-2).ToObject()},
+		{f: "pow", args: wrapArgs(-2, 3, 5), want: NewInt(2).ToObject()},
+		{f: "pow", args: wrapArgs(3, 0, 1), want: NewInt(0).ToObject()},
+		{f: "pow", args: wrapArgs(4, new(big.Int).Lsh(big.NewInt(1), 100), modExpMod), want: NewLong(new(big.Int).Exp(big.NewInt(4), new(big.Int).Lsh(big.NewInt(1), 100), modExpMod)).ToObject()},
+		{f: "pow", args: wrapArgs(2, -1, 5), wantExc: mustCreateException(TypeErrorType, "pow() 2nd argument cannot be negative when 3rd argument specified")},
+		{f: "pow", args: wrapArgs(2, 3, 0), wantExc: mustCreateException(ValueErrorType, "pow() 3rd argument cannot be 0")},
+		{f: "pow", args: wrapArgs(2.0, 3, 5), wantExc: mustCreateException(TypeErrorType, "pow() 3rd argument not allowed unless all arguments are integers")},
+		{f: "pow", args: wrapArgs("a", 3, 5), wantExc: mustCreateException(TypeErrorType, "unsupported operand type(s) for pow(): 'str', 'int', 'int'")},
+		{f: "pow", args: wrapArgs(newObject(powType), 3, 5), want: newTestTuple(3, 5).ToObject()},
+		{f: "pow", args: wrapArgs(1), wantExc: mustCreateException(TypeErrorType, "'pow' requires 3 arguments")},
+		{f: "range", args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "range expected at least 1 arguments, got 0")},
+		{f: "range", args: wrapArgs(1, 2, 3, 4), wantExc: mustCreateException(TypeErrorType, "range expected at most 3 arguments, got 4")},
+		{f: "range", args: wrapArgs(1.0), wantExc: mustCreateException(TypeErrorType, "range() integer end argument expected, got float.")},
+		{f: "range", args: wrapArgs(1.0, 3), wantExc: mustCreateException(TypeErrorType, "range() integer start argument expected, got float.")},
+		{f: "range", args: wrapArgs(1, 3, "a"), wantExc: mustCreateException(TypeErrorType, "range() integer step argument expected, got str.")},
+		{f: "range", args: wrapArgs(1, 3, 0), wantExc: mustCreateException(ValueErrorType, "range() step argument must not be zero")},
+		{f: "range", args: wrapArgs(0, MaxInt), wantExc: mustCreateException(OverflowErrorType, "range() result has too many items")},
+		{f: "range", args: wrapArgs(MinInt, MaxInt), wantExc: mustCreateException(OverflowErrorType, "range() result has too many items")},
+		{f: "range", args: wrapArgs(new(big.Int).Lsh(big.NewInt(1), 100)), wantExc: mustCreateException(OverflowErrorType, "range() result has too many items")},
+		{f: "range", args: wrapArgs(new(big.Int).Lsh(big.NewInt(1), 40)), wantExc: mustCreateException(OverflowErrorType, "range() result has too many items")},
+		{f: "range", args: wrapArgs(big.NewInt(2), newTestIndexObject(4)), want: newTestList(2, 3).ToObject()},
+		{f: "range", args: wrapArgs(5, 0, -2), want: newTestList(5, 3, 1).ToObject()},
+		{f: "range", args: wrapArgs(true), want: newTestList(0).ToObject()},
 		{f: "range", args: wrapArgs(3), want: newTestList(0, 1, 2).ToObject()},
 		{f: "range", args: wrapArgs(10, 0), want: NewList().ToObject()},
 		{f: "range", args: wrapArgs(-12, -23, -5), want: newTestList(-12, -17, -22).ToObject()},
@@ -316,8 +485,18 @@ func TestBuiltinFuncs(t *testing.T) {
 		{f: "sorted", args: wrapArgs(newTestRange(100)), want: newTestRange(100).ToObject()},
 		{f: "sorted", args: wrapArgs(newTestTuple(1, 2, 0, 3)), want: newTestRange(4).ToObject()},
 		{f: "sorted", args: wrapArgs(newTestDict("foo", 1, "bar", 2)), want: newTestList("bar", "foo").ToObject()},
+		{f: "sorted", args: wrapArgs(newTestList(1, -3, 2)), kwargs: wrapKWArgs("key", neg), want: newTestList(2, 1, -3).ToObject()},
+		{f: "sorted", args: wrapArgs(newTestList(1, 3, 2)), kwargs: wrapKWArgs("reverse", true), want: newTestList(3, 2, 1).ToObject()},
+		{f: "sorted", args: wrapArgs(newTestList(1, 3, 2)), kwargs: wrapKWArgs("key", None, "cmp", None), want: newTestList(1, 2, 3).ToObject()},
+		{f: "sorted", args: wrapArgs(newTestList(1, 3)), kwargs: wrapKWArgs("key", raiseKey), wantExc: mustCreateException(RuntimeErrorType, "foo")},
 		{f: "sorted", args: wrapArgs(1), wantExc: mustCreateException(TypeErrorType, "'int' object is not iterable")},
-		{f: "sorted", args: wrapArgs(newTestList("foo", "bar"), 2), wantExc: mustCreateException(TypeErrorType, "'sorted' requires 1 arguments")},
+		{f: "sorted", args: wrapArgs(newTestList("foo", "bar"), 2), wantExc: mustCreateException(TypeErrorType, "'int' object is not callable")},
+		{f: "sorted", args: wrapArgs(newTestList(1, -3, 2), None, neg), want: newTestList(2, 1, -3).ToObject()},
+		{f: "sorted", args: wrapArgs(newTestList(1, 3, 2), None, None, true), want: newTestList(3, 2, 1).ToObject()},
+		{f: "sorted", args: wrapArgs(newTestList(1, 3, 2), None, None, true, None), wantExc: mustCreateException(TypeErrorType, "sorted() takes at most 4 arguments (5 given)")},
+		{f: "sorted", args: wrapArgs(newTestList(1, 3, 2), None), kwargs: wrapKWArgs("cmp", None), wantExc: mustCreateException(TypeErrorType, "Argument given by name ('cmp') and position (2)")},
+		{f: "sorted", args: wrapArgs(newTestList(1, 3, 2)), kwargs: wrapKWArgs("foo", None), wantExc: mustCreateException(TypeErrorType, "'foo' is an invalid keyword argument for this function")},
+		{f: "sorted", args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "'sorted' requires 1 arguments")},
 		{f: "sum", args: wrapArgs(newTestList(1, 2, 3, 4)), want: NewInt(10).ToObject()},
 		{f: "sum", args: wrapArgs(newTestList(1, 2), 3), want: NewFloat(6).ToObject()},
 		{f: "sum", args: wrapArgs(newTestList(2, 1.1)), want: NewFloat(3.1).ToObject()},
@@ -325,6 +504,12 @@ func TestBuiltinFuncs(t *testing.T) {
 		{f: "sum", args: wrapArgs(newTestList(2, 1.1, 2.0)), want: NewFloat(5.1).ToObject()},
 		{f: "sum", args: wrapArgs(newTestList(1), newObject(addType)), want: NewInt(1).ToObject()},
 		{f: "sum", args: wrapArgs(newTestList(newObject(addType)), newObject(addType)), want: NewInt(1).ToObject()},
+		{f: "sum", args: wrapArgs(newTestList(1, 2), 10), want: NewInt(13).ToObject()},
+		{f: "sum", args: wrapArgs(newTestList(newTestList(2), newTestList(3)), newTestList(1)), want: newTestList(1, 2, 3).ToObject()},
+		{f: "sum", args: wrapArgs(newTestList(), ""), wantExc: mustCreateException(TypeErrorType, "sum() can't sum strings [use ''.join(seq) instead]")},
+		{f: "sum", args: wrapArgs(newTestList("a"), NewUnicode("b")), wantExc: mustCreateException(TypeErrorType, "sum() can't sum strings [use ''.join(seq) instead]")},
+		{f: "sum", args: wrapArgs(newTestList("a", "b")), wantExc: mustCreateException(TypeErrorType, "unsupported operand type(s) for +: 'int' and 'str'")},
+		{f: "sum", args: wrapArgs(1), wantExc: mustCreateException(TypeErrorType, "'int' object is not iterable")},
 		{f: "unichr", args: wrapArgs(0), want: NewUnicode("\x00").ToObject()},
 		{f: "unichr", args: wrapArgs(65), want: NewStr("A").ToObject()},
 		{f: "unichr", args: wrapArgs(0x120000), wantExc: mustCreateException(ValueErrorType, "unichr() arg not in range(0x10ffff)")},
@@ -368,6 +553,64 @@ func TestBuiltinGlobals(t *testing.T) {
 	}
 }
 
+func TestBuiltinLocals(t *testing.T) {
+	f := NewRootFrame()
+	f.globals = newTestDict("foo", 1)
+	locals := mustNotRaise(Builtins.GetItemString(f, "locals"))
+	if got := mustNotRaise(locals.Call(f, nil, nil)); got != f.globals.ToObject() {
+		t.Errorf("locals() at module level = %v, want globals %v", got, f.globals)
+	}
+	foo := NewInt(42).ToObject()
+	f.SetLocals(NewLocals([]string{"foo"}, func() []*Object { return []*Object{foo} }))
+	got, raised := locals.Call(f, nil, nil)
+	want := newTestDict("foo", 42).ToObject()
+	if checkResult(got, want, raised, nil) != checkInvokeResultOk {
+		t.Errorf("locals() = (%v, %v), want (%v, nil)", got, raised, want)
+	}
+	if _, raised := locals.Call(f, wrapArgs(1), nil); raised == nil {
+		t.Errorf("locals(1) did not raise")
+	}
+}
+
+func TestBuiltinDirNoArgs(t *testing.T) {
+	f := NewRootFrame()
+	f.globals = newTestDict("foo", 1, "bar", 2)
+	dir := mustNotRaise(Builtins.GetItemString(f, "dir"))
+	got, raised := dir.Call(f, nil, nil)
+	want := newTestList("bar", "foo").ToObject()
+	if checkResult(got, want, raised, nil) != checkInvokeResultOk {
+		t.Errorf("dir() = (%v, %v), want (%v, nil)", got, raised, want)
+	}
+	baz := NewInt(3).ToObject()
+	f.SetLocals(NewLocals([]string{"baz", "qux"}, func() []*Object { return []*Object{baz, UnboundLocal} }))
+	got, raised = dir.Call(f, nil, nil)
+	want = newTestList("baz").ToObject()
+	if checkResult(got, want, raised, nil) != checkInvokeResultOk {
+		t.Errorf("dir() = (%v, %v), want (%v, nil)", got, raised, want)
+	}
+}
+
+func TestBuiltinIntern(t *testing.T) {
+	f := NewRootFrame()
+	intern := mustNotRaise(Builtins.GetItemString(f, "intern"))
+	s1, s2 := NewStr("interned"+"foo"), NewStr("internedfoo")
+	if s1 == s2 {
+		t.Fatalf("NewStr() returned the same object for %q", s1.Value())
+	}
+	got1 := mustNotRaise(intern.Call(f, Args{s1.ToObject()}, nil))
+	got2 := mustNotRaise(intern.Call(f, Args{s2.ToObject()}, nil))
+	if got1 != s1.ToObject() {
+		t.Errorf("intern(%v) returned a different object", s1)
+	}
+	if got2 != got1 {
+		t.Errorf("intern(%v) = %v, want the previously interned %v", s2, got2, got1)
+	}
+	// Strings interned at init time are returned as is.
+	if got := mustNotRaise(intern.Call(f, wrapArgs("a"), nil)); got != NewStr("a").ToObject() {
+		t.Errorf("intern('a') = %v, want the builtin interned 'a'", got)
+	}
+}
+
 func TestEllipsisRepr(t *testing.T) {
 	cas := invokeTestCase{args: wrapArgs(Ellipsis), want: NewStr("Ellipsis").ToObject()}
 	if err := runInvokeMethodTestCase(EllipsisType, "__repr__", &cas); err != "" {
@@ -0,0 +1,379 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grumpy
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ByteArrayType is the object representing the Python 'bytearray' type.
+var ByteArrayType = newBasisType("bytearray", reflect.TypeOf(ByteArray{}), toByteArrayUnsafe, ObjectType)
+
+// ByteArray represents Python 'bytearray' objects, mutable sequences of
+// bytes.
+type ByteArray struct {
+	Object
+	mutex sync.RWMutex
+	value []byte
+}
+
+// NewByteArray returns a new ByteArray holding a copy of the given bytes.
+func NewByteArray(b []byte) *ByteArray {
+	return &ByteArray{Object: Object{typ: ByteArrayType}, value: append([]byte(nil), b...)}
+}
+
+func toByteArrayUnsafe(o *Object) *ByteArray {
+	return (*ByteArray)(o.toPointer())
+}
+
+// ToObject upcasts a to an Object.
+func (a *ByteArray) ToObject() *Object {
+	return &a.Object
+}
+
+// Value returns a copy of the bytes held by a.
+func (a *ByteArray) Value() []byte {
+	a.mutex.RLock()
+	v := append([]byte(nil), a.value...)
+	a.mutex.RUnlock()
+	return v
+}
+
+func byteArrayAdd(f *Frame, v, w *Object) (*Object, *BaseException) {
+	b, ok := byteArrayCoerce(w)
+	if !ok {
+		format := "can't concat bytearray to %s"
+		return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, w.typ.Name()))
+	}
+	return NewByteArray(append(toByteArrayUnsafe(v).Value(), b...)).ToObject(), nil
+}
+
+func byteArrayAppend(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "append", args, ByteArrayType, ObjectType); raised != nil {
+		return nil, raised
+	}
+	c, raised := byteArrayGetByte(f, args[1])
+	if raised != nil {
+		return nil, raised
+	}
+	a := toByteArrayUnsafe(args[0])
+	a.mutex.Lock()
+	a.value = append(a.value, c)
+	a.mutex.Unlock()
+	return None, nil
+}
+
+func byteArrayContains(f *Frame, o, value *Object) (*Object, *BaseException) {
+	s := string(toByteArrayUnsafe(o).Value())
+	if b, ok := byteArrayCoerce(value); ok {
+		return GetBool(strings.Contains(s, string(b))).ToObject(), nil
+	}
+	c, raised := byteArrayGetByte(f, value)
+	if raised != nil {
+		return nil, raised
+	}
+	return GetBool(strings.IndexByte(s, c) != -1).ToObject(), nil
+}
+
+func byteArrayDecode(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	expectedTypes := []*Type{ByteArrayType, StrType, StrType}
+	argc := len(args)
+	if argc >= 1 && argc < 3 {
+		expectedTypes = expectedTypes[:argc]
+	}
+	if raised := checkMethodArgs(f, "decode", args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	encoding := EncodeDefault
+	if argc > 1 {
+		encoding = toStrUnsafe(args[1]).Value()
+	}
+	errors := EncodeStrict
+	if argc > 2 {
+		errors = toStrUnsafe(args[2]).Value()
+	}
+	s, raised := NewStr(string(toByteArrayUnsafe(args[0]).Value())).Decode(f, encoding, errors)
+	if raised != nil {
+		return nil, raised
+	}
+	return s.ToObject(), nil
+}
+
+func byteArrayEq(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return byteArrayCompare(v, w, False, True, False), nil
+}
+
+func byteArrayGE(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return byteArrayCompare(v, w, False, True, True), nil
+}
+
+func byteArrayGetItem(f *Frame, o, key *Object) (*Object, *BaseException) {
+	b := toByteArrayUnsafe(o).Value()
+	if key.typ.slots.Index != nil {
+		index, raised := IndexInt(f, key)
+		if raised != nil {
+			return nil, raised
+		}
+		if index < 0 {
+			index += len(b)
+		}
+		if index < 0 || index >= len(b) {
+			return nil, f.RaiseType(IndexErrorType, "bytearray index out of range")
+		}
+		return NewInt(int(b[index])).ToObject(), nil
+	}
+	if !key.isInstance(SliceType) {
+		return nil, f.RaiseType(TypeErrorType, "bytearray indices must be integers")
+	}
+	s, raised := strGetItem(f, NewStr(string(b)).ToObject(), key)
+	if raised != nil {
+		return nil, raised
+	}
+	return NewByteArray([]byte(toStrUnsafe(s).Value())).ToObject(), nil
+}
+
+func byteArrayGT(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return byteArrayCompare(v, w, False, False, True), nil
+}
+
+func byteArrayInit(f *Frame, o *Object, args Args, _ KWArgs) (*Object, *BaseException) {
+	expectedTypes := []*Type{ObjectType, StrType, StrType}
+	argc := len(args)
+	if argc < 3 {
+		expectedTypes = expectedTypes[:argc]
+	}
+	if raised := checkFunctionArgs(f, "bytearray", args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	var value []byte
+	if argc > 0 {
+		var raised *BaseException
+		if value, raised = byteArrayFromObject(f, args[0], args[1:]); raised != nil {
+			return nil, raised
+		}
+	}
+	a := toByteArrayUnsafe(o)
+	a.mutex.Lock()
+	a.value = value
+	a.mutex.Unlock()
+	return None, nil
+}
+
+func byteArrayLE(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return byteArrayCompare(v, w, True, True, False), nil
+}
+
+func byteArrayLen(f *Frame, o *Object) (*Object, *BaseException) {
+	a := toByteArrayUnsafe(o)
+	a.mutex.RLock()
+	n := len(a.value)
+	a.mutex.RUnlock()
+	return NewInt(n).ToObject(), nil
+}
+
+func byteArrayLT(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return byteArrayCompare(v, w, True, False, False), nil
+}
+
+func byteArrayMul(f *Frame, v, w *Object) (*Object, *BaseException) {
+	b := toByteArrayUnsafe(v).Value()
+	n, ok, raised := strRepeatCount(f, len(b), w)
+	if raised != nil {
+		return nil, raised
+	}
+	if !ok {
+		return NotImplemented, nil
+	}
+	return NewByteArray(bytes.Repeat(b, n)).ToObject(), nil
+}
+
+func byteArrayNE(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return byteArrayCompare(v, w, True, False, True), nil
+}
+
+func byteArrayRAdd(f *Frame, v, w *Object) (*Object, *BaseException) {
+	b, ok := byteArrayCoerce(w)
+	if !ok {
+		return NotImplemented, nil
+	}
+	return NewByteArray(append(b, toByteArrayUnsafe(v).Value()...)).ToObject(), nil
+}
+
+func byteArrayRepr(f *Frame, o *Object) (*Object, *BaseException) {
+	r, raised := strRepr(f, NewStr(string(toByteArrayUnsafe(o).Value())).ToObject())
+	if raised != nil {
+		return nil, raised
+	}
+	return NewStr("bytearray(b" + toStrUnsafe(r).Value() + ")").ToObject(), nil
+}
+
+func byteArraySetItem(f *Frame, o, key, value *Object) *BaseException {
+	if key.typ.slots.Index == nil {
+		return f.RaiseType(TypeErrorType, "bytearray indices must be integers")
+	}
+	index, raised := IndexInt(f, key)
+	if raised != nil {
+		return raised
+	}
+	c, raised := byteArrayGetByte(f, value)
+	if raised != nil {
+		return raised
+	}
+	a := toByteArrayUnsafe(o)
+	a.mutex.Lock()
+	if index < 0 {
+		index += len(a.value)
+	}
+	if index < 0 || index >= len(a.value) {
+		raised = f.RaiseType(IndexErrorType, "bytearray index out of range")
+	} else {
+		a.value[index] = c
+	}
+	a.mutex.Unlock()
+	return raised
+}
+
+func byteArrayStr(f *Frame, o *Object) (*Object, *BaseException) {
+	return NewStr(string(toByteArrayUnsafe(o).Value())).ToObject(), nil
+}
+
+func initByteArrayType(dict map[string]*Object) {
+	dict["append"] = newBuiltinFunction("append", byteArrayAppend).ToObject()
+	dict["decode"] = newBuiltinFunction("decode", byteArrayDecode).ToObject()
+	ByteArrayType.slots.Add = &binaryOpSlot{byteArrayAdd}
+	ByteArrayType.slots.Contains = &binaryOpSlot{byteArrayContains}
+	ByteArrayType.slots.Eq = &binaryOpSlot{byteArrayEq}
+	ByteArrayType.slots.GE = &binaryOpSlot{byteArrayGE}
+	ByteArrayType.slots.GetItem = &binaryOpSlot{byteArrayGetItem}
+	ByteArrayType.slots.GT = &binaryOpSlot{byteArrayGT}
+	ByteArrayType.slots.Hash = &unaryOpSlot{hashNotImplemented}
+	ByteArrayType.slots.Init = &initSlot{byteArrayInit}
+	ByteArrayType.slots.LE = &binaryOpSlot{byteArrayLE}
+	ByteArrayType.slots.Len = &unaryOpSlot{byteArrayLen}
+	ByteArrayType.slots.LT = &binaryOpSlot{byteArrayLT}
+	ByteArrayType.slots.Mul = &binaryOpSlot{byteArrayMul}
+	ByteArrayType.slots.NE = &binaryOpSlot{byteArrayNE}
+	ByteArrayType.slots.RAdd = &binaryOpSlot{byteArrayRAdd}
+	ByteArrayType.slots.Repr = &unaryOpSlot{byteArrayRepr}
+	ByteArrayType.slots.RMul = &binaryOpSlot{byteArrayMul}
+	ByteArrayType.slots.SetItem = &setItemSlot{byteArraySetItem}
+	ByteArrayType.slots.Str = &unaryOpSlot{byteArrayStr}
+}
+
+// byteArrayCoerce returns the bytes of o if it is a str, bytearray or buffer.
+func byteArrayCoerce(o *Object) ([]byte, bool) {
+	switch {
+	case o.isInstance(StrType):
+		return []byte(toStrUnsafe(o).Value()), true
+	case o.isInstance(ByteArrayType):
+		return toByteArrayUnsafe(o).Value(), true
+	case o.isInstance(BufferType):
+		return []byte(toBufferUnsafe(o).Value()), true
+	}
+	return nil, false
+}
+
+func byteArrayCompare(v, w *Object, ltResult, eqResult, gtResult *Int) *Object {
+	if v == w {
+		return eqResult.ToObject()
+	}
+	b, ok := byteArrayCoerce(w)
+	if !ok {
+		return NotImplemented
+	}
+	switch bytes.Compare(toByteArrayUnsafe(v).Value(), b) {
+	case -1:
+		return ltResult.ToObject()
+	case 0:
+		return eqResult.ToObject()
+	}
+	return gtResult.ToObject()
+}
+
+// byteArrayFromObject returns the initial contents of a bytearray constructed
+// from o with the given optional encoding and errors arguments.
+func byteArrayFromObject(f *Frame, o *Object, encodingArgs Args) ([]byte, *BaseException) {
+	if o.isInstance(UnicodeType) {
+		if len(encodingArgs) == 0 {
+			return nil, f.RaiseType(TypeErrorType, "unicode argument without an encoding")
+		}
+		errors := EncodeStrict
+		if len(encodingArgs) > 1 {
+			errors = toStrUnsafe(encodingArgs[1]).Value()
+		}
+		s, raised := toUnicodeUnsafe(o).Encode(f, toStrUnsafe(encodingArgs[0]).Value(), errors)
+		if raised != nil {
+			return nil, raised
+		}
+		return []byte(s.Value()), nil
+	}
+	if b, ok := byteArrayCoerce(o); ok {
+		return b, nil
+	}
+	if len(encodingArgs) > 0 {
+		return nil, f.RaiseType(TypeErrorType, "encoding or errors without a string argument")
+	}
+	if o.typ.slots.Index != nil {
+		n, raised := IndexInt(f, o)
+		if raised != nil {
+			return nil, raised
+		}
+		if n < 0 {
+			return nil, f.RaiseType(ValueErrorType, "negative count")
+		}
+		if uint64(n) > maxAllocSize {
+			return nil, f.Raise(MemoryErrorType.ToObject(), nil, nil)
+		}
+		return make([]byte, n), nil
+	}
+	var value []byte
+	raised := seqForEach(f, o, func(item *Object) *BaseException {
+		c, raised := byteArrayGetByte(f, item)
+		if raised == nil {
+			value = append(value, c)
+		}
+		return raised
+	})
+	if raised != nil {
+		return nil, raised
+	}
+	return value, nil
+}
+
+// byteArrayGetByte converts o, an integer in range(0, 256) or a str of length
+// one, to a byte.
+func byteArrayGetByte(f *Frame, o *Object) (byte, *BaseException) {
+	if o.isInstance(StrType) {
+		if s := toStrUnsafe(o).Value(); len(s) == 1 {
+			return s[0], nil
+		}
+		return 0, f.RaiseType(ValueErrorType, "string must be of size 1")
+	}
+	if o.typ.slots.Index == nil {
+		return 0, f.RaiseType(TypeErrorType, "an integer or string of size 1 is required")
+	}
+	i, raised := IndexInt(f, o)
+	if raised != nil {
+		return 0, raised
+	}
+	if i < 0 || i > 255 {
+		return 0, f.RaiseType(ValueErrorType, "byte must be in range(0, 256)")
+	}
+	return byte(i), nil
+}
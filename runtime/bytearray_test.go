@@ -0,0 +1,165 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grumpy
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestByteArrayNew(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(), want: newTestByteArray("").ToObject()},
+		{args: wrapArgs(3), want: newTestByteArray("\x00\x00\x00").ToObject()},
+		{args: wrapArgs("abc"), want: newTestByteArray("abc").ToObject()},
+		{args: wrapArgs("abc", "utf-8"), want: newTestByteArray("abc").ToObject()},
+		{args: wrapArgs(NewUnicode("hé"), "utf-8"), want: newTestByteArray("h\xc3\xa9").ToObject()},
+		{args: wrapArgs(newTestByteArray("xyz")), want: newTestByteArray("xyz").ToObject()},
+		{args: wrapArgs(newTestList(1, "a", 255)), want: newTestByteArray("\x01a\xff").ToObject()},
+		{args: wrapArgs(NewUnicode("abc")), wantExc: mustCreateException(TypeErrorType, "unicode argument without an encoding")},
+		{args: wrapArgs(3, "utf-8"), wantExc: mustCreateException(TypeErrorType, "encoding or errors without a string argument")},
+		{args: wrapArgs(-1), wantExc: mustCreateException(ValueErrorType, "negative count")},
+		{args: wrapArgs(MaxInt), wantExc: mustCreateException(MemoryErrorType, "")},
+		{args: wrapArgs(big.NewInt(1 << 40)), wantExc: mustCreateException(MemoryErrorType, "")},
+		{args: wrapArgs(newTestList(1, 256)), wantExc: mustCreateException(ValueErrorType, "byte must be in range(0, 256)")},
+		{args: wrapArgs(newTestList(None)), wantExc: mustCreateException(TypeErrorType, "an integer or string of size 1 is required")},
+		{args: wrapArgs(3.14), wantExc: mustCreateException(TypeErrorType, "'float' object is not iterable")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(ByteArrayType.ToObject(), &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestByteArrayBinaryOps(t *testing.T) {
+	cases := []struct {
+		fun     func(f *Frame, v, w *Object) (*Object, *BaseException)
+		v, w    *Object
+		want    *Object
+		wantExc *BaseException
+	}{
+		{Add, newTestByteArray("ab").ToObject(), NewStr("c").ToObject(), newTestByteArray("abc").ToObject(), nil},
+		{Add, NewStr("c").ToObject(), newTestByteArray("ab").ToObject(), newTestByteArray("cab").ToObject(), nil},
+		{Add, newTestByteArray("ab").ToObject(), newTestByteArray("c").ToObject(), newTestByteArray("abc").ToObject(), nil},
+		{Add, newTestByteArray("ab").ToObject(), NewUnicode("c").ToObject(), nil, mustCreateException(TypeErrorType, "can't concat bytearray to unicode")},
+		{Add, newTestByteArray("ab").ToObject(), NewInt(1).ToObject(), nil, mustCreateException(TypeErrorType, "can't concat bytearray to int")},
+		{Mul, newTestByteArray("ab").ToObject(), NewInt(2).ToObject(), newTestByteArray("abab").ToObject(), nil},
+		{Mul, NewInt(0).ToObject(), newTestByteArray("ab").ToObject(), newTestByteArray("").ToObject(), nil},
+		{Eq, newTestByteArray("ab").ToObject(), NewStr("ab").ToObject(), True.ToObject(), nil},
+		{Eq, NewStr("ab").ToObject(), newTestByteArray("ab").ToObject(), True.ToObject(), nil},
+		{NE, newTestByteArray("ab").ToObject(), newTestByteArray("ac").ToObject(), True.ToObject(), nil},
+		{LT, newTestByteArray("ab").ToObject(), NewStr("b").ToObject(), True.ToObject(), nil},
+		{GE, newTestByteArray("ab").ToObject(), NewStr("abc").ToObject(), False.ToObject(), nil},
+	}
+	for _, cas := range cases {
+		testCase := invokeTestCase{args: wrapArgs(cas.v, cas.w), want: cas.want, wantExc: cas.wantExc}
+		if err := runInvokeTestCase(wrapFuncForTest(cas.fun), &testCase); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestByteArrayContains(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(newTestByteArray("abc"), 98), want: True.ToObject()},
+		{args: wrapArgs(newTestByteArray("abc"), 100), want: False.ToObject()},
+		{args: wrapArgs(newTestByteArray("abc"), "bc"), want: True.ToObject()},
+		{args: wrapArgs(newTestByteArray("abc"), ""), want: True.ToObject()},
+		{args: wrapArgs(newTestByteArray("abc"), 300), wantExc: mustCreateException(ValueErrorType, "byte must be in range(0, 256)")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(ByteArrayType, "__contains__", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestByteArrayDecode(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(newTestByteArray("abc")), want: NewUnicode("abc").ToObject()},
+		{args: wrapArgs(newTestByteArray("h\xc3\xa9"), "utf-8"), want: NewUnicode("hé").ToObject()},
+		{args: wrapArgs(newTestByteArray("\xff"), "utf-8", "replace"), want: NewUnicode("�").ToObject()},
+		{args: wrapArgs(newTestByteArray("\xff"), "utf-8"), wantExc: mustCreateException(UnicodeDecodeErrorType, "'utf-8' codec can't decode byte 0xff in position 0")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(ByteArrayType, "decode", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestByteArrayGetItem(t *testing.T) {
+	a := newTestByteArray("hello")
+	cases := []invokeTestCase{
+		{args: wrapArgs(a, 0), want: NewInt(104).ToObject()},
+		{args: wrapArgs(a, -1), want: NewInt(111).ToObject()},
+		{args: wrapArgs(a, newTestSlice(1, 3)), want: newTestByteArray("el").ToObject()},
+		{args: wrapArgs(a, newTestSlice(None, None, -1)), want: newTestByteArray("olleh").ToObject()},
+		{args: wrapArgs(a, 5), wantExc: mustCreateException(IndexErrorType, "bytearray index out of range")},
+		{args: wrapArgs(a, "foo"), wantExc: mustCreateException(TypeErrorType, "bytearray indices must be integers")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(wrapFuncForTest(GetItem), &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestByteArraySetItem(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, o, key, value *Object) (*Object, *BaseException) {
+		if raised := SetItem(f, o, key, value); raised != nil {
+			return nil, raised
+		}
+		return o, nil
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(newTestByteArray("abc"), 0, 65), want: newTestByteArray("Abc").ToObject()},
+		{args: wrapArgs(newTestByteArray("abc"), -1, "Z"), want: newTestByteArray("abZ").ToObject()},
+		{args: wrapArgs(newTestByteArray("abc"), 3, 65), wantExc: mustCreateException(IndexErrorType, "bytearray index out of range")},
+		{args: wrapArgs(newTestByteArray("abc"), 0, 256), wantExc: mustCreateException(ValueErrorType, "byte must be in range(0, 256)")},
+		{args: wrapArgs(newTestByteArray("abc"), 0, "xy"), wantExc: mustCreateException(ValueErrorType, "string must be of size 1")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestByteArrayStrRepr(t *testing.T) {
+	cases := []struct {
+		o        *Object
+		wantStr  string
+		wantRepr string
+	}{
+		{newTestByteArray("").ToObject(), "", "bytearray(b'')"},
+		{newTestByteArray("a\xff\"").ToObject(), "a\xff\"", `bytearray(b'a\xff"')`},
+	}
+	for _, cas := range cases {
+		s, raised := ToStr(NewRootFrame(), cas.o)
+		if raised != nil || s.Value() != cas.wantStr {
+			t.Errorf("str(%v) = (%v, %v), want %q", cas.o, s, raised, cas.wantStr)
+		}
+		r, raised := Repr(NewRootFrame(), cas.o)
+		if raised != nil || r.Value() != cas.wantRepr {
+			t.Errorf("repr(%v) = (%v, %v), want %q", cas.o, r, raised, cas.wantRepr)
+		}
+	}
+}
+
+func newTestByteArray(s string) *ByteArray {
+	return NewByteArray([]byte(s))
+}
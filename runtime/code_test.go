@@ -15,6 +15,8 @@
 package grumpy
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -107,3 +109,38 @@ func TestCodeEvalRestoreExc(t *testing.T) {
 		t.Error("c2 did not run")
 	}
 }
+
+func TestCodeEvalTraceback(t *testing.T) {
+	globals := NewDict()
+	inner := NewCode("inner", "foo.py", nil, 0, func(f *Frame, _ []*Object) (*Object, *BaseException) {
+		f.SetLineno(3)
+		return nil, f.RaiseType(ValueErrorType, "boom")
+	})
+	outer := NewCode("outer", "foo.py", nil, 0, func(f *Frame, _ []*Object) (*Object, *BaseException) {
+		f.SetLineno(2)
+		return inner.Eval(f, globals, nil, nil)
+	})
+	f := NewRootFrame()
+	f.SetLineno(1)
+	_, raised := outer.Eval(f, globals, nil, nil)
+	if raised == nil || raised.typ != ValueErrorType {
+		t.Fatalf("outer() raised %v, want ValueError", raised)
+	}
+	e, tb := f.ExcInfo()
+	if e != raised {
+		t.Errorf("ExcInfo() exception = %v, want %v", e, raised)
+	}
+	// The chain runs from the outermost frame to the one that raised.
+	want := []string{"<root>:1", "outer:2", "inner:3"}
+	var got []string
+	for ; tb != nil; tb = tb.next {
+		name := "<root>"
+		if tb.frame.code != nil {
+			name = tb.frame.code.name
+		}
+		got = append(got, fmt.Sprintf("%s:%d", name, tb.lineno))
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("traceback = %v, want %v", got, want)
+	}
+}
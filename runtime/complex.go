@@ -17,8 +17,10 @@ package grumpy
 import (
 	"fmt"
 	"math"
+	"math/cmplx"
 	"reflect"
 	"strconv"
+	"strings"
 )
 
 // ComplexType is the object representing the Python 'complex' type.
@@ -49,12 +51,43 @@ func (c *Complex) Value() complex128 {
 	return c.value
 }
 
+func complexAbs(f *Frame, o *Object) (*Object, *BaseException) {
+	c := toComplexUnsafe(o).Value()
+	result := cmplx.Abs(c)
+	if math.IsInf(result, 0) && !cmplx.IsInf(c) {
+		return nil, f.RaiseType(OverflowErrorType, "absolute value too large")
+	}
+	return NewFloat(result).ToObject(), nil
+}
+
 func complexAdd(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return complexArithmeticOp(f, "__add__", v, w, func(lhs, rhs complex128) complex128 {
 		return lhs + rhs
 	})
 }
 
+func complexCoerceMethod(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "__coerce__", args, ComplexType, ObjectType); raised != nil {
+		return nil, raised
+	}
+	w := args[1]
+	if w.isInstance(ComplexType) {
+		return NewTuple2(args[0], w).ToObject(), nil
+	}
+	complexW, ok := complexCoerce(w)
+	if !ok {
+		if math.IsInf(real(complexW), 0) {
+			return nil, f.RaiseType(OverflowErrorType, "long int too large to convert to float")
+		}
+		return NotImplemented, nil
+	}
+	return NewTuple2(args[0], NewComplex(complexW).ToObject()).ToObject(), nil
+}
+
+func complexDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return complexDivide(f, v, w, false)
+}
+
 func complexEq(f *Frame, v, w *Object) (*Object, *BaseException) {
 	e, ok := complexCompare(toComplexUnsafe(v), w)
 	if !ok {
@@ -72,6 +105,71 @@ func complexHash(f *Frame, o *Object) (*Object, *BaseException) {
 	return NewInt(hashCombined).ToObject(), nil
 }
 
+func complexNew(f *Frame, t *Type, args Args, kwargs KWArgs) (*Object, *BaseException) {
+	argc := len(args)
+	if argc > 2 {
+		return nil, f.RaiseType(TypeErrorType, fmt.Sprintf("complex() takes at most 2 arguments (%d given)", argc))
+	}
+	var realArg, imagArg *Object
+	if argc > 0 {
+		realArg = args[0]
+	}
+	if argc > 1 {
+		imagArg = args[1]
+	}
+	for _, kwarg := range kwargs {
+		switch kwarg.Name {
+		case "real":
+			realArg = kwarg.Value
+		case "imag":
+			imagArg = kwarg.Value
+		default:
+			return nil, f.RaiseType(TypeErrorType, fmt.Sprintf("'%s' is an invalid keyword argument for this function", kwarg.Name))
+		}
+	}
+	var value complex128
+	if realArg != nil && realArg.isInstance(StrType) {
+		if imagArg != nil {
+			return nil, f.RaiseType(TypeErrorType, "complex() can't take second arg if first is a string")
+		}
+		c, ok := complexParse(toStrUnsafe(realArg).Value())
+		if !ok {
+			return nil, f.RaiseType(ValueErrorType, "complex() arg is a malformed string")
+		}
+		value = c
+	} else if realArg != nil {
+		// Like CPython, the result of __complex__ is converted the same
+		// way as any other argument.
+		o, raised := complexSpecialMethod(f, realArg)
+		if raised != nil {
+			return nil, raised
+		}
+		c, raised := complexConvert(f, o)
+		if raised != nil {
+			return nil, raised
+		}
+		value = c
+	}
+	if imagArg != nil {
+		if imagArg.isInstance(StrType) {
+			return nil, f.RaiseType(TypeErrorType, "complex() second arg can't be a string")
+		}
+		c, raised := complexConvert(f, imagArg)
+		if raised != nil {
+			return nil, raised
+		}
+		// Like CPython, the result is real + imag*1j even when either
+		// argument is itself complex.
+		value = complex(real(value)-imag(c), imag(value)+real(c))
+	}
+	if t != ComplexType {
+		result := toComplexUnsafe(newObject(t))
+		result.value = value
+		return result.ToObject(), nil
+	}
+	return NewComplex(value).ToObject(), nil
+}
+
 func complexNE(f *Frame, v, w *Object) (*Object, *BaseException) {
 	e, ok := complexCompare(toComplexUnsafe(v), w)
 	if !ok {
@@ -86,6 +184,10 @@ func complexRAdd(f *Frame, v, w *Object) (*Object, *BaseException) {
 	})
 }
 
+func complexRDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return complexDivide(f, v, w, true)
+}
+
 func complexRepr(f *Frame, o *Object) (*Object, *BaseException) {
 	c := toComplexUnsafe(o).Value()
 	rs, is := "", ""
@@ -111,14 +213,25 @@ func complexRSub(f *Frame, v, w *Object) (*Object, *BaseException) {
 	})
 }
 
+func complexRTrueDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return complexDivide(f, v, w, true)
+}
+
 func complexSub(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return complexArithmeticOp(f, "__rsub__", v, w, func(lhs, rhs complex128) complex128 {
 		return lhs - rhs
 	})
 }
 
+func complexTrueDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return complexDivide(f, v, w, false)
+}
+
 func initComplexType(dict map[string]*Object) {
+	dict["__coerce__"] = newBuiltinFunction("__coerce__", complexCoerceMethod).ToObject()
+	ComplexType.slots.Abs = &unaryOpSlot{complexAbs}
 	ComplexType.slots.Add = &binaryOpSlot{complexAdd}
+	ComplexType.slots.Div = &binaryOpSlot{complexDiv}
 	ComplexType.slots.Eq = &binaryOpSlot{complexEq}
 	ComplexType.slots.GE = &binaryOpSlot{complexCompareNotSupported}
 	ComplexType.slots.GT = &binaryOpSlot{complexCompareNotSupported}
@@ -126,10 +239,14 @@ func initComplexType(dict map[string]*Object) {
 	ComplexType.slots.LE = &binaryOpSlot{complexCompareNotSupported}
 	ComplexType.slots.LT = &binaryOpSlot{complexCompareNotSupported}
 	ComplexType.slots.NE = &binaryOpSlot{complexNE}
+	ComplexType.slots.New = &newSlot{complexNew}
 	ComplexType.slots.RAdd = &binaryOpSlot{complexRAdd}
+	ComplexType.slots.RDiv = &binaryOpSlot{complexRDiv}
 	ComplexType.slots.Repr = &unaryOpSlot{complexRepr}
 	ComplexType.slots.RSub = &binaryOpSlot{complexRSub}
+	ComplexType.slots.RTrueDiv = &binaryOpSlot{complexRTrueDiv}
 	ComplexType.slots.Sub = &binaryOpSlot{complexSub}
+	ComplexType.slots.TrueDiv = &binaryOpSlot{complexTrueDiv}
 }
 
 func complexCompare(v *Complex, w *Object) (bool, bool) {
@@ -169,6 +286,101 @@ func complexCoerce(o *Object) (complex128, bool) {
 	return complex(floatO, 0.0), true
 }
 
+// complexConvert converts the non-string argument o of complex() to a
+// complex128, falling back to __float__ and then __index__ for non-numeric
+// types.
+func complexConvert(f *Frame, o *Object) (complex128, *BaseException) {
+	c, ok := complexCoerce(o)
+	if ok {
+		return c, nil
+	}
+	if math.IsInf(real(c), 0) {
+		return 0, f.RaiseType(OverflowErrorType, "long int too large to convert to float")
+	}
+	floatSlot := o.typ.slots.Float
+	if floatSlot == nil {
+		i, raised := Index(f, o)
+		if raised != nil {
+			return 0, raised
+		}
+		if i == nil {
+			return 0, f.RaiseType(TypeErrorType, "complex() argument must be a string or a number")
+		}
+		if c, ok = complexCoerce(i); !ok {
+			return 0, f.RaiseType(OverflowErrorType, "long int too large to convert to float")
+		}
+		return c, nil
+	}
+	result, raised := floatSlot.Fn(f, o)
+	if raised != nil {
+		return 0, raised
+	}
+	if !result.isInstance(FloatType) {
+		return 0, f.RaiseType(TypeErrorType, fmt.Sprintf("__float__ returned non-float (type %s)", result.typ.Name()))
+	}
+	return complex(toFloatUnsafe(result).Value(), 0), nil
+}
+
+// complexSpecialMethod returns the result of calling o's __complex__ method,
+// or o itself if it has no such method.
+func complexSpecialMethod(f *Frame, o *Object) (*Object, *BaseException) {
+	method, raised := o.typ.mroLookup(f, NewStr("__complex__"))
+	if raised != nil {
+		return nil, raised
+	}
+	if method == nil {
+		return o, nil
+	}
+	return method.Call(f, Args{o}, nil)
+}
+
+// complexDivide returns v / w, or w / v if reversed is true. Complex division
+// is always true division so it backs both __div__ and __truediv__.
+func complexDivide(f *Frame, v, w *Object, reversed bool) (*Object, *BaseException) {
+	rhs, ok := complexCoerce(w)
+	if !ok {
+		if math.IsInf(real(rhs), 0) {
+			return nil, f.RaiseType(OverflowErrorType, "long int too large to convert to float")
+		}
+		return NotImplemented, nil
+	}
+	lhs := toComplexUnsafe(v).Value()
+	if reversed {
+		lhs, rhs = rhs, lhs
+	}
+	if rhs == 0 {
+		return nil, f.RaiseType(ZeroDivisionErrorType, "complex division by zero")
+	}
+	return NewComplex(lhs / rhs).ToObject(), nil
+}
+
+// complexParse parses a Python complex literal such as "1+2j" or "(-j)".
+func complexParse(s string) (complex128, bool) {
+	s = strings.TrimSpace(s)
+	if len(s) > 1 && s[0] == '(' && s[len(s)-1] == ')' {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	// Reject the forms Go accepts but Python doesn't, e.g. "1+2i", "0x10"
+	// and "1_000".
+	if strings.ContainsAny(strings.NewReplacer("infinity", "", "inf", "").Replace(strings.ToLower(s)), " \t\n\r\v\fix_") {
+		return 0, false
+	}
+	if n := len(s); n > 0 && (s[n-1] == 'j' || s[n-1] == 'J') {
+		// Go uses an i suffix for the imaginary part and requires an
+		// explicit coefficient, e.g. "1-j" must become "1-1i".
+		s = s[:n-1]
+		if n == 1 || s[n-2] == '+' || s[n-2] == '-' {
+			s += "1"
+		}
+		s += "i"
+	}
+	c, err := strconv.ParseComplex(s, 128)
+	if err != nil && err.(*strconv.NumError).Err != strconv.ErrRange {
+		return 0, false
+	}
+	return c, true
+}
+
 func complexArithmeticOp(f *Frame, method string, v, w *Object, fun func(v, w complex128) complex128) (*Object, *BaseException) {
 	if w.isInstance(ComplexType) {
 		return NewComplex(fun(toComplexUnsafe(v).Value(), toComplexUnsafe(w).Value())).ToObject(), nil
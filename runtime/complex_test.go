@@ -21,6 +21,22 @@ import (
 	"testing"
 )
 
+func TestComplexAbs(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(complex(0, 0)), want: NewFloat(0).ToObject()},
+		{args: wrapArgs(complex(3, -4)), want: NewFloat(5).ToObject()},
+		{args: wrapArgs(complex(-1.5, 0)), want: NewFloat(1.5).ToObject()},
+		{args: wrapArgs(complex(0, math.Inf(-1))), want: NewFloat(math.Inf(1)).ToObject()},
+		{args: wrapArgs(complex(math.Inf(1), math.NaN())), want: NewFloat(math.Inf(1)).ToObject()},
+		{args: wrapArgs(complex(math.MaxFloat64, math.MaxFloat64)), wantExc: mustCreateException(OverflowErrorType, "absolute value too large")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(wrapFuncForTest(complexAbs), &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestComplexEq(t *testing.T) {
 	cases := []invokeTestCase{
 		{args: wrapArgs(complex(0, 0), 0), want: True.ToObject()},
@@ -65,6 +81,15 @@ func TestComplexBinaryOps(t *testing.T) {
 		{Add, NewInt(3).ToObject(), NewComplex(3i).ToObject(), NewComplex(3 + 3i).ToObject(), nil},
 		{Add, NewLong(big.NewInt(9999999)).ToObject(), NewComplex(3i).ToObject(), NewComplex(9999999 + 3i).ToObject(), nil},
 		{Add, NewFloat(3.5).ToObject(), NewComplex(3i).ToObject(), NewComplex(3.5 + 3i).ToObject(), nil},
+		{Div, NewComplex(1 + 2i).ToObject(), NewInt(2).ToObject(), NewComplex(0.5 + 1i).ToObject(), nil},
+		{Div, NewInt(2).ToObject(), NewComplex(1 + 1i).ToObject(), NewComplex(1 - 1i).ToObject(), nil},
+		{Div, NewComplex(3 + 4i).ToObject(), NewComplex(1 + 2i).ToObject(), NewComplex(2.2 - 0.4i).ToObject(), nil},
+		{Div, NewComplex(1 + 2i).ToObject(), NewFloat(0).ToObject(), nil, mustCreateException(ZeroDivisionErrorType, "complex division by zero")},
+		{Div, NewFloat(1).ToObject(), NewComplex(0).ToObject(), nil, mustCreateException(ZeroDivisionErrorType, "complex division by zero")},
+		{Div, NewComplex(1 + 2i).ToObject(), None, nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for /: 'complex' and 'NoneType'")},
+		{Div, NewComplex(1 + 2i).ToObject(), NewLong(big.NewInt(1).Lsh(big.NewInt(1), 1024)).ToObject(), nil, mustCreateException(OverflowErrorType, "long int too large to convert to float")},
+		{FloorDiv, NewComplex(7 + 2i).ToObject(), NewInt(2).ToObject(), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for //: 'complex' and 'int'")},
+		{FloorDiv, NewFloat(7).ToObject(), NewComplex(2i).ToObject(), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for //: 'float' and 'complex'")},
 		{Sub, NewComplex(1 + 3i).ToObject(), NewComplex(1 + 3i).ToObject(), NewComplex(0i).ToObject(), nil},
 		{Sub, NewComplex(1 + 3i).ToObject(), NewComplex(3i).ToObject(), NewComplex(1).ToObject(), nil},
 		{Sub, NewComplex(1 + 3i).ToObject(), NewFloat(1).ToObject(), NewComplex(3i).ToObject(), nil},
@@ -79,6 +104,9 @@ func TestComplexBinaryOps(t *testing.T) {
 		{Sub, NewFloat(math.NaN()).ToObject(), NewComplex(3i).ToObject(), NewComplex(complex(math.NaN(), -3)).ToObject(), nil},
 		{Sub, NewComplex(cmplx.NaN()).ToObject(), NewComplex(3i).ToObject(), NewComplex(cmplx.NaN()).ToObject(), nil},
 		{Sub, NewFloat(math.Inf(-1)).ToObject(), NewComplex(complex(math.Inf(-1), 3)).ToObject(), NewComplex(complex(math.NaN(), -3)).ToObject(), nil},
+		{TrueDiv, NewComplex(1 + 2i).ToObject(), NewInt(2).ToObject(), NewComplex(0.5 + 1i).ToObject(), nil},
+		{TrueDiv, NewFloat(5).ToObject(), NewComplex(1 + 2i).ToObject(), NewComplex(1 - 2i).ToObject(), nil},
+		{TrueDiv, NewComplex(1 + 2i).ToObject(), NewInt(0).ToObject(), nil, mustCreateException(ZeroDivisionErrorType, "complex division by zero")},
 	}
 
 	for _, cas := range cases {
@@ -109,6 +137,67 @@ func TestComplexCompareNotSupported(t *testing.T) {
 	}
 }
 
+func TestComplexNew(t *testing.T) {
+	floatObj := newObject(newTestClass("Foo", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__float__": newBuiltinFunction("__float__", func(_ *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return NewFloat(2.5).ToObject(), nil
+		}).ToObject(),
+	})))
+	indexType := newTestClass("Index", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__index__": newBuiltinFunction("__index__", func(_ *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return NewInt(3).ToObject(), nil
+		}).ToObject(),
+	}))
+	complexType := newTestClass("Complex", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__complex__": newBuiltinFunction("__complex__", func(_ *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return NewComplex(complex(1, 2)).ToObject(), nil
+		}).ToObject(),
+	}))
+	bothType := newTestClass("Both", []*Type{indexType, complexType}, NewDict())
+	badComplexObj := newObject(newTestClass("BadComplex", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__complex__": newBuiltinFunction("__complex__", func(_ *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return NewStr("foo").ToObject(), nil
+		}).ToObject(),
+	})))
+	subType := newTestClass("SubComplex", []*Type{ComplexType}, NewDict())
+	cases := []invokeTestCase{
+		{args: wrapArgs(ComplexType), want: NewComplex(0).ToObject()},
+		{args: wrapArgs(ComplexType, 1), want: NewComplex(1).ToObject()},
+		{args: wrapArgs(ComplexType, big.NewInt(-3)), want: NewComplex(-3).ToObject()},
+		{args: wrapArgs(ComplexType, 1.5, 2), want: NewComplex(complex(1.5, 2)).ToObject()},
+		{args: wrapArgs(ComplexType, complex(1, 2), complex(3, 4)), want: NewComplex(complex(-3, 5)).ToObject()},
+		{args: wrapArgs(ComplexType, floatObj), want: NewComplex(2.5).ToObject()},
+		{args: wrapArgs(ComplexType, newObject(indexType)), want: NewComplex(3).ToObject()},
+		{args: wrapArgs(ComplexType, 1, newObject(indexType)), want: NewComplex(complex(1, 3)).ToObject()},
+		{args: wrapArgs(ComplexType, newObject(complexType)), want: NewComplex(complex(1, 2)).ToObject()},
+		{args: wrapArgs(ComplexType, newObject(bothType)), want: NewComplex(complex(1, 2)).ToObject()},
+		{args: wrapArgs(ComplexType, newObject(complexType), 1), want: NewComplex(complex(1, 3)).ToObject()},
+		{args: wrapArgs(ComplexType, badComplexObj), wantExc: mustCreateException(TypeErrorType, "complex() argument must be a string or a number")},
+		{args: wrapArgs(ComplexType), kwargs: wrapKWArgs("imag", 3), want: NewComplex(complex(0, 3)).ToObject()},
+		{args: wrapArgs(ComplexType, "1+2j"), want: NewComplex(complex(1, 2)).ToObject()},
+		{args: wrapArgs(ComplexType, " (1e3-4.5J) "), want: NewComplex(complex(1000, -4.5)).ToObject()},
+		{args: wrapArgs(ComplexType, "j"), want: NewComplex(complex(0, 1)).ToObject()},
+		{args: wrapArgs(ComplexType, "-j"), want: NewComplex(complex(0, -1)).ToObject()},
+		{args: wrapArgs(ComplexType, "2"), want: NewComplex(2).ToObject()},
+		{args: wrapArgs(ComplexType, "inf"), want: NewComplex(complex(math.Inf(1), 0)).ToObject()},
+		{args: wrapArgs(subType, 1, 2), want: NewComplex(complex(1, 2)).ToObject()},
+		{args: wrapArgs(ComplexType, "1+2i"), wantExc: mustCreateException(ValueErrorType, "complex() arg is a malformed string")},
+		{args: wrapArgs(ComplexType, "1 + 2j"), wantExc: mustCreateException(ValueErrorType, "complex() arg is a malformed string")},
+		{args: wrapArgs(ComplexType, "1+"), wantExc: mustCreateException(ValueErrorType, "complex() arg is a malformed string")},
+		{args: wrapArgs(ComplexType, "1", 2), wantExc: mustCreateException(TypeErrorType, "complex() can't take second arg if first is a string")},
+		{args: wrapArgs(ComplexType, 1, "2"), wantExc: mustCreateException(TypeErrorType, "complex() second arg can't be a string")},
+		{args: wrapArgs(ComplexType, NewList()), wantExc: mustCreateException(TypeErrorType, "complex() argument must be a string or a number")},
+		{args: wrapArgs(ComplexType, new(big.Int).Lsh(big.NewInt(1), 2000)), wantExc: mustCreateException(OverflowErrorType, "long int too large to convert to float")},
+		{args: wrapArgs(ComplexType, 1, 2, 3), wantExc: mustCreateException(TypeErrorType, "complex() takes at most 2 arguments (3 given)")},
+		{args: wrapArgs(ComplexType), kwargs: wrapKWArgs("foo", 1), wantExc: mustCreateException(TypeErrorType, "'foo' is an invalid keyword argument for this function")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(ComplexType, "__new__", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestComplexNE(t *testing.T) {
 	cases := []invokeTestCase{
 		{args: wrapArgs(complex(0, 0), 0), want: False.ToObject()},
@@ -151,6 +240,8 @@ func TestComplexHash(t *testing.T) {
 		{args: wrapArgs(complex(0.0, 0.0)), want: NewInt(0).ToObject()},
 		{args: wrapArgs(complex(0.0, 1.0)), want: NewInt(1000003).ToObject()},
 		{args: wrapArgs(complex(1.0, 0.0)), want: NewInt(1).ToObject()},
+		{args: wrapArgs(complex(-1.0, 0.0)), want: NewInt(-2).ToObject()},
+		{args: wrapArgs(complex(1.5, 2.0)), want: NewInt(1612645510).ToObject()},
 		{args: wrapArgs(complex(3.1, -4.2)), want: NewInt(-1556830019620134).ToObject()},
 		{args: wrapArgs(complex(3.1, 4.2)), want: NewInt(1557030815934348).ToObject()},
 	}
@@ -177,6 +177,33 @@ func Eq(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return GetBool(compareDefault(f, v, w) == 0).ToObject(), nil
 }
 
+// Exec implements the exec statement, running code in the given namespaces.
+// Nil or None globals means the globals of the current frame. Only code
+// objects can be run since there's no compiler available at runtime.
+func Exec(f *Frame, code, globals, locals *Object) *BaseException {
+	if globals == nil || globals == None {
+		globals = f.Globals().ToObject()
+	} else if !globals.isInstance(DictType) {
+		return f.RaiseType(TypeErrorType, "exec: arg 2 must be a dictionary or None")
+	}
+	if locals != nil && locals != None && locals.typ.slots.GetItem == nil {
+		return f.RaiseType(TypeErrorType, "exec: arg 3 must be a mapping or None")
+	}
+	switch {
+	case code.isInstance(CodeType):
+		// Compiled code binds names in its globals so a distinct locals
+		// mapping can't be honored.
+		if locals != nil && locals != None && locals != globals {
+			return f.RaiseType(NotImplementedErrorType, "exec of a code object with a separate locals mapping is not supported")
+		}
+		_, raised := toCodeUnsafe(code).Eval(f, toDictUnsafe(globals), nil, nil)
+		return raised
+	case code.isInstance(StrType), code.isInstance(UnicodeType), code.isInstance(FileType):
+		return raiseDynamicCompile(f, "exec of source code")
+	}
+	return f.RaiseType(TypeErrorType, "exec: arg 1 must be a string, file, or code object")
+}
+
 // FloorDiv returns the equality of v and w according to the __floordiv/rfloordiv__ operator.
 func FloorDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return binaryOp(f, v, w, v.typ.slots.FloorDiv, v.typ.slots.RFloorDiv, w.typ.slots.RFloorDiv, "//")
@@ -219,13 +246,18 @@ func GetItem(f *Frame, o, key *Object) (*Object, *BaseException) {
 // GetAttr returns the named attribute of o. Equivalent to the Python expression
 // getattr(o, name, def).
 func GetAttr(f *Frame, o *Object, name *Str, def *Object) (*Object, *BaseException) {
-	// TODO: Fall back to __getattr__.
 	getAttribute := o.typ.slots.GetAttribute
 	if getAttribute == nil {
 		msg := fmt.Sprintf("'%s' has no attribute '%s'", o.typ.Name(), name.Value())
 		return nil, f.RaiseType(AttributeErrorType, msg)
 	}
 	result, raised := getAttribute.Fn(f, o, name)
+	if getAttr := o.typ.slots.GetAttr; raised != nil && getAttr != nil && raised.isInstance(AttributeErrorType) {
+		// Like CPython, __getattr__ is only consulted when the normal
+		// lookup fails.
+		f.RestoreExc(nil, nil)
+		result, raised = getAttr.Fn(f, o, name)
+	}
 	if raised != nil && raised.isInstance(AttributeErrorType) && def != nil {
 		f.RestoreExc(nil, nil)
 		result, raised = def, nil
@@ -350,36 +382,82 @@ func IRShift(f *Frame, v, w *Object) (*Object, *BaseException) {
 // IsInstance returns true if the type o is an instance of classinfo, or an
 // instance of an element in classinfo (if classinfo is a tuple). It returns
 // false otherwise. The argument classinfo must be a type or a tuple whose
-// elements are types like the isinstance() Python builtin.
+// elements are types or nested tuples like the isinstance() Python builtin.
+// The check may be overridden by an __instancecheck__ method on classinfo's
+// metaclass.
 func IsInstance(f *Frame, o *Object, classinfo *Object) (bool, *BaseException) {
-	return IsSubclass(f, o.typ.ToObject(), classinfo)
+	if o.typ.ToObject() == classinfo {
+		return true, nil
+	}
+	if classinfo.isInstance(TupleType) {
+		for _, elem := range toTupleUnsafe(classinfo).elems {
+			ret, raised := IsInstance(f, o, elem)
+			if raised != nil || ret {
+				return ret, raised
+			}
+		}
+		return false, nil
+	}
+	if ret, ok, raised := callClassInfoHook(f, classinfo, "__instancecheck__", o); ok {
+		return ret, raised
+	}
+	if classinfo.isInstance(TypeType) {
+		t := toTypeUnsafe(classinfo)
+		if o.typ.isSubclass(t) {
+			return true, nil
+		}
+		// Like CPython, an object may claim to be an instance of another
+		// type via __class__, e.g. a proxy.
+		cls := getClassAttr(f, o)
+		return cls != nil && cls != o.typ.ToObject() && cls.isInstance(TypeType) && toTypeUnsafe(cls).isSubclass(t), nil
+	}
+	if ok, raised := isClassLike(f, classinfo); raised != nil {
+		return false, raised
+	} else if !ok {
+		return false, f.RaiseType(TypeErrorType, "classinfo must be a type or tuple of types")
+	}
+	cls := getClassAttr(f, o)
+	if cls == nil {
+		return false, nil
+	}
+	return isAbstractSubclass(f, cls, classinfo)
 }
 
 // IsSubclass returns true if the type o is a subtype of classinfo or a subtype
 // of an element in classinfo (if classinfo is a tuple). It returns false
 // otherwise. The argument o must be a type and classinfo must be a type or a
-// tuple whose elements are types like the issubclass() Python builtin.
+// tuple whose elements are types or nested tuples like the issubclass() Python
+// builtin. The check may be overridden by a __subclasscheck__ method on
+// classinfo's metaclass.
 func IsSubclass(f *Frame, o *Object, classinfo *Object) (bool, *BaseException) {
-	if !o.isInstance(TypeType) {
-		return false, f.RaiseType(TypeErrorType, "issubclass() arg 1 must be a class")
+	if classinfo.isInstance(TupleType) {
+		for _, elem := range toTupleUnsafe(classinfo).elems {
+			ret, raised := IsSubclass(f, o, elem)
+			if raised != nil || ret {
+				return ret, raised
+			}
+		}
+		return false, nil
 	}
-	t := toTypeUnsafe(o)
-	errorMsg := "classinfo must be a type or tuple of types"
-	if classinfo.isInstance(TypeType) {
-		return t.isSubclass(toTypeUnsafe(classinfo)), nil
+	if ret, ok, raised := callClassInfoHook(f, classinfo, "__subclasscheck__", o); ok {
+		return ret, raised
 	}
-	if !classinfo.isInstance(TupleType) {
-		return false, f.RaiseType(TypeErrorType, errorMsg)
+	if o.isInstance(TypeType) && classinfo.isInstance(TypeType) {
+		return toTypeUnsafe(o).isSubclass(toTypeUnsafe(classinfo)), nil
 	}
-	for _, elem := range toTupleUnsafe(classinfo).elems {
-		if !elem.isInstance(TypeType) {
-			return false, f.RaiseType(TypeErrorType, errorMsg)
-		}
-		if t.isSubclass(toTypeUnsafe(elem)) {
-			return true, nil
-		}
+	// Otherwise fall back to walking __bases__ like CPython does, which
+	// lets arbitrary objects participate as classes.
+	if ok, raised := isClassLike(f, o); raised != nil {
+		return false, raised
+	} else if !ok {
+		return false, f.RaiseType(TypeErrorType, "issubclass() arg 1 must be a class")
 	}
-	return false, nil
+	if ok, raised := isClassLike(f, classinfo); raised != nil {
+		return false, raised
+	} else if !ok {
+		return false, f.RaiseType(TypeErrorType, "classinfo must be a type or tuple of types")
+	}
+	return isAbstractSubclass(f, o, classinfo)
 }
 
 // IsTrue returns the truthiness of o according to the __nonzero__ operator.
@@ -415,10 +493,13 @@ func IsTrue(f *Frame, o *Object) (bool, *BaseException) {
 // ISub returns the result of v.__isub__ if defined, otherwise falls back to
 // sub.
 func ISub(f *Frame, v, w *Object) (*Object, *BaseException) {
-	if isub := v.typ.slots.ISub; isub != nil {
-		return isub.Fn(f, v, w)
-	}
-	return Sub(f, v, w)
+	return inplaceOp(f, v, w, v.typ.slots.ISub, Sub)
+}
+
+// ITrueDiv returns the result of v.__itruediv__ if defined, otherwise falls
+// back to TrueDiv.
+func ITrueDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return inplaceOp(f, v, w, v.typ.slots.ITrueDiv, TrueDiv)
 }
 
 // Iter implements the Python iter() builtin. It returns an iterator for o if
@@ -534,7 +615,7 @@ func Index(f *Frame, o *Object) (*Object, *BaseException) {
 }
 
 // IndexInt returns the value of o converted to a Go int according to o's
-// __index__ slot.
+// __index__ slot. Longs outside the int range are clamped to it.
 // It raises a TypeError if o doesn't have an __index__ method.
 func IndexInt(f *Frame, o *Object) (i int, raised *BaseException) {
 	if index := o.typ.slots.Index; index != nil {
@@ -549,9 +630,13 @@ func IndexInt(f *Frame, o *Object) (i int, raised *BaseException) {
 	}
 	if o.isInstance(LongType) {
 		l := toLongUnsafe(o).Value()
-		// Anything bigger than maxIntBig will treat as maxIntBig.
+		// Values outside the int range are clamped to MinInt or MaxInt
+		// like CPython's _PyEval_SliceIndex.
 		if !numInIntRange(l) {
-			l = maxIntBig
+			if l.Sign() < 0 {
+				return MinInt, nil
+			}
+			return MaxInt, nil
 		}
 		return int(l.Int64()), nil
 	}
@@ -564,6 +649,10 @@ func IndexInt(f *Frame, o *Object) (i int, raised *BaseException) {
 // then it passes those to *Object.Call.
 func Invoke(f *Frame, callable *Object, args Args, varargs *Object, keywords KWArgs, kwargs *Object) (*Object, *BaseException) {
 	if varargs != nil {
+		if varargs.typ.slots.Iter == nil && varargs.typ.slots.GetItem == nil {
+			format := "%s argument after * must be an iterable, not %s"
+			return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, callableDesc(callable), varargs.typ.Name()))
+		}
 		raised := seqApply(f, varargs, func(elems []*Object, _ bool) *BaseException {
 			numArgs := len(args)
 			packed := make([]*Object, numArgs+len(elems))
@@ -578,8 +667,13 @@ func Invoke(f *Frame, callable *Object, args Args, varargs *Object, keywords KWA
 	}
 	if kwargs != nil {
 		if !kwargs.isInstance(DictType) {
-			format := "argument after ** must be a dict, not %s"
-			return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, kwargs.typ.Name()))
+			// Other mappings are copied into a dict using their keys()
+			// and __getitem__ methods.
+			mapping, raised := invokeMappingToDict(f, callable, kwargs)
+			if raised != nil {
+				return nil, raised
+			}
+			kwargs = mapping.ToObject()
 		}
 		kwargsDict := toDictUnsafe(kwargs)
 		numKeywords := len(keywords)
@@ -593,14 +687,14 @@ func Invoke(f *Frame, callable *Object, args Args, varargs *Object, keywords KWA
 		copy(packed, keywords)
 		raised = seqForEach(f, kwargs, func(o *Object) *BaseException {
 			if !o.isInstance(StrType) {
-				return f.RaiseType(TypeErrorType, "keywords must be strings")
+				return f.RaiseType(TypeErrorType, fmt.Sprintf("%s keywords must be strings", callableDesc(callable)))
 			}
 			s := toStrUnsafe(o).Value()
 			// Search for dupes linearly assuming small number of keywords.
 			for _, kw := range keywords {
 				if kw.Name == s {
-					format := "got multiple values for keyword argument '%s'"
-					return f.RaiseType(TypeErrorType, fmt.Sprintf(format, s))
+					format := "%s got multiple values for keyword argument '%s'"
+					return f.RaiseType(TypeErrorType, fmt.Sprintf(format, callableDesc(callable), s))
 				}
 			}
 			item, raised := kwargsDict.GetItem(f, o)
@@ -633,6 +727,28 @@ func NE(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return GetBool(compareDefault(f, v, w) != 0).ToObject(), nil
 }
 
+// NewLocals returns a function for use with Frame.SetLocals that maps each of
+// names to the current value of the corresponding local variable as given by
+// values. Like CPython, each call updates and returns the same dict, dropping
+// locals that are unbound.
+func NewLocals(names []string, values func() []*Object) func(*Frame) (*Dict, *BaseException) {
+	d := NewDict()
+	return func(f *Frame) (*Dict, *BaseException) {
+		for i, value := range values() {
+			var raised *BaseException
+			if value == UnboundLocal {
+				_, raised = d.DelItemString(f, names[i])
+			} else {
+				raised = d.SetItemString(f, names[i], value)
+			}
+			if raised != nil {
+				return nil, raised
+			}
+		}
+		return d, nil
+	}
+}
+
 // Next implements the Python next() builtin. It calls next on the provided
 // iterator. It raises TypeError if iter is not an iterator object.
 // Note that the next(it, default) form is not yet supported.
@@ -836,7 +952,12 @@ func Tie(f *Frame, t TieTarget, o *Object) *BaseException {
 				return raised
 			}
 		} else if raised.isInstance(StopIterationType) {
-			return f.RaiseType(ValueErrorType, fmt.Sprintf("need more than %d values to unpack", i))
+			plural := "s"
+			if i == 1 {
+				plural = ""
+			}
+			format := "need more than %d value%s to unpack"
+			return f.RaiseType(ValueErrorType, fmt.Sprintf(format, i, plural))
 		} else {
 			return raised
 		}
@@ -907,6 +1028,13 @@ func ToStr(f *Frame, o *Object) (*Str, *BaseException) {
 	return toStrUnsafe(result), nil
 }
 
+// TrueDiv returns the result of dividing v by w according to the
+// __truediv/rtruediv__ operator. It implements / when the division future
+// feature is enabled.
+func TrueDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
+	return binaryOp(f, v, w, v.typ.slots.TrueDiv, v.typ.slots.RTrueDiv, w.typ.slots.RTrueDiv, "/")
+}
+
 // Neg returns the result of o.__neg__ and is equivalent to the Python
 // expression "-o".
 func Neg(f *Frame, o *Object) (*Object, *BaseException) {
@@ -928,6 +1056,12 @@ const (
 	errUnsupportedOperand = "unsupported operand type(s) for %s: '%s' and '%s'"
 )
 
+// maxAllocSize is the size in bytes of the largest buffer allocated on behalf
+// of a user supplied size, e.g. bytearray(n). The Go runtime aborts the
+// process when an allocation can't be satisfied instead of failing like
+// malloc, so such sizes are checked against this conservative bound up front.
+const maxAllocSize = 1 << 32
+
 // binaryOp picks an appropriate operator method (op or rop) from v or w and
 // returns its result. It raises TypeError if no appropriate method is found.
 // It is similar to CPython's binary_op1 function from abstract.c.
@@ -968,7 +1102,10 @@ func binaryOp(f *Frame, v, w *Object, op, vrop, wrop *binaryOpSlot, opName strin
 
 func inplaceOp(f *Frame, v, w *Object, slot *binaryOpSlot, fallback binaryOpFunc) (*Object, *BaseException) {
 	if slot != nil {
-		return slot.Fn(f, v, w)
+		r, raised := slot.Fn(f, v, w)
+		if raised != nil || r != NotImplemented {
+			return r, raised
+		}
 	}
 	return fallback(f, v, w)
 }
@@ -1211,6 +1348,49 @@ func tryRichTo3wayCompare(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return NotImplemented, nil
 }
 
+// callableDesc returns a description of callable suitable for prefixing
+// errors about the arguments it was invoked with, e.g. "foo()" for functions
+// and methods and "int object" for other callables.
+func callableDesc(callable *Object) string {
+	switch {
+	case callable.isInstance(FunctionType):
+		return toFunctionUnsafe(callable).Name() + "()"
+	case callable.isInstance(MethodType):
+		return toMethodUnsafe(callable).function.Name() + "()"
+	}
+	return callable.typ.Name() + " object"
+}
+
+// invokeMappingToDict copies the items of the **kwargs mapping o into a new
+// dict. o must provide keys() and __getitem__.
+func invokeMappingToDict(f *Frame, callable, o *Object) (*Dict, *BaseException) {
+	keys, raised := GetAttr(f, o, NewStr("keys"), nil)
+	if raised != nil {
+		if !raised.isInstance(AttributeErrorType) {
+			return nil, raised
+		}
+		f.RestoreExc(nil, nil)
+		format := "%s argument after ** must be a mapping, not %s"
+		return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, callableDesc(callable), o.typ.Name()))
+	}
+	keyList, raised := keys.Call(f, nil, nil)
+	if raised != nil {
+		return nil, raised
+	}
+	d := NewDict()
+	raised = seqForEach(f, keyList, func(key *Object) *BaseException {
+		value, raised := GetItem(f, o, key)
+		if raised != nil {
+			return raised
+		}
+		return d.SetItem(f, key, value)
+	})
+	if raised != nil {
+		return nil, raised
+	}
+	return d, nil
+}
+
 func checkFunctionArgs(f *Frame, function string, args Args, types ...*Type) *BaseException {
 	if len(args) != len(types) {
 		msg := fmt.Sprintf("'%s' requires %d arguments", function, len(types))
@@ -1257,6 +1437,83 @@ func hashNotImplemented(f *Frame, o *Object) (*Object, *BaseException) {
 	return nil, f.RaiseType(TypeErrorType, fmt.Sprintf("unhashable type: '%s'", o.typ.Name()))
 }
 
+// callClassInfoHook calls the method called name on classinfo's type with o
+// as its argument, e.g. __instancecheck__. ok is false if the type does not
+// define the method, in which case the default check should be used.
+func callClassInfoHook(f *Frame, classinfo *Object, name string, o *Object) (ret, ok bool, raised *BaseException) {
+	if classinfo.typ == TypeType {
+		// Fast path for the common case since type defines neither hook.
+		return false, false, nil
+	}
+	hook, raised := classinfo.typ.mroLookup(f, NewStr(name))
+	if raised != nil {
+		return false, true, raised
+	}
+	if hook == nil {
+		return false, false, nil
+	}
+	result, raised := hook.Call(f, Args{classinfo, o}, nil)
+	if raised != nil {
+		return false, true, raised
+	}
+	ret, raised = IsTrue(f, result)
+	return ret, true, raised
+}
+
+// getAbstractBases returns o's __bases__ attribute or nil if o has no such
+// attribute or it is not a tuple.
+func getAbstractBases(f *Frame, o *Object) (*Tuple, *BaseException) {
+	bases, raised := GetAttr(f, o, NewStr("__bases__"), None)
+	if raised != nil || !bases.isInstance(TupleType) {
+		return nil, raised
+	}
+	return toTupleUnsafe(bases), nil
+}
+
+// getClassAttr returns o's __class__ attribute or nil if it could not be
+// retrieved. Errors are swallowed as they are by CPython's isinstance.
+func getClassAttr(f *Frame, o *Object) *Object {
+	exc, tb := f.ExcInfo()
+	cls, raised := GetAttr(f, o, NewStr("__class__"), nil)
+	if raised != nil {
+		f.RestoreExc(exc, tb)
+		return nil
+	}
+	return cls
+}
+
+// isAbstractSubclass returns true if cls is derived or is reachable from
+// derived via __bases__.
+func isAbstractSubclass(f *Frame, derived, cls *Object) (bool, *BaseException) {
+	for derived != cls {
+		bases, raised := getAbstractBases(f, derived)
+		if raised != nil || bases == nil || len(bases.elems) == 0 {
+			return false, raised
+		}
+		if len(bases.elems) > 1 {
+			for _, base := range bases.elems {
+				if ret, raised := isAbstractSubclass(f, base, cls); raised != nil || ret {
+					return ret, raised
+				}
+			}
+			return false, nil
+		}
+		derived = bases.elems[0]
+	}
+	return true, nil
+}
+
+// isClassLike returns true if o is a type or has a tuple valued __bases__
+// attribute, the requirement CPython places on the arguments of isinstance and
+// issubclass.
+func isClassLike(f *Frame, o *Object) (bool, *BaseException) {
+	if o.isInstance(TypeType) {
+		return true, nil
+	}
+	bases, raised := getAbstractBases(f, o)
+	return bases != nil, raised
+}
+
 // pyPrint encapsulates the logic of the Python print function.
 func pyPrint(f *Frame, args Args, sep, end string, file *File) *BaseException {
 	for i, arg := range args {
@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"regexp"
 	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -105,6 +106,11 @@ func TestBinaryOps(t *testing.T) {
 			return args[1], nil
 		}).ToObject(),
 	}))
+	notImplementedInplaceType := newTestClass("NotImplementedInplace", []*Type{fooType}, newStringDict(map[string]*Object{
+		"__iadd__": newBuiltinFunction("__iadd__", func(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
+			return NotImplemented, nil
+		}).ToObject(),
+	}))
 	cases := []struct {
 		fun     func(f *Frame, v, w *Object) (*Object, *BaseException)
 		v, w    *Object
@@ -122,6 +128,8 @@ func TestBinaryOps(t *testing.T) {
 		{IAdd, NewStr("foo").ToObject(), NewStr("bar").ToObject(), NewStr("foobar").ToObject(), nil},
 		{IAdd, newObject(fooType), newObject(ObjectType), NewStr("foo add").ToObject(), nil},
 		{IAdd, newObject(inplaceType), NewStr("foo").ToObject(), NewStr("foo").ToObject(), nil},
+		{IAdd, newObject(notImplementedInplaceType), NewInt(1).ToObject(), NewStr("foo add").ToObject(), nil},
+		{IAdd, NewInt(1).ToObject(), newObject(notImplementedInplaceType), NewStr("foo radd").ToObject(), nil},
 		{IAnd, NewInt(9).ToObject(), NewInt(12).ToObject(), NewInt(8).ToObject(), nil},
 		{IAnd, newObject(inplaceType), NewStr("foo").ToObject(), NewStr("foo").ToObject(), nil},
 		{IAnd, newObject(ObjectType), newObject(fooType), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for &: 'object' and 'Foo'")},
@@ -303,6 +311,44 @@ func TestContains(t *testing.T) {
 
 // DelAttr is tested in TestObjectDelAttr.
 
+func TestDelAttr(t *testing.T) {
+	delAttr := wrapFuncForTest(func(f *Frame, o *Object, name *Str) (*Object, *BaseException) {
+		if raised := DelAttr(f, o, name); raised != nil {
+			return nil, raised
+		}
+		return o.Dict().ToObject(), nil
+	})
+	fooType := newTestClass("Foo", []*Type{ObjectType}, NewDict())
+	guardedType := newTestClass("Guarded", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__delattr__": newBuiltinFunction("__delattr__", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+			name := toStrUnsafe(args[1])
+			if name.Value() == "guarded" {
+				return nil, f.RaiseType(AttributeErrorType, "can't delete guarded")
+			}
+			if raised := objectDelAttr(f, args[0], name); raised != nil {
+				return nil, raised
+			}
+			return None, nil
+		}).ToObject(),
+	}))
+	newTestObject := func(t *Type) *Object {
+		o := newObject(t)
+		o.dict = newTestDict("bar", None, "guarded", None)
+		return o
+	}
+	cases := []invokeTestCase{
+		{args: wrapArgs(newTestObject(fooType), "bar"), want: newTestDict("guarded", None).ToObject()},
+		{args: wrapArgs(newTestObject(fooType), "baz"), wantExc: mustCreateException(AttributeErrorType, "'Foo' object has no attribute 'baz'")},
+		{args: wrapArgs(newTestObject(guardedType), "bar"), want: newTestDict("guarded", None).ToObject()},
+		{args: wrapArgs(newTestObject(guardedType), "guarded"), wantExc: mustCreateException(AttributeErrorType, "can't delete guarded")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(delAttr, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestDelItem(t *testing.T) {
 	delItem := newBuiltinFunction("TestDelItem", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 		if raised := checkFunctionArgs(f, "TestDelItem", args, ObjectType, ObjectType); raised != nil {
@@ -326,6 +372,37 @@ func TestDelItem(t *testing.T) {
 	}
 }
 
+func TestExec(t *testing.T) {
+	setFooCode := NewCode("<module>", "foo.py", nil, 0, func(f *Frame, _ []*Object) (*Object, *BaseException) {
+		return nil, f.Globals().SetItemString(f, "foo", NewInt(42).ToObject())
+	})
+	exec := newBuiltinFunction("TestExec", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+		if raised := checkFunctionArgs(f, "TestExec", args, ObjectType, ObjectType, ObjectType); raised != nil {
+			return nil, raised
+		}
+		if raised := Exec(f, args[0], args[1], args[2]); raised != nil {
+			return nil, raised
+		}
+		return args[1], nil
+	}).ToObject()
+	execGlobals := newTestDict("bar", 1)
+	cases := []invokeTestCase{
+		{args: wrapArgs(setFooCode, NewDict(), None), want: newTestDict("foo", 42).ToObject()},
+		{args: wrapArgs(setFooCode, newTestDict("bar", 1), None), want: newTestDict("foo", 42, "bar", 1).ToObject()},
+		{args: wrapArgs(setFooCode, execGlobals, execGlobals), want: newTestDict("foo", 42, "bar", 1).ToObject()},
+		{args: wrapArgs(setFooCode, NewDict(), NewDict()), wantExc: mustCreateException(NotImplementedErrorType, "exec of a code object with a separate locals mapping is not supported")},
+		{args: wrapArgs("foo = 42", NewDict(), None), wantExc: mustCreateException(NotImplementedErrorType, "exec of source code is not supported: Grumpy compiles Python ahead of time, move the code into a module and import it instead")},
+		{args: wrapArgs(123, NewDict(), None), wantExc: mustCreateException(TypeErrorType, "exec: arg 1 must be a string, file, or code object")},
+		{args: wrapArgs(setFooCode, newTestList(), None), wantExc: mustCreateException(TypeErrorType, "exec: arg 2 must be a dictionary or None")},
+		{args: wrapArgs(setFooCode, NewDict(), 123), wantExc: mustCreateException(TypeErrorType, "exec: arg 3 must be a mapping or None")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(exec, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestFormatException(t *testing.T) {
 	f := NewRootFrame()
 	cases := []struct {
@@ -379,6 +456,23 @@ func TestGetAttr(t *testing.T) {
 			return nil, f.RaiseType(TypeErrorType, "uh oh")
 		}).ToObject(),
 	}))
+	// __getattr__ is called for missing attributes and returns their names.
+	getAttrFunc := newBuiltinFunction("__getattr__", func(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
+		if toStrUnsafe(args[1]).Value() == "missing" {
+			return nil, f.RaiseType(AttributeErrorType, "missing")
+		}
+		return args[1], nil
+	}).ToObject()
+	bazType := newTestClass("Baz", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__getattr__": getAttrFunc,
+		"qux":         NewInt(123).ToObject(),
+	}))
+	quxType := newTestClass("Qux", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__getattr__": getAttrFunc,
+		"__getattribute__": newBuiltinFunction("__getattribute__", func(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
+			return nil, f.RaiseType(AttributeErrorType, "intercepted")
+		}).ToObject(),
+	}))
 	cases := []invokeTestCase{
 		{args: wrapArgs(newObject(fooType), "bar"), want: fooResult},
 		{args: wrapArgs(newObject(fooType), "baz", None), want: fooResult},
@@ -386,6 +480,11 @@ func TestGetAttr(t *testing.T) {
 		{args: wrapArgs(NewTuple(), "noexist"), wantExc: mustCreateException(AttributeErrorType, "'tuple' object has no attribute 'noexist'")},
 		{args: wrapArgs(DictType, "noexist"), wantExc: mustCreateException(AttributeErrorType, "type object 'dict' has no attribute 'noexist'")},
 		{args: wrapArgs(newObject(barType), "noexist"), wantExc: mustCreateException(TypeErrorType, "uh oh")},
+		{args: wrapArgs(newObject(bazType), "qux"), want: NewInt(123).ToObject()},
+		{args: wrapArgs(newObject(bazType), "foo"), want: NewStr("foo").ToObject()},
+		{args: wrapArgs(newObject(bazType), "missing"), wantExc: mustCreateException(AttributeErrorType, "missing")},
+		{args: wrapArgs(newObject(bazType), "missing", None), want: None},
+		{args: wrapArgs(newObject(quxType), "foo"), want: NewStr("foo").ToObject()},
 	}
 	for _, cas := range cases {
 		if err := runInvokeTestCase(getAttr, &cas); err != "" {
@@ -501,6 +600,29 @@ func TestIndex(t *testing.T) {
 	}
 }
 
+func TestIndexInt(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, o *Object) (*Object, *BaseException) {
+		i, raised := IndexInt(f, o)
+		if raised != nil {
+			return nil, raised
+		}
+		return NewInt(i).ToObject(), nil
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(42), want: NewInt(42).ToObject()},
+		{args: wrapArgs(-42), want: NewInt(-42).ToObject()},
+		{args: wrapArgs(NewLong(big.NewInt(123))), want: NewInt(123).ToObject()},
+		{args: wrapArgs(NewLong(new(big.Int).Lsh(maxIntBig, 2))), want: NewInt(MaxInt).ToObject()},
+		{args: wrapArgs(NewLong(new(big.Int).Lsh(minIntBig, 2))), want: NewInt(MinInt).ToObject()},
+		{args: wrapArgs(3.14), wantExc: mustCreateException(TypeErrorType, "slice indices must be integers or None or have an __index__ method")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestInvert(t *testing.T) {
 	cases := []invokeTestCase{
 		{args: wrapArgs(42), want: NewInt(-43).ToObject()},
@@ -535,6 +657,11 @@ func TestIsInstanceIsSubclass(t *testing.T) {
 		{newObject(fooType), IntType.ToObject(), False.ToObject(), nil},
 		{newObject(ObjectType), None, nil, mustCreateException(TypeErrorType, "classinfo must be a type or tuple of types")},
 		{newObject(ObjectType), NewTuple(None).ToObject(), nil, mustCreateException(TypeErrorType, "classinfo must be a type or tuple of types")},
+		{NewInt(42).ToObject(), NewTuple(StrType.ToObject(), NewTuple(NoneType.ToObject(), IntType.ToObject()).ToObject()).ToObject(), True.ToObject(), nil},
+		{NewInt(42).ToObject(), NewTuple(NewTuple(NewTuple(IntType.ToObject()).ToObject()).ToObject()).ToObject(), True.ToObject(), nil},
+		{NewInt(42).ToObject(), NewTuple(StrType.ToObject(), NewTuple(NoneType.ToObject()).ToObject()).ToObject(), False.ToObject(), nil},
+		{NewInt(42).ToObject(), NewTuple(IntType.ToObject(), None).ToObject(), True.ToObject(), nil},
+		{NewInt(42).ToObject(), NewTuple(NewTuple(StrType.ToObject(), None).ToObject(), IntType.ToObject()).ToObject(), nil, mustCreateException(TypeErrorType, "classinfo must be a type or tuple of types")},
 	}
 	for _, cas := range cases {
 		// IsInstance
@@ -555,6 +682,129 @@ func TestIsInstanceIsSubclass(t *testing.T) {
 	}
 }
 
+func TestIsInstanceIsSubclassHooks(t *testing.T) {
+	// The hooks report that everything but ints is an instance/subclass.
+	hook := wrapFuncForTest(func(f *Frame, cls, o *Object) (*Object, *BaseException) {
+		if o == IntType.ToObject() || o.isInstance(IntType) {
+			return False.ToObject(), nil
+		}
+		return NewInt(1).ToObject(), nil
+	})
+	raisingHook := wrapFuncForTest(func(f *Frame, cls, o *Object) (*Object, *BaseException) {
+		return nil, f.RaiseType(RuntimeErrorType, "foo")
+	})
+	metaType := newTestClass("Meta", []*Type{TypeType}, newStringDict(map[string]*Object{
+		"__instancecheck__": hook,
+		"__subclasscheck__": hook,
+	}))
+	badMetaType := newTestClass("BadMeta", []*Type{TypeType}, newStringDict(map[string]*Object{
+		"__instancecheck__": raisingHook,
+		"__subclasscheck__": raisingHook,
+	}))
+	fooType, raised := newClass(NewRootFrame(), metaType, "Foo", []*Type{ObjectType}, NewDict())
+	if raised != nil {
+		t.Fatal(raised)
+	}
+	barType, raised := newClass(NewRootFrame(), badMetaType, "Bar", []*Type{ObjectType}, NewDict())
+	if raised != nil {
+		t.Fatal(raised)
+	}
+	cases := []struct {
+		o         *Object
+		classinfo *Object
+		want      *Object
+		wantExc   *BaseException
+	}{
+		{NewStr("foo").ToObject(), fooType.ToObject(), True.ToObject(), nil},
+		{NewInt(42).ToObject(), fooType.ToObject(), False.ToObject(), nil},
+		{NewInt(42).ToObject(), NewTuple(StrType.ToObject(), fooType.ToObject()).ToObject(), False.ToObject(), nil},
+		{NewFloat(3.14).ToObject(), NewTuple(StrType.ToObject(), fooType.ToObject()).ToObject(), True.ToObject(), nil},
+		{NewFloat(3.14).ToObject(), barType.ToObject(), nil, mustCreateException(RuntimeErrorType, "foo")},
+	}
+	for _, cas := range cases {
+		testCase := invokeTestCase{args: wrapArgs(cas.o, cas.classinfo), want: cas.want, wantExc: cas.wantExc}
+		if err := runInvokeTestCase(wrapFuncForTest(IsInstance), &testCase); err != "" {
+			t.Error(err)
+		}
+		testCase.args = wrapArgs(cas.o.Type(), cas.classinfo)
+		if err := runInvokeTestCase(wrapFuncForTest(IsSubclass), &testCase); err != "" {
+			t.Error(err)
+		}
+	}
+	// Exact type matches don't consult __instancecheck__.
+	testCase := invokeTestCase{args: wrapArgs(newObject(barType), barType), want: True.ToObject()}
+	if err := runInvokeTestCase(wrapFuncForTest(IsInstance), &testCase); err != "" {
+		t.Error(err)
+	}
+}
+
+func TestIsInstanceIsSubclassAbstract(t *testing.T) {
+	f := NewRootFrame()
+	fooType := newTestClass("Foo", []*Type{ObjectType}, NewDict())
+	abstractType := newTestClass("Abstract", []*Type{ObjectType}, NewDict())
+	newAbstract := func(bases ...*Object) *Object {
+		o := newObject(abstractType)
+		if raised := SetAttr(f, o, NewStr("__bases__"), NewTuple(bases...).ToObject()); raised != nil {
+			t.Fatal(raised)
+		}
+		return o
+	}
+	base := newAbstract()
+	other := newAbstract()
+	derived := newAbstract(base)
+	multi := newAbstract(other, derived)
+	classGetter := func(cls *Object) *Object {
+		return newProperty(wrapFuncForTest(func(f *Frame, o *Object) (*Object, *BaseException) {
+			return cls, nil
+		}), nil, nil).ToObject()
+	}
+	proxyType := newTestClass("Proxy", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__class__": classGetter(fooType.ToObject()),
+	}))
+	raisingClassType := newTestClass("RaisingClass", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__class__": newProperty(wrapFuncForTest(func(f *Frame, o *Object) (*Object, *BaseException) {
+			return nil, f.RaiseType(RuntimeErrorType, "foo")
+		}), nil, nil).ToObject(),
+	}))
+	abstractInstType := newTestClass("AbstractInst", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__class__": classGetter(derived),
+	}))
+	isInstanceCases := []invokeTestCase{
+		{args: wrapArgs(newObject(proxyType), fooType), want: True.ToObject()},
+		{args: wrapArgs(newObject(proxyType), proxyType), want: True.ToObject()},
+		{args: wrapArgs(newObject(proxyType), IntType), want: False.ToObject()},
+		{args: wrapArgs(newObject(raisingClassType), fooType), want: False.ToObject()},
+		{args: wrapArgs(newObject(abstractInstType), derived), want: True.ToObject()},
+		{args: wrapArgs(newObject(abstractInstType), base), want: True.ToObject()},
+		{args: wrapArgs(newObject(abstractInstType), other), want: False.ToObject()},
+		{args: wrapArgs(newObject(raisingClassType), base), want: False.ToObject()},
+		{args: wrapArgs(42, base), want: False.ToObject()},
+		{args: wrapArgs(42, newObject(fooType)), wantExc: mustCreateException(TypeErrorType, "classinfo must be a type or tuple of types")},
+	}
+	for _, cas := range isInstanceCases {
+		if err := runInvokeTestCase(wrapFuncForTest(IsInstance), &cas); err != "" {
+			t.Error(err)
+		}
+	}
+	isSubclassCases := []invokeTestCase{
+		{args: wrapArgs(base, base), want: True.ToObject()},
+		{args: wrapArgs(derived, base), want: True.ToObject()},
+		{args: wrapArgs(base, derived), want: False.ToObject()},
+		{args: wrapArgs(multi, base), want: True.ToObject()},
+		{args: wrapArgs(multi, other), want: True.ToObject()},
+		{args: wrapArgs(multi, NewTuple(fooType.ToObject(), base).ToObject()), want: True.ToObject()},
+		{args: wrapArgs(derived, other), want: False.ToObject()},
+		{args: wrapArgs(IntType, base), want: False.ToObject()},
+		{args: wrapArgs(None, base), wantExc: mustCreateException(TypeErrorType, "issubclass() arg 1 must be a class")},
+		{args: wrapArgs(base, None), wantExc: mustCreateException(TypeErrorType, "classinfo must be a type or tuple of types")},
+	}
+	for _, cas := range isSubclassCases {
+		if err := runInvokeTestCase(wrapFuncForTest(IsSubclass), &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestIsTrue(t *testing.T) {
 	badNonZeroType := newTestClass("BadNonZeroType", []*Type{ObjectType}, newStringDict(map[string]*Object{
 		"__nonzero__": newBuiltinFunction("__nonzero__", func(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
@@ -638,6 +888,24 @@ func TestNeg(t *testing.T) {
 	}
 }
 
+func TestNewLocals(t *testing.T) {
+	f := NewRootFrame()
+	foo, bar := NewInt(1).ToObject(), UnboundLocal
+	locals := NewLocals([]string{"foo", "bar"}, func() []*Object { return []*Object{foo, bar} })
+	d, raised := locals(f)
+	if want := newTestDict("foo", 1).ToObject(); checkResult(d.ToObject(), want, raised, nil) != checkInvokeResultOk {
+		t.Errorf("locals() = (%v, %v), want (%v, nil)", d, raised, want)
+	}
+	foo, bar = UnboundLocal, NewStr("bar").ToObject()
+	d2, raised := locals(f)
+	if want := newTestDict("bar", "bar").ToObject(); checkResult(d2.ToObject(), want, raised, nil) != checkInvokeResultOk {
+		t.Errorf("locals() = (%v, %v), want (%v, nil)", d2, raised, want)
+	}
+	if d2 != d {
+		t.Errorf("locals() returned a new dict, want the same dict")
+	}
+}
+
 func TestNext(t *testing.T) {
 	fun := newBuiltinFunction("TestNext", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 		if argc := len(args); argc != 1 {
@@ -717,18 +985,20 @@ func TestInvokePositionalArgs(t *testing.T) {
 		varargs *Object
 		args    Args
 		want    *Object
+		wantExc *BaseException
 	}{
-		{nil, nil, NewTuple().ToObject()},
-		{NewTuple(NewInt(2).ToObject()).ToObject(), nil, NewTuple(NewInt(2).ToObject()).ToObject()},
-		{nil, []*Object{NewStr("foo").ToObject()}, NewTuple(NewStr("foo").ToObject()).ToObject()},
-		{NewTuple(NewFloat(3.14).ToObject()).ToObject(), []*Object{NewStr("foo").ToObject()}, NewTuple(NewStr("foo").ToObject(), NewFloat(3.14).ToObject()).ToObject()},
-		{NewList(NewFloat(3.14).ToObject()).ToObject(), []*Object{NewStr("foo").ToObject()}, NewTuple(NewStr("foo").ToObject(), NewFloat(3.14).ToObject()).ToObject()},
+		{nil, nil, NewTuple().ToObject(), nil},
+		{NewTuple(NewInt(2).ToObject()).ToObject(), nil, NewTuple(NewInt(2).ToObject()).ToObject(), nil},
+		{nil, []*Object{NewStr("foo").ToObject()}, NewTuple(NewStr("foo").ToObject()).ToObject(), nil},
+		{NewTuple(NewFloat(3.14).ToObject()).ToObject(), []*Object{NewStr("foo").ToObject()}, NewTuple(NewStr("foo").ToObject(), NewFloat(3.14).ToObject()).ToObject(), nil},
+		{NewList(NewFloat(3.14).ToObject()).ToObject(), []*Object{NewStr("foo").ToObject()}, NewTuple(NewStr("foo").ToObject(), NewFloat(3.14).ToObject()).ToObject(), nil},
+		{NewInt(1).ToObject(), nil, nil, mustCreateException(TypeErrorType, "TestInvokePositionalArgs() argument after * must be an iterable, not int")},
 	}
 	for _, cas := range cases {
 		got, raised := Invoke(NewRootFrame(), fun, cas.args, cas.varargs, nil, nil)
-		switch checkResult(got, cas.want, raised, nil) {
+		switch checkResult(got, cas.want, raised, cas.wantExc) {
 		case checkInvokeResultExceptionMismatch:
-			t.Errorf("PackArgs(%v, %v) raised %v, want nil", cas.args, cas.varargs, raised)
+			t.Errorf("PackArgs(%v, %v) raised %v, want %v", cas.args, cas.varargs, raised, cas.wantExc)
 		case checkInvokeResultReturnValueMismatch:
 			t.Errorf("PackArgs(%v, %v) = %v, want %v", cas.args, cas.varargs, got, cas.want)
 		}
@@ -745,6 +1015,14 @@ func TestInvokeKeywordArgs(t *testing.T) {
 	}).ToObject()
 	d := NewDict()
 	d.SetItem(NewRootFrame(), NewInt(123).ToObject(), None)
+	mapping := newTestClass("Mapping", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"keys": newBuiltinFunction("keys", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return NewList(NewStr("foo").ToObject()).ToObject(), nil
+		}).ToObject(),
+		"__getitem__": newBuiltinFunction("__getitem__", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+			return NewStr("bar").ToObject(), nil
+		}).ToObject(),
+	}))
 	cases := []struct {
 		keywords KWArgs
 		kwargs   *Object
@@ -755,8 +1033,10 @@ func TestInvokeKeywordArgs(t *testing.T) {
 		{wrapKWArgs("foo", 42), nil, newTestDict("foo", 42).ToObject(), nil},
 		{nil, newTestDict("foo", None).ToObject(), newTestDict("foo", None).ToObject(), nil},
 		{wrapKWArgs("foo", 42), newTestDict("bar", None).ToObject(), newTestDict("foo", 42, "bar", None).ToObject(), nil},
-		{nil, NewList().ToObject(), nil, mustCreateException(TypeErrorType, "argument after ** must be a dict, not list")},
-		{nil, d.ToObject(), nil, mustCreateException(TypeErrorType, "keywords must be strings")},
+		{nil, newObject(mapping), newTestDict("foo", "bar").ToObject(), nil},
+		{wrapKWArgs("foo", 42), newTestDict("foo", None).ToObject(), nil, mustCreateException(TypeErrorType, "TestInvokeKeywordArgs() got multiple values for keyword argument 'foo'")},
+		{nil, NewList().ToObject(), nil, mustCreateException(TypeErrorType, "TestInvokeKeywordArgs() argument after ** must be a mapping, not list")},
+		{nil, d.ToObject(), nil, mustCreateException(TypeErrorType, "TestInvokeKeywordArgs() keywords must be strings")},
 	}
 	for _, cas := range cases {
 		got, raised := Invoke(NewRootFrame(), fun, nil, nil, cas.keywords, cas.kwargs)
@@ -992,6 +1272,39 @@ func TestCheckLocal(t *testing.T) {
 	}
 }
 
+func TestSetAttr(t *testing.T) {
+	setAttr := wrapFuncForTest(func(f *Frame, o *Object, name *Str, value *Object) (*Object, *BaseException) {
+		if raised := SetAttr(f, o, name, value); raised != nil {
+			return nil, raised
+		}
+		return o.Dict().ToObject(), nil
+	})
+	fooType := newTestClass("Foo", []*Type{ObjectType}, NewDict())
+	validatingType := newTestClass("Validating", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__setattr__": newBuiltinFunction("__setattr__", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+			name := toStrUnsafe(args[1])
+			if strings.HasPrefix(name.Value(), "_") {
+				return nil, f.RaiseType(AttributeErrorType, "private attribute")
+			}
+			if raised := objectSetAttr(f, args[0], name, NewTuple(args[2]).ToObject()); raised != nil {
+				return nil, raised
+			}
+			return None, nil
+		}).ToObject(),
+	}))
+	cases := []invokeTestCase{
+		{args: wrapArgs(newObject(fooType), "bar", 123), want: newTestDict("bar", 123).ToObject()},
+		{args: wrapArgs(newObject(validatingType), "bar", 123), want: newTestDict("bar", newTestTuple(123)).ToObject()},
+		{args: wrapArgs(newObject(validatingType), "_bar", 123), wantExc: mustCreateException(AttributeErrorType, "private attribute")},
+		{args: wrapArgs(NewTuple(), "bar", 123), wantExc: mustCreateException(AttributeErrorType, "'tuple' object has no attribute 'bar'")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(setAttr, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestSetItem(t *testing.T) {
 	setItem := newBuiltinFunction("TestSetItem", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 		if raised := checkFunctionArgs(f, "TestSetItem", args, ObjectType, ObjectType, ObjectType); raised != nil {
@@ -1076,7 +1389,18 @@ func TestTie(t *testing.T) {
 			},
 			NewList(NewStr("foo").ToObject()).ToObject(),
 			nil,
-			mustCreateException(ValueErrorType, "need more than 1 values to unpack"),
+			mustCreateException(ValueErrorType, "need more than 1 value to unpack"),
+		},
+		{
+			TieTarget{
+				Children: []TieTarget{
+					{Target: &targets[0]},
+					{Children: []TieTarget{{Target: &targets[1]}, {Target: &targets[2]}}},
+				},
+			},
+			NewTuple(NewStr("foo").ToObject(), NewTuple().ToObject()).ToObject(),
+			nil,
+			mustCreateException(ValueErrorType, "need more than 0 values to unpack"),
 		},
 		{
 			TieTarget{Children: []TieTarget{{Target: &targets[0]}}},
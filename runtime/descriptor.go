@@ -22,7 +22,9 @@ import (
 // Property represents Python 'property' objects.
 type Property struct {
 	Object
-	get, set, del *Object
+	get *Object `attr:"fget"`
+	set *Object `attr:"fset"`
+	del *Object `attr:"fdel"`
 }
 
 func newProperty(get, set, del *Object) *Property {
@@ -57,8 +59,13 @@ func propertyDelete(f *Frame, desc, inst *Object) *BaseException {
 	return raised
 }
 
-func propertyGet(f *Frame, desc, instance *Object, _ *Type) (*Object, *BaseException) {
+func propertyGet(f *Frame, desc, instance *Object, owner *Type) (*Object, *BaseException) {
 	p := toPropertyUnsafe(desc)
+	if instance == None && owner != NoneType {
+		// Like CPython, accessing a property on a class returns the
+		// property itself.
+		return desc, nil
+	}
 	if p.get == nil || p.get == None {
 		return nil, f.RaiseType(AttributeErrorType, "unreadable attribute")
 	}
@@ -18,6 +18,23 @@ import (
 	"testing"
 )
 
+func TestPropertyAttrs(t *testing.T) {
+	getAttr := wrapFuncForTest(func(f *Frame, o *Object, name *Str) (*Object, *BaseException) {
+		return GetAttr(f, o, name, nil)
+	})
+	p := newProperty(NewStr("foo").ToObject(), nil, NewStr("bar").ToObject())
+	cases := []invokeTestCase{
+		{args: wrapArgs(p, "fget"), want: NewStr("foo").ToObject()},
+		{args: wrapArgs(p, "fset"), want: None},
+		{args: wrapArgs(p, "fdel"), want: NewStr("bar").ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(getAttr, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestPropertyDelete(t *testing.T) {
 	dummy := newObject(ObjectType)
 	cases := []invokeTestCase{
@@ -34,10 +51,13 @@ func TestPropertyDelete(t *testing.T) {
 
 func TestPropertyGet(t *testing.T) {
 	dummy := newObject(ObjectType)
+	classProp := newProperty(wrapFuncForTest(func(f *Frame, o *Object) *Type { return o.typ }), nil, nil).ToObject()
 	cases := []invokeTestCase{
 		{args: wrapArgs(newProperty(wrapFuncForTest(func(f *Frame, o *Object) (*Object, *BaseException) { return o, nil }), nil, nil), dummy, ObjectType), want: dummy},
 		{args: wrapArgs(newProperty(wrapFuncForTest(func(f *Frame, o *Object) (*Object, *BaseException) { return nil, f.RaiseType(ValueErrorType, "bar") }), nil, nil), dummy, ObjectType), wantExc: mustCreateException(ValueErrorType, "bar")},
 		{args: wrapArgs(newProperty(nil, nil, nil), dummy, ObjectType), wantExc: mustCreateException(AttributeErrorType, "unreadable attribute")},
+		{args: wrapArgs(classProp, None, ObjectType), want: classProp},
+		{args: wrapArgs(classProp, None, NoneType), want: NoneType.ToObject()},
 	}
 	for _, cas := range cases {
 		if err := runInvokeMethodTestCase(PropertyType, "__get__", &cas); err != "" {
@@ -61,6 +61,10 @@ type dictTable struct {
 	// When the table is no longer large enough to hold a dict's contents,
 	// a new dictTable will be created.
 	entries []*dictEntry
+	// popFinger is the index where dict.popitem() resumes its search for
+	// an occupied slot, so that draining a dict doesn't rescan the slots
+	// emptied by earlier calls.
+	popFinger int
 }
 
 // newDictTable allocates a table where at least minCapacity entries can be
@@ -531,6 +535,23 @@ func dictClear(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	return None, nil
 }
 
+func dictCopy(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "copy", args, DictType); raised != nil {
+		return nil, raised
+	}
+	d := toDictUnsafe(args[0])
+	d.mutex.Lock(f)
+	// Entries are immutable so they can be shared with the copy.
+	table := newDictTable(d.Len() * 2)
+	for _, entry := range d.table.entries {
+		if entry != nil && entry != deletedEntry {
+			table.insertAbsentEntry(entry)
+		}
+	}
+	d.mutex.Unlock(f)
+	return (&Dict{Object: Object{typ: DictType}, table: table}).ToObject(), nil
+}
+
 func dictContains(f *Frame, seq, value *Object) (*Object, *BaseException) {
 	item, raised := toDictUnsafe(seq).GetItem(f, value)
 	if raised != nil {
@@ -561,6 +582,33 @@ func dictEq(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return GetBool(eq).ToObject(), nil
 }
 
+// dictFromKeys implements the dict.fromkeys classmethod.
+func dictFromKeys(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	expectedTypes := []*Type{TypeType, ObjectType, ObjectType}
+	argc := len(args)
+	if argc == 2 {
+		expectedTypes = expectedTypes[:2]
+	}
+	if raised := checkMethodArgs(f, "fromkeys", args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	value := None
+	if argc > 2 {
+		value = args[2]
+	}
+	d, raised := args[0].Call(f, nil, nil)
+	if raised != nil {
+		return nil, raised
+	}
+	raised = seqForEach(f, args[1], func(key *Object) *BaseException {
+		return SetItem(f, d, key, value)
+	})
+	if raised != nil {
+		return nil, raised
+	}
+	return d, nil
+}
+
 func dictGet(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 	expectedTypes := []*Type{DictType, ObjectType, ObjectType}
 	argc := len(args)
@@ -656,12 +704,55 @@ func dictPop(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	return item, raised
 }
 
+func dictPopItem(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "popitem", args, DictType); raised != nil {
+		return nil, raised
+	}
+	d := toDictUnsafe(args[0])
+	d.mutex.Lock(f)
+	var item *Object
+	if t := d.table; t.loadUsed() > 0 {
+		numEntries := len(t.entries)
+		i := t.popFinger
+		for {
+			if i >= numEntries {
+				i = 0
+			}
+			if entry := t.entries[i]; entry != nil && entry != deletedEntry {
+				item = NewTuple2(entry.key, entry.value).ToObject()
+				break
+			}
+			i++
+		}
+		t.storeEntry(i, deletedEntry)
+		t.incUsed(-1)
+		t.popFinger = i + 1
+		d.incVersion()
+	}
+	d.mutex.Unlock(f)
+	if item == nil {
+		return nil, f.RaiseType(KeyErrorType, "popitem(): dictionary is empty")
+	}
+	return item, nil
+}
+
 func dictGetItem(f *Frame, o, key *Object) (*Object, *BaseException) {
 	item, raised := toDictUnsafe(o).GetItem(f, key)
 	if raised != nil {
 		return nil, raised
 	}
 	if item == nil {
+		// Subclasses may define __missing__ to handle absent keys, e.g.
+		// collections.Counter and defaultdict.
+		if o.typ != DictType {
+			missing, raised := o.typ.mroLookup(f, NewStr("__missing__"))
+			if raised != nil {
+				return nil, raised
+			}
+			if missing != nil {
+				return missing.Call(f, Args{o, key}, nil)
+			}
+		}
 		return nil, raiseKeyError(f, key)
 	}
 	return item, nil
@@ -755,6 +846,30 @@ func dictRepr(f *Frame, o *Object) (*Object, *BaseException) {
 	return NewStr(buf.String()).ToObject(), nil
 }
 
+func dictSetDefault(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	expectedTypes := []*Type{DictType, ObjectType, ObjectType}
+	argc := len(args)
+	if argc == 2 {
+		expectedTypes = expectedTypes[:2]
+	}
+	if raised := checkMethodArgs(f, "setdefault", args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	d := toDictUnsafe(args[0])
+	item, raised := d.GetItem(f, args[1])
+	if raised != nil || item != nil {
+		return item, raised
+	}
+	item = None
+	if argc > 2 {
+		item = args[2]
+	}
+	if raised := d.SetItem(f, args[1], item); raised != nil {
+		return nil, raised
+	}
+	return item, nil
+}
+
 func dictSetItem(f *Frame, o, key, value *Object) *BaseException {
 	return toDictUnsafe(o).SetItem(f, key, value)
 }
@@ -795,6 +910,8 @@ func dictValues(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 
 func initDictType(dict map[string]*Object) {
 	dict["clear"] = newBuiltinFunction("clear", dictClear).ToObject()
+	dict["copy"] = newBuiltinFunction("copy", dictCopy).ToObject()
+	dict["fromkeys"] = newClassMethod(newBuiltinFunction("fromkeys", dictFromKeys).ToObject()).ToObject()
 	dict["get"] = newBuiltinFunction("get", dictGet).ToObject()
 	dict["has_key"] = newBuiltinFunction("has_key", dictHasKey).ToObject()
 	dict["items"] = newBuiltinFunction("items", dictItems).ToObject()
@@ -803,6 +920,8 @@ func initDictType(dict map[string]*Object) {
 	dict["itervalues"] = newBuiltinFunction("itervalues", dictIterValues).ToObject()
 	dict["keys"] = newBuiltinFunction("keys", dictKeys).ToObject()
 	dict["pop"] = newBuiltinFunction("pop", dictPop).ToObject()
+	dict["popitem"] = newBuiltinFunction("popitem", dictPopItem).ToObject()
+	dict["setdefault"] = newBuiltinFunction("setdefault", dictSetDefault).ToObject()
 	dict["update"] = newBuiltinFunction("update", dictUpdate).ToObject()
 	dict["values"] = newBuiltinFunction("values", dictValues).ToObject()
 	DictType.slots.Contains = &binaryOpSlot{dictContains}
@@ -83,6 +83,34 @@ func TestDictContains(t *testing.T) {
 	}
 }
 
+func TestDictCopy(t *testing.T) {
+	copyDict := mustNotRaise(GetAttr(NewRootFrame(), DictType.ToObject(), NewStr("copy"), nil))
+	fun := wrapFuncForTest(func(f *Frame, d *Object) (*Object, *BaseException) {
+		o, raised := copyDict.Call(f, Args{d}, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		// Modifying the copy must not affect the original.
+		if raised := toDictUnsafe(o).SetItemString(f, "qux", None); raised != nil {
+			return nil, raised
+		}
+		return newTestTuple(o.typ, d, o).ToObject(), nil
+	})
+	subType := newTestClass("Sub", []*Type{DictType}, NewDict())
+	sub := mustNotRaise(subType.Call(NewRootFrame(), wrapArgs(newTestDict("foo", 1)), nil))
+	cases := []invokeTestCase{
+		{args: wrapArgs(NewDict()), want: newTestTuple(DictType, NewDict(), newTestDict("qux", None)).ToObject()},
+		{args: wrapArgs(newTestDict(2, None, "baz", 3.14)), want: newTestTuple(DictType, newTestDict(2, None, "baz", 3.14), newTestDict(2, None, "baz", 3.14, "qux", None)).ToObject()},
+		{args: wrapArgs(sub), want: newTestTuple(DictType, newTestDict("foo", 1), newTestDict("foo", 1, "qux", None)).ToObject()},
+		{args: wrapArgs(None), wantExc: mustCreateException(TypeErrorType, "unbound method copy() must be called with dict instance as first argument (got NoneType instance instead)")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestDictDelItem(t *testing.T) {
 	fun := newBuiltinFunction("TestDictDelItem", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 		if raised := checkMethodArgs(f, "TestDictDelItem", args, DictType, ObjectType); raised != nil {
@@ -182,6 +210,33 @@ func TestDictEqNE(t *testing.T) {
 	}
 }
 
+func TestDictFromKeys(t *testing.T) {
+	setItemType := newTestClass("SetItem", []*Type{DictType}, newStringDict(map[string]*Object{
+		"__setitem__": newBuiltinFunction("__setitem__", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+			return None, toDictUnsafe(args[0]).SetItem(f, args[1], NewInt(123).ToObject())
+		}).ToObject(),
+	}))
+	fromKeys := mustNotRaise(GetAttr(NewRootFrame(), DictType.ToObject(), NewStr("fromkeys"), nil))
+	subFromKeys := mustNotRaise(GetAttr(NewRootFrame(), setItemType.ToObject(), NewStr("fromkeys"), nil))
+	cases := []struct {
+		fromKeys *Object
+		invokeTestCase
+	}{
+		{fromKeys, invokeTestCase{args: wrapArgs(newTestList()), want: NewDict().ToObject()}},
+		{fromKeys, invokeTestCase{args: wrapArgs(newTestTuple("foo", 2)), want: newTestDict("foo", None, 2, None).ToObject()}},
+		{fromKeys, invokeTestCase{args: wrapArgs("ab", 1), want: newTestDict("a", 1, "b", 1).ToObject()}},
+		{fromKeys, invokeTestCase{args: wrapArgs(newTestList(newTestList())), wantExc: mustCreateException(TypeErrorType, "unhashable type: 'list'")}},
+		{fromKeys, invokeTestCase{args: wrapArgs(1), wantExc: mustCreateException(TypeErrorType, "'int' object is not iterable")}},
+		{fromKeys, invokeTestCase{wantExc: mustCreateException(TypeErrorType, "'fromkeys' of 'type' requires 3 arguments")}},
+		{subFromKeys, invokeTestCase{args: wrapArgs(newTestList("foo")), want: newTestDict("foo", 123).ToObject()}},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(cas.fromKeys, &cas.invokeTestCase); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestDictGet(t *testing.T) {
 	cases := []invokeTestCase{
 		{args: wrapArgs(NewDict(), "foo"), want: None},
@@ -373,6 +428,32 @@ func BenchmarkDictIterValues(b *testing.B) {
 	b.Run("8-elements", bench(newTestDict(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16)))
 }
 
+func TestDictGetItemMissing(t *testing.T) {
+	missingType := newTestClass("Missing", []*Type{DictType}, newStringDict(map[string]*Object{
+		"__missing__": newBuiltinFunction("__missing__", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+			return NewTuple(args[1], NewStr("missing").ToObject()).ToObject(), nil
+		}).ToObject(),
+	}))
+	raisesType := newTestClass("Raises", []*Type{DictType}, newStringDict(map[string]*Object{
+		"__missing__": newBuiltinFunction("__missing__", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+			return nil, f.RaiseType(RuntimeErrorType, "foo")
+		}).ToObject(),
+	}))
+	f := NewRootFrame()
+	d := mustNotRaise(missingType.Call(f, wrapArgs(newTestDict("foo", 1)), nil))
+	cases := []invokeTestCase{
+		{args: wrapArgs(d, "foo"), want: NewInt(1).ToObject()},
+		{args: wrapArgs(d, "bar"), want: newTestTuple("bar", "missing").ToObject()},
+		{args: wrapArgs(mustNotRaise(raisesType.Call(f, nil, nil)), "bar"), wantExc: mustCreateException(RuntimeErrorType, "foo")},
+		{args: wrapArgs(mustNotRaise(newTestClass("NoMissing", []*Type{DictType}, NewDict()).Call(f, nil, nil)), "bar"), wantExc: mustCreateException(KeyErrorType, "bar")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(wrapFuncForTest(GetItem), &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestDictGetItemString(t *testing.T) {
 	getItemString := newBuiltinFunction("TestDictGetItemString", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 		if raised := checkFunctionArgs(f, "TestDictGetItem", args, DictType, StrType); raised != nil {
@@ -585,6 +666,47 @@ func TestDictPop(t *testing.T) {
 	}
 }
 
+func TestDictPopItem(t *testing.T) {
+	popItem := mustNotRaise(GetAttr(NewRootFrame(), DictType.ToObject(), NewStr("popitem"), nil))
+	fun := wrapFuncForTest(func(f *Frame, d *Dict) (*Object, *BaseException) {
+		item, raised := popItem.Call(f, Args{d.ToObject()}, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return NewTuple2(item, d.ToObject()).ToObject(), nil
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(newTestDict("foo", 42)), want: newTestTuple(newTestTuple("foo", 42), NewDict()).ToObject()},
+		{args: wrapArgs(NewDict()), wantExc: mustCreateException(KeyErrorType, "popitem(): dictionary is empty")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+	// Drain a dict, refilling it partway through, and check that every
+	// key comes out exactly once.
+	d := NewDict()
+	for i := 0; i < 100; i++ {
+		d.SetItem(NewRootFrame(), NewInt(i).ToObject(), None)
+	}
+	seen := map[int]bool{}
+	for d.Len() > 0 {
+		item := mustNotRaise(popItem.Call(NewRootFrame(), Args{d.ToObject()}, nil))
+		i := toIntUnsafe(toTupleUnsafe(item).elems[0]).Value()
+		if seen[i] {
+			t.Fatalf("popitem() returned key %d twice", i)
+		}
+		seen[i] = true
+		if len(seen) == 50 {
+			d.SetItem(NewRootFrame(), NewInt(100).ToObject(), None)
+		}
+	}
+	if len(seen) != 101 {
+		t.Errorf("popitem() returned %d distinct keys, want 101", len(seen))
+	}
+}
+
 func TestDictNewInit(t *testing.T) {
 	cases := []invokeTestCase{
 		{args: wrapArgs(), want: NewDict().ToObject()},
@@ -615,6 +737,29 @@ func TestDictNewRaises(t *testing.T) {
 	}
 }
 
+func TestDictSetDefault(t *testing.T) {
+	setDefault := mustNotRaise(GetAttr(NewRootFrame(), DictType.ToObject(), NewStr("setdefault"), nil))
+	fun := wrapFuncForTest(func(f *Frame, args ...*Object) (*Tuple, *BaseException) {
+		result, raised := setDefault.Call(f, args, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return NewTuple2(result, args[0]), nil
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(NewDict(), "foo"), want: newTestTuple(None, newTestDict("foo", None)).ToObject()},
+		{args: wrapArgs(NewDict(), "foo", 42), want: newTestTuple(42, newTestDict("foo", 42)).ToObject()},
+		{args: wrapArgs(newTestDict("foo", 1), "foo", 42), want: newTestTuple(1, newTestDict("foo", 1)).ToObject()},
+		{args: wrapArgs(NewDict(), NewList()), wantExc: mustCreateException(TypeErrorType, "unhashable type: 'list'")},
+		{args: wrapArgs(NewDict()), wantExc: mustCreateException(TypeErrorType, "'setdefault' of 'dict' requires 3 arguments")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestDictSetItem(t *testing.T) {
 	setItem := newBuiltinFunction("TestDictSetItem", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 		if raised := checkFunctionArgs(f, "TestDictSetItem", args, DictType, ObjectType, ObjectType); raised != nil {
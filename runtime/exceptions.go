@@ -34,6 +34,8 @@ var (
 	ExceptionType = newSimpleType("Exception", BaseExceptionType)
 	// FutureWarningType corresponds to the Python type 'FutureWarning'.
 	FutureWarningType = newSimpleType("FutureWarning", WarningType)
+	// GeneratorExitType corresponds to the Python type 'GeneratorExit'.
+	GeneratorExitType = newSimpleType("GeneratorExit", BaseExceptionType)
 	// ImportErrorType corresponds to the Python type 'ImportError'.
 	ImportErrorType = newSimpleType("ImportError", StandardErrorType)
 	// ImportWarningType corresponds to the Python type 'ImportWarning'.
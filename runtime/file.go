@@ -58,33 +58,70 @@ func (f *File) ToObject() *Object {
 	return &f.Object
 }
 
+// readByte returns the next byte from f. In universal newline mode "\r\n"
+// and "\r" are both translated to "\n".
+func (f *File) readByte() (byte, error) {
+	b, err := f.reader.ReadByte()
+	if err != nil || !f.univNewLine {
+		return b, err
+	}
+	if f.skipNextLF {
+		f.skipNextLF = false
+		if b == '\n' {
+			// The \n was already returned as part of a \r\n.
+			if b, err = f.reader.ReadByte(); err != nil {
+				return b, err
+			}
+		}
+	}
+	if b == '\r' {
+		f.skipNextLF = true
+		b = '\n'
+	}
+	return b, nil
+}
+
+func (f *File) read(size int) ([]byte, error) {
+	if !f.univNewLine {
+		if size < 0 {
+			return ioutil.ReadAll(f.reader)
+		}
+		data := make([]byte, size)
+		n, err := io.ReadFull(f.reader, data)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			// A short read at the end of the file is not an error.
+			err = nil
+		}
+		return data[:n], err
+	}
+	var buf bytes.Buffer
+	for size < 0 || buf.Len() < size {
+		b, err := f.readByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteByte(b)
+	}
+	return buf.Bytes(), nil
+}
+
 func (f *File) readLine(maxBytes int) (string, error) {
 	var buf bytes.Buffer
-	numBytesRead := 0
-	for maxBytes < 0 || numBytesRead < maxBytes {
-		b, err := f.reader.ReadByte()
+	for maxBytes < 0 || buf.Len() < maxBytes {
+		b, err := f.readByte()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
 			return "", err
 		}
-		if b == '\r' && f.univNewLine {
-			f.skipNextLF = true
-			buf.WriteByte('\n')
+		buf.WriteByte(b)
+		if b == '\n' {
 			break
-		} else if b == '\n' {
-			if f.skipNextLF {
-				f.skipNextLF = false
-				continue // Do not increment numBytesRead.
-			} else {
-				buf.WriteByte(b)
-				break
-			}
-		} else {
-			buf.WriteByte(b)
 		}
-		numBytesRead++
 	}
 	return buf.String(), nil
 }
@@ -102,6 +139,20 @@ func (f *File) writeString(s string) error {
 	return nil
 }
 
+// syncPosition moves the underlying file offset back to the position the
+// caller has actually consumed, discarding any read-ahead data buffered by
+// f.reader. It must be called before writing or seeking relative to the
+// current position.
+func (f *File) syncPosition() error {
+	if n := f.reader.Buffered(); n > 0 {
+		if _, err := f.file.Seek(int64(-n), io.SeekCurrent); err != nil {
+			return err
+		}
+	}
+	f.reader.Reset(f.file)
+	return nil
+}
+
 // FileType is the object representing the Python 'file' type.
 var FileType = newBasisType("file", reflect.TypeOf(File{}), toFileUnsafe, ObjectType)
 
@@ -190,6 +241,22 @@ func fileClose(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	return None, nil
 }
 
+func fileFlush(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "flush", args, FileType); raised != nil {
+		return nil, raised
+	}
+	file := toFileUnsafe(args[0])
+	file.mutex.Lock()
+	defer file.mutex.Unlock()
+	if !file.open {
+		return nil, f.RaiseType(ValueErrorType, "I/O operation on closed file")
+	}
+	// Writes go directly to the os.File so there's nothing buffered on our
+	// side. Syncing to disk is more than CPython does for flush() so
+	// don't bother.
+	return None, nil
+}
+
 func fileIter(f *Frame, o *Object) (*Object, *BaseException) {
 	return o, nil
 }
@@ -221,16 +288,7 @@ func fileRead(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	if !file.open {
 		return nil, f.RaiseType(ValueErrorType, "I/O operation on closed file")
 	}
-	var data []byte
-	var err error
-	if size < 0 {
-		data, err = ioutil.ReadAll(file.file)
-	} else {
-		data = make([]byte, size)
-		var n int
-		n, err = file.reader.Read(data)
-		data = data[:n]
-	}
+	data, err := file.read(size)
 	if err != nil {
 		return nil, f.RaiseType(IOErrorType, err.Error())
 	}
@@ -310,6 +368,68 @@ func fileRepr(f *Frame, o *Object) (*Object, *BaseException) {
 	return NewStr(fmt.Sprintf("<%s file %q, mode %q at %p>", openState, name, mode, file)).ToObject(), nil
 }
 
+func fileSeek(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	argc := len(args)
+	expectedTypes := []*Type{FileType, IntType, IntType}
+	if argc == 2 {
+		expectedTypes = expectedTypes[:2]
+	}
+	if raised := checkMethodArgs(f, "seek", args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	file := toFileUnsafe(args[0])
+	file.mutex.Lock()
+	defer file.mutex.Unlock()
+	if !file.open {
+		return nil, f.RaiseType(ValueErrorType, "I/O operation on closed file")
+	}
+	offset := int64(toIntUnsafe(args[1]).Value())
+	whence := io.SeekStart
+	if argc > 2 {
+		whence = toIntUnsafe(args[2]).Value()
+	}
+	if whence < io.SeekStart || whence > io.SeekEnd {
+		return nil, f.RaiseType(IOErrorType, "[Errno 22] Invalid argument")
+	}
+	if whence == io.SeekCurrent {
+		// The OS offset is ahead of the logical position by however
+		// much data the reader has buffered.
+		offset -= int64(file.reader.Buffered())
+	}
+	if _, err := file.file.Seek(offset, whence); err != nil {
+		return nil, f.RaiseType(IOErrorType, err.Error())
+	}
+	file.reader.Reset(file.file)
+	file.skipNextLF = false
+	return None, nil
+}
+
+func fileTell(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "tell", args, FileType); raised != nil {
+		return nil, raised
+	}
+	file := toFileUnsafe(args[0])
+	file.mutex.Lock()
+	defer file.mutex.Unlock()
+	if !file.open {
+		return nil, f.RaiseType(ValueErrorType, "I/O operation on closed file")
+	}
+	if file.skipNextLF {
+		// The \r of a \r\n pair was returned as \n but the trailing \n
+		// hasn't been consumed yet, so consume it now lest the position
+		// be off by one.
+		if b, err := file.reader.Peek(1); err == nil && b[0] == '\n' {
+			file.reader.Discard(1)
+		}
+		file.skipNextLF = false
+	}
+	pos, err := file.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, f.RaiseType(IOErrorType, err.Error())
+	}
+	return NewInt(int(pos) - file.reader.Buffered()).ToObject(), nil
+}
+
 func fileWrite(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	if raised := checkMethodArgs(f, "write", args, FileType, StrType); raised != nil {
 		return nil, raised
@@ -320,21 +440,60 @@ func fileWrite(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	if !file.open {
 		return nil, f.RaiseType(ValueErrorType, "I/O operation on closed file")
 	}
+	if err := file.syncPosition(); err != nil {
+		return nil, f.RaiseType(IOErrorType, err.Error())
+	}
 	if _, err := file.file.Write([]byte(toStrUnsafe(args[1]).Value())); err != nil {
 		return nil, f.RaiseType(IOErrorType, err.Error())
 	}
 	return None, nil
 }
 
+func fileWriteLines(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "writelines", args, FileType, ObjectType); raised != nil {
+		return nil, raised
+	}
+	// Collect the lines up front so that a bad element doesn't result in a
+	// partial write.
+	var buf bytes.Buffer
+	raised := seqForEach(f, args[1], func(o *Object) *BaseException {
+		if !o.isInstance(StrType) {
+			return f.RaiseType(TypeErrorType, "writelines() argument must be a sequence of strings")
+		}
+		buf.WriteString(toStrUnsafe(o).Value())
+		return nil
+	})
+	if raised != nil {
+		return nil, raised
+	}
+	file := toFileUnsafe(args[0])
+	file.mutex.Lock()
+	defer file.mutex.Unlock()
+	if !file.open {
+		return nil, f.RaiseType(ValueErrorType, "I/O operation on closed file")
+	}
+	if err := file.syncPosition(); err != nil {
+		return nil, f.RaiseType(IOErrorType, err.Error())
+	}
+	if _, err := file.file.Write(buf.Bytes()); err != nil {
+		return nil, f.RaiseType(IOErrorType, err.Error())
+	}
+	return None, nil
+}
+
 func initFileType(dict map[string]*Object) {
 	// TODO: Make enter/exit into slots.
 	dict["__enter__"] = newBuiltinFunction("__enter__", fileEnter).ToObject()
 	dict["__exit__"] = newBuiltinFunction("__exit__", fileExit).ToObject()
 	dict["close"] = newBuiltinFunction("close", fileClose).ToObject()
+	dict["flush"] = newBuiltinFunction("flush", fileFlush).ToObject()
 	dict["read"] = newBuiltinFunction("read", fileRead).ToObject()
 	dict["readline"] = newBuiltinFunction("readline", fileReadLine).ToObject()
 	dict["readlines"] = newBuiltinFunction("readlines", fileReadLines).ToObject()
+	dict["seek"] = newBuiltinFunction("seek", fileSeek).ToObject()
+	dict["tell"] = newBuiltinFunction("tell", fileTell).ToObject()
 	dict["write"] = newBuiltinFunction("write", fileWrite).ToObject()
+	dict["writelines"] = newBuiltinFunction("writelines", fileWriteLines).ToObject()
 	FileType.slots.Init = &initSlot{fileInit}
 	FileType.slots.Iter = &unaryOpSlot{fileIter}
 	FileType.slots.Next = &unaryOpSlot{fileNext}
@@ -51,10 +51,14 @@ func TestFileCloseExit(t *testing.T) {
 		// This puts the file into an invalid state since Grumpy thinks
 		// it's open even though the underlying file was closed.
 		closedFile.file.Close()
+		closedFileCloseError := closedFile.file.Close()
+		openFile := f.open("r")
 		cases := []invokeTestCase{
 			{args: wrapArgs(newObject(FileType)), want: None},
-			{args: wrapArgs(f.open("r")), want: None},
-			{args: wrapArgs(closedFile), wantExc: mustCreateException(IOErrorType, "invalid argument")},
+			{args: wrapArgs(openFile), want: None},
+			// Closing an already closed file is a no-op.
+			{args: wrapArgs(openFile), want: None},
+			{args: wrapArgs(closedFile), wantExc: mustCreateException(IOErrorType, closedFileCloseError.Error())},
 		}
 		for _, cas := range cases {
 			if err := runInvokeMethodTestCase(FileType, method, &cas); err != "" {
@@ -122,17 +126,25 @@ func TestFileNext(t *testing.T) {
 func TestFileRead(t *testing.T) {
 	f := newTestFile("foo\nbar")
 	defer f.cleanup()
+	files := makeTestFiles()
+	defer files.cleanup()
 	closedFile := f.open("r")
 	closedFile.file.Close()
 	_, closedFileReadError := closedFile.file.Read(make([]byte, 10))
+	partialReadFile := files[6].open("rU")
+	partialReadFile.readLine(-1)
 	cases := []invokeTestCase{
 		{args: wrapArgs(f.open("r")), want: NewStr("foo\nbar").ToObject()},
 		{args: wrapArgs(f.open("r"), 3), want: NewStr("foo").ToObject()},
 		{args: wrapArgs(f.open("r"), 1000), want: NewStr("foo\nbar").ToObject()},
+		{args: wrapArgs(files[6].open("r")), want: NewStr("foo\r\nbar\rbaz\nqux\r\rquux\r").ToObject()},
+		{args: wrapArgs(files[6].open("rU")), want: NewStr("foo\nbar\nbaz\nqux\n\nquux\n").ToObject()},
+		{args: wrapArgs(files[6].open("U"), 8), want: NewStr("foo\nbar\n").ToObject()},
+		{args: wrapArgs(partialReadFile, 3), want: NewStr("bar").ToObject()},
 		{args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "unbound method read() must be called with file instance as first argument (got nothing instead)")},
 		{args: wrapArgs(closedFile), wantExc: mustCreateException(IOErrorType, closedFileReadError.Error())},
 		{args: wrapArgs(newObject(FileType)), wantExc: mustCreateException(ValueErrorType, "I/O operation on closed file")},
-		{args: wrapArgs(newObject(FileType), "abc"), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 10: abc")},
+		{args: wrapArgs(newObject(FileType), "abc"), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 10: 'abc'")},
 		{args: wrapArgs(newObject(FileType), 123, 456), wantExc: mustCreateException(TypeErrorType, "'read' of 'file' requires 2 arguments")},
 	}
 	for _, cas := range cases {
@@ -175,7 +187,7 @@ func TestFileReadLine(t *testing.T) {
 		{args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "unbound method readline() must be called with file instance as first argument (got nothing instead)")},
 		{args: wrapArgs(closedFile), wantExc: mustCreateException(IOErrorType, closedFileReadError.Error())},
 		{args: wrapArgs(newObject(FileType)), wantExc: mustCreateException(ValueErrorType, "I/O operation on closed file")},
-		{args: wrapArgs(newObject(FileType), "abc"), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 10: abc")},
+		{args: wrapArgs(newObject(FileType), "abc"), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 10: 'abc'")},
 		{args: wrapArgs(newObject(FileType), 123, 456), wantExc: mustCreateException(TypeErrorType, "'readline' of 'file' requires 2 arguments")},
 	}
 	for _, cas := range cases {
@@ -215,10 +227,11 @@ func TestFileReadLines(t *testing.T) {
 		// number of bytes when possible. Check that the trailing \n
 		// does not count toward the bytes read.
 		{args: wrapArgs(partialReadFile, 3), want: newTestList("bar\n").ToObject()},
+		{args: wrapArgs(files[6].open("rU")), want: newTestList("foo\n", "bar\n", "baz\n", "qux\n", "\n", "quux\n").ToObject()},
 		{args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "unbound method readlines() must be called with file instance as first argument (got nothing instead)")},
 		{args: wrapArgs(closedFile), wantExc: mustCreateException(IOErrorType, closedFileReadError.Error())},
 		{args: wrapArgs(newObject(FileType)), wantExc: mustCreateException(ValueErrorType, "I/O operation on closed file")},
-		{args: wrapArgs(newObject(FileType), "abc"), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 10: abc")},
+		{args: wrapArgs(newObject(FileType), "abc"), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 10: 'abc'")},
 		{args: wrapArgs(newObject(FileType), 123, 456), wantExc: mustCreateException(TypeErrorType, "'readlines' of 'file' requires 2 arguments")},
 	}
 	for _, cas := range cases {
@@ -228,6 +241,108 @@ func TestFileReadLines(t *testing.T) {
 	}
 }
 
+func TestFileReadAfterEOF(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, file *File, size int) (*Tuple, *BaseException) {
+		o := file.ToObject()
+		first, raised := fileRead(f, Args{o, NewInt(size).ToObject()}, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		second, raised := fileRead(f, Args{o, NewInt(size).ToObject()}, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return NewTuple2(first, second), nil
+	})
+	f := newTestFile("foo\nbar")
+	defer f.cleanup()
+	cases := []invokeTestCase{
+		{args: wrapArgs(f.open("r"), 4), want: newTestTuple("foo\n", "bar").ToObject()},
+		{args: wrapArgs(f.open("r"), 7), want: newTestTuple("foo\nbar", "").ToObject()},
+		{args: wrapArgs(f.open("r"), 100), want: newTestTuple("foo\nbar", "").ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFileSeekTell(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, file *File, args ...*Object) (*Tuple, *BaseException) {
+		o := file.ToObject()
+		// Consume a line first so that the reader has buffered data.
+		if _, raised := fileReadLine(f, Args{o}, nil); raised != nil {
+			return nil, raised
+		}
+		if _, raised := fileSeek(f, append(Args{o}, args...), nil); raised != nil {
+			return nil, raised
+		}
+		pos, raised := fileTell(f, Args{o}, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		rest, raised := fileRead(f, Args{o}, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return NewTuple2(pos, rest), nil
+	})
+	f := newTestFile("foo\nbar\nbaz")
+	defer f.cleanup()
+	closedFile := f.open("r")
+	mustNotRaise(fileClose(NewRootFrame(), []*Object{closedFile.ToObject()}, nil))
+	cases := []invokeTestCase{
+		{args: wrapArgs(f.open("r"), 0), want: newTestTuple(0, "foo\nbar\nbaz").ToObject()},
+		{args: wrapArgs(f.open("r"), 8), want: newTestTuple(8, "baz").ToObject()},
+		{args: wrapArgs(f.open("r"), 0, 1), want: newTestTuple(4, "bar\nbaz").ToObject()},
+		{args: wrapArgs(f.open("r"), -2, 1), want: newTestTuple(2, "o\nbar\nbaz").ToObject()},
+		{args: wrapArgs(f.open("r"), -3, 2), want: newTestTuple(8, "baz").ToObject()},
+		{args: wrapArgs(f.open("r"), 100), want: newTestTuple(100, "").ToObject()},
+		{args: wrapArgs(f.open("r"), "foo"), wantExc: mustCreateException(TypeErrorType, "'seek' requires a 'int' object but received a 'str'")},
+		{args: wrapArgs(f.open("r"), 0, 3), wantExc: mustCreateException(IOErrorType, "[Errno 22] Invalid argument")},
+		{args: wrapArgs(f.open("r"), 0, -1), wantExc: mustCreateException(IOErrorType, "[Errno 22] Invalid argument")},
+		{args: wrapArgs(closedFile, 0), wantExc: mustCreateException(ValueErrorType, "I/O operation on closed file")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFileTellUniversalNewline(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, file *File) (*Tuple, *BaseException) {
+		o := file.ToObject()
+		line, raised := fileReadLine(f, Args{o}, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		pos, raised := fileTell(f, Args{o}, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		rest, raised := fileRead(f, Args{o}, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return NewTuple3(line, pos, rest), nil
+	})
+	files := makeTestFiles()
+	defer files.cleanup()
+	cases := []invokeTestCase{
+		{args: wrapArgs(files[3].open("rU")), want: newTestTuple("foo\n", 5, "").ToObject()},
+		{args: wrapArgs(files[4].open("rU")), want: newTestTuple("foo\n", 4, "bar").ToObject()},
+		{args: wrapArgs(files[5].open("rU")), want: newTestTuple("foo\n", 5, "bar\nbaz").ToObject()},
+		{args: wrapArgs(files[5].open("r")), want: newTestTuple("foo\r\n", 5, "bar\r\nbaz").ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestFileStrRepr(t *testing.T) {
 	fun := newBuiltinFunction("TestFileStrRepr", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 		if raised := checkFunctionArgs(f, "TestFileStrRepr", args, ObjectType, StrType); raised != nil {
@@ -330,6 +445,46 @@ func TestFileWrite(t *testing.T) {
 	}
 }
 
+func TestFileWriteLines(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, path, mode string, lines *Object) (*Object, *BaseException) {
+		file, raised := FileType.Call(f, wrapArgs(path, mode), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		if _, raised := fileWriteLines(f, Args{file, lines}, nil); raised != nil {
+			return nil, raised
+		}
+		if _, raised := fileClose(f, Args{file}, nil); raised != nil {
+			return nil, raised
+		}
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, f.RaiseType(RuntimeErrorType, fmt.Sprintf("error reading file: %s", err.Error()))
+		}
+		return NewStr(string(contents)).ToObject(), nil
+	})
+	f := newTestFile("foo\nbar")
+	defer f.cleanup()
+	closedFile := f.open("r")
+	mustNotRaise(fileClose(NewRootFrame(), []*Object{closedFile.ToObject()}, nil))
+	cases := []invokeTestCase{
+		{args: wrapArgs(f.path, "w", newTestList("foo\n", "bar\n")), want: NewStr("foo\nbar\n").ToObject()},
+		{args: wrapArgs(f.path, "a", newTestTuple("baz", "qux")), want: NewStr("foo\nbar\nbazqux").ToObject()},
+		{args: wrapArgs(f.path, "w", NewTuple()), want: NewStr("").ToObject()},
+		{args: wrapArgs(f.path, "a", newTestList("foo", 123)), wantExc: mustCreateException(TypeErrorType, "writelines() argument must be a sequence of strings")},
+		{args: wrapArgs(f.path, "a", 123), wantExc: mustCreateException(TypeErrorType, "'int' object is not iterable")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+	cas := invokeTestCase{args: wrapArgs(closedFile, NewList()), wantExc: mustCreateException(ValueErrorType, "I/O operation on closed file")}
+	if err := runInvokeMethodTestCase(FileType, "writelines", &cas); err != "" {
+		t.Error(err)
+	}
+}
+
 type testFile struct {
 	path  string
 	files []*File
@@ -377,6 +532,7 @@ func makeTestFiles() testFileSlice {
 		newTestFile("foo\r\n"),
 		newTestFile("foo\rbar"),
 		newTestFile("foo\r\nbar\r\nbaz"),
+		newTestFile("foo\r\nbar\rbaz\nqux\r\rquux\r"),
 	}
 }
 
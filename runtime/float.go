@@ -20,6 +20,7 @@ import (
 	"math/big"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"unsafe"
 )
@@ -62,6 +63,28 @@ func floatAdd(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return floatArithmeticOp(f, "__add__", v, w, func(v, w float64) float64 { return v + w })
 }
 
+func floatAsIntegerRatio(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "as_integer_ratio", args, FloatType); raised != nil {
+		return nil, raised
+	}
+	val := toFloatUnsafe(args[0]).Value()
+	if math.IsInf(val, 0) {
+		return nil, f.RaiseType(OverflowErrorType, "Cannot pass infinity to float.as_integer_ratio.")
+	}
+	if math.IsNaN(val) {
+		return nil, f.RaiseType(ValueErrorType, "Cannot pass NaN to float.as_integer_ratio.")
+	}
+	toInt := func(i *big.Int) *Object {
+		if numInIntRange(i) {
+			return NewInt(int(i.Int64())).ToObject()
+		}
+		return NewLong(i).ToObject()
+	}
+	// SetFloat64 is exact and yields the ratio in lowest terms.
+	r := new(big.Rat).SetFloat64(val)
+	return NewTuple2(toInt(r.Num()), toInt(r.Denom())).ToObject(), nil
+}
+
 func floatDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return floatDivModOp(f, "__div__", v, w, func(v, w float64) (float64, bool) {
 		if w == 0.0 {
@@ -73,11 +96,7 @@ func floatDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
 
 func floatDivMod(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return floatDivAndModOp(f, "__divmod__", v, w, func(v, w float64) (float64, float64, bool) {
-		m, r := floatModFunc(v, w)
-		if !r {
-			return 0, 0, false
-		}
-		return math.Floor(v / w), m, true
+		return floatDivModFunc(v, w)
 	})
 }
 
@@ -85,17 +104,87 @@ func floatEq(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return floatCompare(toFloatUnsafe(v), w, False, True, False), nil
 }
 
+func floatCoerceMethod(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "__coerce__", args, FloatType, ObjectType); raised != nil {
+		return nil, raised
+	}
+	w := args[1]
+	if w.isInstance(FloatType) {
+		return NewTuple2(args[0], w).ToObject(), nil
+	}
+	floatW, ok := floatCoerce(w)
+	if !ok {
+		if math.IsInf(floatW, 0) {
+			return nil, f.RaiseType(OverflowErrorType, "long int too large to convert to float")
+		}
+		return NotImplemented, nil
+	}
+	return NewTuple2(args[0], NewFloat(floatW).ToObject()).ToObject(), nil
+}
+
+func floatConjugate(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "conjugate", args, FloatType); raised != nil {
+		return nil, raised
+	}
+	if args[0].typ == FloatType {
+		return args[0], nil
+	}
+	return NewFloat(toFloatUnsafe(args[0]).Value()).ToObject(), nil
+}
+
 func floatFloat(f *Frame, o *Object) (*Object, *BaseException) {
 	return o, nil
 }
 
 func floatFloorDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
-	return floatDivModOp(f, "__floordiv__", v, w, func(v, w float64) (float64, bool) {
-		if w == 0.0 {
-			return 0, false
+	return floatDivModOp(f, "__floordiv__", v, w, floatFloorDivFunc)
+}
+
+// floatFromHex implements the float.fromhex classmethod, accepting the
+// strings produced by float.hex as well as "inf" and "nan".
+func floatFromHex(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "fromhex", args, TypeType, StrType); raised != nil {
+		return nil, raised
+	}
+	s := strings.TrimSpace(toStrUnsafe(args[1]).Value())
+	sign := ""
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		sign, s = s[:1], s[1:]
+	}
+	var val float64
+	switch lower := strings.ToLower(s); lower {
+	case "inf", "infinity":
+		val = math.Inf(1)
+	case "nan":
+		val = math.NaN()
+	default:
+		if strings.HasPrefix(lower, "0x") {
+			s = s[2:]
 		}
-		return math.Floor(v / w), true
-	})
+		// Unlike Go, Python doesn't require an exponent and doesn't
+		// allow underscores.
+		if strings.Contains(s, "_") {
+			return nil, f.RaiseType(ValueErrorType, "invalid hexadecimal floating-point string")
+		}
+		if !strings.ContainsAny(s, "pP") {
+			s += "p0"
+		}
+		var err error
+		if val, err = strconv.ParseFloat("0x"+s, 64); err != nil {
+			if err.(*strconv.NumError).Err == strconv.ErrRange {
+				return nil, f.RaiseType(OverflowErrorType, "hexadecimal value too large to represent as a float")
+			}
+			return nil, f.RaiseType(ValueErrorType, "invalid hexadecimal floating-point string")
+		}
+	}
+	if sign == "-" {
+		val = -val
+	}
+	result := NewFloat(val).ToObject()
+	if cls := toTypeUnsafe(args[0]); cls != FloatType {
+		return cls.Call(f, Args{result}, nil)
+	}
+	return result, nil
 }
 
 func floatGE(f *Frame, v, w *Object) (*Object, *BaseException) {
@@ -129,6 +218,38 @@ func floatHash(f *Frame, o *Object) (*Object, *BaseException) {
 	return h.ToObject(), nil
 }
 
+// floatHex returns the C99 hexadecimal representation of a float, e.g.
+// "0x1.c000000000000p+1" for 3.5.
+func floatHex(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "hex", args, FloatType); raised != nil {
+		return nil, raised
+	}
+	val := toFloatUnsafe(args[0]).Value()
+	sign := ""
+	if math.Signbit(val) {
+		sign = "-"
+	}
+	if math.IsNaN(val) {
+		return NewStr("nan").ToObject(), nil
+	}
+	if math.IsInf(val, 0) {
+		return NewStr(sign + "inf").ToObject(), nil
+	}
+	if val == 0 {
+		return NewStr(sign + "0x0.0p+0").ToObject(), nil
+	}
+	bits := math.Float64bits(val)
+	exp := int(bits>>52) & 0x7ff
+	mantissa := bits & (1<<52 - 1)
+	lead := 1
+	if exp == 0 {
+		// Subnormals have no implicit leading bit.
+		lead, exp = 0, 1
+	}
+	s := fmt.Sprintf("%s0x%d.%013xp%+d", sign, lead, mantissa, exp-1023)
+	return NewStr(s).ToObject(), nil
+}
+
 func floatInt(f *Frame, o *Object) (*Object, *BaseException) {
 	val := toFloatUnsafe(o).Value()
 	if math.IsInf(val, 0) {
@@ -157,6 +278,21 @@ func floatLong(f *Frame, o *Object) (*Object, *BaseException) {
 	return NewLong(i).ToObject(), nil
 }
 
+func floatGetImag(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_get_imag", args, FloatType); raised != nil {
+		return nil, raised
+	}
+	return NewFloat(0).ToObject(), nil
+}
+
+func floatIsInteger(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "is_integer", args, FloatType); raised != nil {
+		return nil, raised
+	}
+	val := toFloatUnsafe(args[0]).Value()
+	return GetBool(!math.IsInf(val, 0) && val == math.Trunc(val)).ToObject(), nil
+}
+
 func floatLE(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return floatCompare(toFloatUnsafe(v), w, True, True, False), nil
 }
@@ -255,11 +391,7 @@ func floatRDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
 
 func floatRDivMod(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return floatDivAndModOp(f, "__rdivmod__", v, w, func(v, w float64) (float64, float64, bool) {
-		m, r := floatModFunc(w, v)
-		if !r {
-			return 0, 0, false
-		}
-		return w / v, m, true
+		return floatDivModFunc(w, v)
 	})
 }
 
@@ -269,10 +401,7 @@ func floatRepr(f *Frame, o *Object) (*Object, *BaseException) {
 
 func floatRFloorDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return floatDivModOp(f, "__rfloordiv__", v, w, func(v, w float64) (float64, bool) {
-		if v == 0.0 {
-			return 0, false
-		}
-		return math.Floor(w / v), true
+		return floatFloorDivFunc(w, v)
 	})
 }
 
@@ -299,7 +428,15 @@ func floatSub(f *Frame, v, w *Object) (*Object, *BaseException) {
 }
 
 func initFloatType(dict map[string]*Object) {
+	dict["__coerce__"] = newBuiltinFunction("__coerce__", floatCoerceMethod).ToObject()
 	dict["__getnewargs__"] = newBuiltinFunction("__getnewargs__", floatGetNewArgs).ToObject()
+	dict["as_integer_ratio"] = newBuiltinFunction("as_integer_ratio", floatAsIntegerRatio).ToObject()
+	dict["conjugate"] = newBuiltinFunction("conjugate", floatConjugate).ToObject()
+	dict["fromhex"] = newClassMethod(newBuiltinFunction("fromhex", floatFromHex).ToObject()).ToObject()
+	dict["hex"] = newBuiltinFunction("hex", floatHex).ToObject()
+	dict["imag"] = newProperty(newBuiltinFunction("_get_imag", floatGetImag).ToObject(), nil, nil).ToObject()
+	dict["is_integer"] = newBuiltinFunction("is_integer", floatIsInteger).ToObject()
+	dict["real"] = newProperty(newBuiltinFunction("_get_real", floatConjugate).ToObject(), nil, nil).ToObject()
 	FloatType.slots.Abs = &unaryOpSlot{floatAbs}
 	FloatType.slots.Add = &binaryOpSlot{floatAdd}
 	FloatType.slots.Div = &binaryOpSlot{floatDiv}
@@ -332,7 +469,9 @@ func initFloatType(dict map[string]*Object) {
 	FloatType.slots.RMul = &binaryOpSlot{floatRMul}
 	FloatType.slots.RPow = &binaryOpSlot{floatRPow}
 	FloatType.slots.RSub = &binaryOpSlot{floatRSub}
+	FloatType.slots.RTrueDiv = &binaryOpSlot{floatRDiv}
 	FloatType.slots.Sub = &binaryOpSlot{floatSub}
+	FloatType.slots.TrueDiv = &binaryOpSlot{floatDiv}
 }
 
 func floatArithmeticOp(f *Frame, method string, v, w *Object, fun func(v, w float64) float64) (*Object, *BaseException) {
@@ -449,12 +588,9 @@ func hashFloat(v float64) int {
 
 	_, fracPart := math.Modf(v)
 	if fracPart == 0.0 {
+		// Integral values hash like the equivalent int or long.
 		i := big.Int{}
 		big.NewFloat(v).Int(&i)
-		if numInIntRange(&i) {
-			return int(i.Int64())
-		}
-		// TODO: hashBigInt() is not yet matched that of cpython or pypy.
 		return hashBigInt(&i)
 	}
 
@@ -462,8 +598,38 @@ func hashFloat(v float64) int {
 	v *= 2147483648.0
 	hiPart := int(v)
 	v = (v - float64(hiPart)) * 2147483648.0
-	x := int(hiPart + int(v) + (expo << 15))
-	return x
+	return hashInt(hiPart + int(v) + (expo << 15))
+}
+
+func floatDivModFunc(v, w float64) (float64, float64, bool) {
+	div, ok := floatFloorDivFunc(v, w)
+	if !ok {
+		return 0, 0, false
+	}
+	mod, _ := floatModFunc(v, w)
+	return div, mod, true
+}
+
+// floatFloorDivFunc computes v // w the way CPython's float_divmod does:
+// rather than flooring v / w, which may round up across an integer boundary
+// (e.g. 1 // 0.1), the quotient is derived from the exact remainder.
+func floatFloorDivFunc(v, w float64) (float64, bool) {
+	if w == 0.0 {
+		return 0, false
+	}
+	mod := math.Mod(v, w)
+	div := (v - mod) / w
+	if mod != 0 && (w < 0) != (mod < 0) {
+		div -= 1.0
+	}
+	if div == 0 {
+		return math.Copysign(0, v/w), true
+	}
+	floorDiv := math.Floor(div)
+	if div-floorDiv > 0.5 {
+		floorDiv += 1.0
+	}
+	return floorDiv, true
 }
 
 func floatModFunc(v, w float64) (float64, bool) {
@@ -479,6 +645,9 @@ func floatModFunc(v, w float64) (float64, bool) {
 		// of the modulo result differs from that of the
 		// divisor.
 		x += w
+	} else if x == 0 {
+		// A zero result also takes the sign of the divisor.
+		x = math.Copysign(0, w)
 	}
 	return x, true
 }
@@ -64,12 +64,20 @@ func TestFloatArithmeticOps(t *testing.T) {
 		{FloorDiv, NewFloat(-12.5).ToObject(), NewInt(4).ToObject(), NewFloat(-4).ToObject(), nil},
 		{FloorDiv, NewInt(25).ToObject(), NewFloat(5).ToObject(), NewFloat(5.0).ToObject(), nil},
 		{FloorDiv, NewFloat(math.Inf(1)).ToObject(), NewFloat(math.Inf(1)).ToObject(), NewFloat(math.NaN()).ToObject(), nil},
-		{FloorDiv, NewFloat(math.Inf(-1)).ToObject(), NewInt(-20).ToObject(), NewFloat(math.Inf(1)).ToObject(), nil},
+		{FloorDiv, NewFloat(math.Inf(-1)).ToObject(), NewInt(-20).ToObject(), NewFloat(math.NaN()).ToObject(), nil},
 		{FloorDiv, NewInt(1).ToObject(), NewFloat(math.Inf(1)).ToObject(), NewFloat(0).ToObject(), nil},
+		{FloorDiv, NewInt(-1).ToObject(), NewFloat(math.Inf(1)).ToObject(), NewFloat(-1).ToObject(), nil},
+		{FloorDiv, NewInt(1).ToObject(), NewFloat(0.1).ToObject(), NewFloat(9).ToObject(), nil},
+		{FloorDiv, NewFloat(-7).ToObject(), NewInt(2).ToObject(), NewFloat(-4).ToObject(), nil},
+		{FloorDiv, NewInt(7).ToObject(), NewFloat(-2).ToObject(), NewFloat(-4).ToObject(), nil},
 		{FloorDiv, newObject(ObjectType), NewFloat(1.1).ToObject(), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for //: 'object' and 'float'")},
 		{FloorDiv, NewFloat(1.0).ToObject(), NewLong(bigLongNumber).ToObject(), nil, mustCreateException(OverflowErrorType, "long int too large to convert to float")},
 		{FloorDiv, True.ToObject(), NewFloat(0).ToObject(), nil, mustCreateException(ZeroDivisionErrorType, "float division or modulo by zero")},
 		{FloorDiv, NewFloat(math.Inf(1)).ToObject(), NewFloat(0).ToObject(), nil, mustCreateException(ZeroDivisionErrorType, "float division or modulo by zero")},
+		{Mod, NewFloat(7).ToObject(), NewFloat(3).ToObject(), NewFloat(1).ToObject(), nil},
+		{Mod, NewFloat(-7).ToObject(), NewFloat(3).ToObject(), NewFloat(2).ToObject(), nil},
+		{Mod, NewFloat(7).ToObject(), NewFloat(-3).ToObject(), NewFloat(-2).ToObject(), nil},
+		{Mod, NewFloat(-7).ToObject(), NewFloat(-3).ToObject(), NewFloat(-1).ToObject(), nil},
 		{Mod, NewFloat(50.5).ToObject(), NewInt(10).ToObject(), NewFloat(0.5).ToObject(), nil},
 		{Mod, NewFloat(50.5).ToObject(), NewFloat(-10).ToObject(), NewFloat(-9.5).ToObject(), nil},
 		{Mod, NewFloat(-20.2).ToObject(), NewFloat(40).ToObject(), NewFloat(19.8).ToObject(), nil},
@@ -96,6 +104,9 @@ func TestFloatArithmeticOps(t *testing.T) {
 		{Sub, True.ToObject(), NewFloat(1.5).ToObject(), NewFloat(-0.5).ToObject(), nil},
 		{Sub, NewFloat(1.0).ToObject(), NewList().ToObject(), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for -: 'float' and 'list'")},
 		{Sub, NewFloat(math.Inf(1)).ToObject(), NewFloat(math.Inf(1)).ToObject(), NewFloat(math.NaN()).ToObject(), nil},
+		{TrueDiv, NewFloat(12.5).ToObject(), NewFloat(4).ToObject(), NewFloat(3.125).ToObject(), nil},
+		{TrueDiv, NewInt(5).ToObject(), NewFloat(2).ToObject(), NewFloat(2.5).ToObject(), nil},
+		{TrueDiv, NewFloat(1.0).ToObject(), NewInt(0).ToObject(), nil, mustCreateException(ZeroDivisionErrorType, "float division or modulo by zero")},
 	}
 	for _, cas := range cases {
 		switch got, result := checkInvokeResult(wrapFuncForTest(cas.fun), []*Object{cas.v, cas.w}, cas.want, cas.wantExc); result {
@@ -111,6 +122,28 @@ func TestFloatArithmeticOps(t *testing.T) {
 	}
 }
 
+func TestFloatModZeroSign(t *testing.T) {
+	cases := []struct {
+		v, w     float64
+		wantSign bool
+	}{
+		{math.Copysign(0, -1), 3, false},
+		{0, -3, true},
+		{6, -3, true},
+		{-6, 3, false},
+	}
+	for _, cas := range cases {
+		got, raised := Mod(NewRootFrame(), NewFloat(cas.v).ToObject(), NewFloat(cas.w).ToObject())
+		if raised != nil {
+			t.Errorf("%v %% %v raised %v", cas.v, cas.w, raised)
+			continue
+		}
+		if x := toFloatUnsafe(got).Value(); x != 0 || math.Signbit(x) != cas.wantSign {
+			t.Errorf("%v %% %v = %v, want zero with sign bit %v", cas.v, cas.w, x, cas.wantSign)
+		}
+	}
+}
+
 func TestFloatDivMod(t *testing.T) {
 	cases := []invokeTestCase{
 		{args: wrapArgs(12.5, 4.0), want: NewTuple2(NewFloat(3).ToObject(), NewFloat(0.5).ToObject()).ToObject()},
@@ -119,8 +152,14 @@ func TestFloatDivMod(t *testing.T) {
 		{args: wrapArgs(-20.2, 40.0), want: NewTuple2(NewFloat(-1).ToObject(), NewFloat(19.8).ToObject()).ToObject()},
 		{args: wrapArgs(math.Inf(1), math.Inf(1)), want: NewTuple2(NewFloat(math.NaN()).ToObject(), NewFloat(math.NaN()).ToObject()).ToObject()},
 		{args: wrapArgs(math.Inf(1), math.Inf(-1)), want: NewTuple2(NewFloat(math.NaN()).ToObject(), NewFloat(math.NaN()).ToObject()).ToObject()},
-		{args: wrapArgs(math.Inf(-1), -20.0), want: NewTuple2(NewFloat(math.Inf(1)).ToObject(), NewFloat(math.NaN()).ToObject()).ToObject()},
+		{args: wrapArgs(math.Inf(-1), -20.0), want: NewTuple2(NewFloat(math.NaN()).ToObject(), NewFloat(math.NaN()).ToObject()).ToObject()},
 		{args: wrapArgs(1, math.Inf(1)), want: NewTuple2(NewFloat(0).ToObject(), NewFloat(1).ToObject()).ToObject()},
+		{args: wrapArgs(-1.0, math.Inf(1)), want: NewTuple2(NewFloat(-1).ToObject(), NewFloat(math.Inf(1)).ToObject()).ToObject()},
+		{args: wrapArgs(7.5, 2.0), want: NewTuple2(NewFloat(3).ToObject(), NewFloat(1.5).ToObject()).ToObject()},
+		{args: wrapArgs(7.5, -2.0), want: NewTuple2(NewFloat(-4).ToObject(), NewFloat(-0.5).ToObject()).ToObject()},
+		{args: wrapArgs(-7.5, -2.0), want: NewTuple2(NewFloat(3).ToObject(), NewFloat(-1.5).ToObject()).ToObject()},
+		{args: wrapArgs(1.0, 0.1), want: NewTuple2(NewFloat(9).ToObject(), NewFloat(0.09999999999999995).ToObject()).ToObject()},
+		{args: wrapArgs(7, 2.5), want: NewTuple2(NewFloat(2).ToObject(), NewFloat(2).ToObject()).ToObject()},
 		{args: wrapArgs(newObject(ObjectType), 1.1), wantExc: mustCreateException(TypeErrorType, "unsupported operand type(s) for divmod(): 'object' and 'float'")},
 		{args: wrapArgs(True.ToObject(), 0.0), wantExc: mustCreateException(ZeroDivisionErrorType, "float division or modulo by zero")},
 		{args: wrapArgs(math.Inf(1), 0.0), wantExc: mustCreateException(ZeroDivisionErrorType, "float division or modulo by zero")},
@@ -153,6 +192,24 @@ func isNaNTupleFloat(got, want *Object) bool {
 	return false
 }
 
+func TestFloatAsIntegerRatio(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(0.25), want: newTestTuple(1, 4).ToObject()},
+		{args: wrapArgs(-1.5), want: newTestTuple(-3, 2).ToObject()},
+		{args: wrapArgs(0.0), want: newTestTuple(0, 1).ToObject()},
+		{args: wrapArgs(0.1), want: newTestTuple(3602879701896397, 36028797018963968).ToObject()},
+		{args: wrapArgs(1e20), want: newTestTuple(NewLong(new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil)).ToObject(), 1).ToObject()},
+		{args: wrapArgs(math.Inf(1)), wantExc: mustCreateException(OverflowErrorType, "Cannot pass infinity to float.as_integer_ratio.")},
+		{args: wrapArgs(math.NaN()), wantExc: mustCreateException(ValueErrorType, "Cannot pass NaN to float.as_integer_ratio.")},
+		{args: wrapArgs(1), wantExc: mustCreateException(TypeErrorType, "unbound method as_integer_ratio() must be called with float instance as first argument (got int instance instead)")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(FloatType, "as_integer_ratio", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestFloatCompare(t *testing.T) {
 	cases := []invokeTestCase{
 		{args: wrapArgs(1.0, 1.0), want: compareAllResultEq},
@@ -209,6 +266,11 @@ func TestFloatHash(t *testing.T) {
 		{args: wrapArgs(NewFloat(3.14)), want: NewInt(3146129223).ToObject()},
 		{args: wrapArgs(NewFloat(42.0)), want: NewInt(42).ToObject()},
 		{args: wrapArgs(NewFloat(42.125)), want: NewInt(1413677056).ToObject()},
+		{args: wrapArgs(NewFloat(-1.0)), want: NewInt(-2).ToObject()},
+		{args: wrapArgs(NewFloat(-0.5)), want: NewInt(-1073741824).ToObject()},
+		{args: wrapArgs(NewFloat(1e20)), want: NewInt(7766279631452241925).ToObject()},
+		{args: wrapArgs(NewFloat(-1e20)), want: NewInt(-7766279631452241925).ToObject()},
+		{args: wrapArgs(NewFloat(1e100)), want: NewInt(-5970202163673034167).ToObject()},
 		{args: wrapArgs(NewFloat(math.Inf(1))), want: NewInt(314159).ToObject()},
 		{args: wrapArgs(NewFloat(math.Inf(-1))), want: NewInt(-271828).ToObject()},
 		{args: wrapArgs(NewFloat(math.NaN())), want: NewInt(0).ToObject()},
@@ -219,6 +281,91 @@ func TestFloatHash(t *testing.T) {
 		}
 	}
 }
+func TestFloatHex(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(3.5), want: NewStr("0x1.c000000000000p+1").ToObject()},
+		{args: wrapArgs(-1.5), want: NewStr("-0x1.8000000000000p+0").ToObject()},
+		{args: wrapArgs(0.0), want: NewStr("0x0.0p+0").ToObject()},
+		{args: wrapArgs(math.Copysign(0, -1)), want: NewStr("-0x0.0p+0").ToObject()},
+		{args: wrapArgs(5e-324), want: NewStr("0x0.0000000000001p-1022").ToObject()},
+		{args: wrapArgs(math.MaxFloat64), want: NewStr("0x1.fffffffffffffp+1023").ToObject()},
+		{args: wrapArgs(math.Inf(1)), want: NewStr("inf").ToObject()},
+		{args: wrapArgs(math.Inf(-1)), want: NewStr("-inf").ToObject()},
+		{args: wrapArgs(math.NaN()), want: NewStr("nan").ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(FloatType, "hex", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFloatFromHex(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs("0x1.c000000000000p+1"), want: NewFloat(3.5).ToObject()},
+		{args: wrapArgs(" -0X1.8P1 "), want: NewFloat(-3).ToObject()},
+		{args: wrapArgs("a.b"), want: NewFloat(10.6875).ToObject()},
+		{args: wrapArgs("0x1p-1074"), want: NewFloat(5e-324).ToObject()},
+		{args: wrapArgs("-Infinity"), want: NewFloat(math.Inf(-1)).ToObject()},
+		{args: wrapArgs("1p"), wantExc: mustCreateException(ValueErrorType, "invalid hexadecimal floating-point string")},
+		{args: wrapArgs("1_0"), wantExc: mustCreateException(ValueErrorType, "invalid hexadecimal floating-point string")},
+		{args: wrapArgs(""), wantExc: mustCreateException(ValueErrorType, "invalid hexadecimal floating-point string")},
+		{args: wrapArgs("0x1.fffffffffffff8p1023"), wantExc: mustCreateException(OverflowErrorType, "hexadecimal value too large to represent as a float")},
+		{args: wrapArgs(1), wantExc: mustCreateException(TypeErrorType, "'fromhex' requires a 'str' object but received a 'int'")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(FloatType, "fromhex", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFloatIsInteger(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(2.0), want: True.ToObject()},
+		{args: wrapArgs(-1e300), want: True.ToObject()},
+		{args: wrapArgs(2.5), want: False.ToObject()},
+		{args: wrapArgs(math.Inf(1)), want: False.ToObject()},
+		{args: wrapArgs(math.NaN()), want: False.ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(FloatType, "is_integer", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFloatRealImag(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, o *Object) (*Object, *BaseException) {
+		real, raised := GetAttr(f, o, NewStr("real"), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		imag, raised := GetAttr(f, o, NewStr("imag"), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		method, raised := GetAttr(f, o, NewStr("conjugate"), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		conjugate, raised := method.Call(f, nil, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return newTestTuple(real, imag, conjugate).ToObject(), nil
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(1.5), want: newTestTuple(1.5, 0.0, 1.5).ToObject()},
+		{args: wrapArgs(-2.0), want: newTestTuple(-2.0, 0.0, -2.0).ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestFloatIsTrue(t *testing.T) {
 	cases := []invokeTestCase{
 		{args: wrapArgs(0.0), want: False.ToObject()},
@@ -40,6 +40,10 @@ type Frame struct {
 	lineno      int   `attr:"f_lineno"`
 	code        *Code `attr:"f_code"`
 	taken       bool
+	// locals produces the dict returned by the locals() builtin for
+	// function and class bodies. It is nil at module level, where
+	// locals() returns globals.
+	locals func(*Frame) (*Dict, *BaseException)
 }
 
 // NewRootFrame creates a Frame that is the bottom of a new stack.
@@ -73,6 +77,7 @@ func (f *Frame) release() {
 		f.dict = nil
 		f.globals = nil
 		f.code = nil
+		f.locals = nil
 	} else if f.back != nil {
 		f.back.taken = true
 	}
@@ -97,6 +102,20 @@ func (f *Frame) Globals() *Dict {
 	return f.globals
 }
 
+// InheritLocals makes the locals() builtin within f return the local namespace
+// of the frame that called f.
+func (f *Frame) InheritLocals() {
+	if f.back != nil {
+		f.locals = f.back.locals
+	}
+}
+
+// SetLocals sets the function that the locals() builtin calls to build the
+// local namespace of f.
+func (f *Frame) SetLocals(locals func(*Frame) (*Dict, *BaseException)) {
+	f.locals = locals
+}
+
 // ToObject upcasts f to an Object.
 func (f *Frame) ToObject() *Object {
 	return &f.Object
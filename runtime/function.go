@@ -78,9 +78,11 @@ type Func func(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException)
 type Function struct {
 	Object
 	fn      Func
-	name    string `attr:"__name__"`
-	code    *Code  `attr:"func_code"`
-	globals *Dict  `attr:"func_globals"`
+	name    string
+	code    *Code `attr:"func_code"`
+	globals *Dict `attr:"func_globals"`
+	module  *Object
+	doc     *Object
 }
 
 // NewFunction creates a function object corresponding to a Python function
@@ -89,7 +91,7 @@ type Function struct {
 // number of arguments are provided, populating *args and **kwargs if
 // necessary, etc.
 func NewFunction(c *Code, globals *Dict) *Function {
-	return &Function{Object{typ: FunctionType, dict: NewDict()}, nil, c.name, c, globals}
+	return &Function{Object{typ: FunctionType, dict: NewDict()}, nil, c.name, c, globals, nil, nil}
 }
 
 // newBuiltinFunction returns a function object with the given name that
@@ -130,7 +132,141 @@ func functionRepr(_ *Frame, o *Object) (*Object, *BaseException) {
 	return NewStr(fmt.Sprintf("<%s %s at %p>", fun.typ.Name(), fun.Name(), fun)).ToObject(), nil
 }
 
-func initFunctionType(map[string]*Object) {
+func functionDeleteDict(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_delete_dict", args, FunctionType); raised != nil {
+		return nil, raised
+	}
+	return nil, f.RaiseType(TypeErrorType, "function's dictionary may not be deleted")
+}
+
+func functionDeleteDoc(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_delete_doc", args, FunctionType); raised != nil {
+		return nil, raised
+	}
+	toFunctionUnsafe(args[0]).doc = nil
+	return None, nil
+}
+
+func functionGetDefaults(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_get_defaults", args, FunctionType); raised != nil {
+		return nil, raised
+	}
+	code := toFunctionUnsafe(args[0]).code
+	if code == nil {
+		return None, nil
+	}
+	// Defaults are evaluated once when the function is defined and are
+	// stored on the parameters that follow the required ones.
+	spec := code.paramSpec
+	var defaults []*Object
+	for _, p := range spec.params[spec.minArgs:] {
+		defaults = append(defaults, p.Def)
+	}
+	if len(defaults) == 0 {
+		return None, nil
+	}
+	return NewTuple(defaults...).ToObject(), nil
+}
+
+func functionGetDict(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_get_dict", args, FunctionType); raised != nil {
+		return nil, raised
+	}
+	return args[0].dict.ToObject(), nil
+}
+
+func functionGetDoc(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_get_doc", args, FunctionType); raised != nil {
+		return nil, raised
+	}
+	if doc := toFunctionUnsafe(args[0]).doc; doc != nil {
+		return doc, nil
+	}
+	return None, nil
+}
+
+func functionGetModule(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_get_module", args, FunctionType); raised != nil {
+		return nil, raised
+	}
+	fun := toFunctionUnsafe(args[0])
+	if fun.module != nil {
+		return fun.module, nil
+	}
+	if fun.globals == nil {
+		return NewStr("__builtin__").ToObject(), nil
+	}
+	// Like CPython, default to the name of the module the function was
+	// defined in.
+	name, raised := fun.globals.GetItemString(f, "__name__")
+	if raised != nil {
+		return nil, raised
+	}
+	if name == nil {
+		return None, nil
+	}
+	return name, nil
+}
+
+func functionGetName(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_get_name", args, FunctionType); raised != nil {
+		return nil, raised
+	}
+	return NewStr(toFunctionUnsafe(args[0]).name).ToObject(), nil
+}
+
+func functionSetName(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_set_name", args, FunctionType, ObjectType); raised != nil {
+		return nil, raised
+	}
+	if !args[1].isInstance(StrType) {
+		return nil, f.RaiseType(TypeErrorType, "__name__ must be set to a string object")
+	}
+	toFunctionUnsafe(args[0]).name = toStrUnsafe(args[1]).Value()
+	return None, nil
+}
+
+func functionSetDict(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_set_dict", args, FunctionType, ObjectType); raised != nil {
+		return nil, raised
+	}
+	if !args[1].isInstance(DictType) {
+		return nil, f.RaiseType(TypeErrorType, "setting function's dictionary to a non-dict")
+	}
+	args[0].dict = toDictUnsafe(args[1])
+	return None, nil
+}
+
+func functionSetDoc(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_set_doc", args, FunctionType, ObjectType); raised != nil {
+		return nil, raised
+	}
+	toFunctionUnsafe(args[0]).doc = args[1]
+	return None, nil
+}
+
+func functionSetModule(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_set_module", args, FunctionType, ObjectType); raised != nil {
+		return nil, raised
+	}
+	toFunctionUnsafe(args[0]).module = args[1]
+	return None, nil
+}
+
+func initFunctionType(dict map[string]*Object) {
+	doc := newProperty(newBuiltinFunction("_get_doc", functionGetDoc).ToObject(), newBuiltinFunction("_set_doc", functionSetDoc).ToObject(), newBuiltinFunction("_delete_doc", functionDeleteDoc).ToObject()).ToObject()
+	funcDict := newProperty(newBuiltinFunction("_get_dict", functionGetDict).ToObject(), newBuiltinFunction("_set_dict", functionSetDict).ToObject(), newBuiltinFunction("_delete_dict", functionDeleteDict).ToObject()).ToObject()
+	name := newProperty(newBuiltinFunction("_get_name", functionGetName).ToObject(), newBuiltinFunction("_set_name", functionSetName).ToObject(), nil).ToObject()
+	defaults := newProperty(newBuiltinFunction("_get_defaults", functionGetDefaults).ToObject(), nil, nil).ToObject()
+	dict["__defaults__"] = defaults
+	dict["__dict__"] = funcDict
+	dict["__doc__"] = doc
+	dict["__module__"] = newProperty(newBuiltinFunction("_get_module", functionGetModule).ToObject(), newBuiltinFunction("_set_module", functionSetModule).ToObject(), nil).ToObject()
+	dict["__name__"] = name
+	dict["func_defaults"] = defaults
+	dict["func_dict"] = funcDict
+	dict["func_doc"] = doc
+	dict["func_name"] = name
 	FunctionType.flags &= ^(typeFlagInstantiable | typeFlagBasetype)
 	FunctionType.slots.Call = &callSlot{functionCall}
 	FunctionType.slots.Get = &getSlot{functionGet}
@@ -140,7 +276,7 @@ func initFunctionType(map[string]*Object) {
 // staticMethod represents Python 'staticmethod' objects.
 type staticMethod struct {
 	Object
-	callable *Object
+	callable *Object `attr:"__func__"`
 }
 
 func newStaticMethod(callable *Object) *staticMethod {
@@ -180,7 +316,7 @@ func initStaticMethodType(map[string]*Object) {
 // classMethod represents Python 'classmethod' objects.
 type classMethod struct {
 	Object
-	callable *Object
+	callable *Object `attr:"__func__"`
 }
 
 func newClassMethod(callable *Object) *classMethod {
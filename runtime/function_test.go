@@ -39,6 +39,92 @@ func TestFunctionCall(t *testing.T) {
 	}
 }
 
+func TestFunctionDefaults(t *testing.T) {
+	fn := func(*Frame, []*Object) (*Object, *BaseException) { return None, nil }
+	fun := wrapFuncForTest(func(f *Frame, o *Object) (*Object, *BaseException) {
+		return GetAttr(f, o, NewStr("func_defaults"), nil)
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(NewFunction(NewCode("foo", "foo.py", nil, 0, fn), nil)), want: None},
+		{args: wrapArgs(NewFunction(NewCode("foo", "foo.py", []Param{{"a", nil}}, CodeFlagVarArg, fn), nil)), want: None},
+		{args: wrapArgs(NewFunction(NewCode("foo", "foo.py", []Param{{"a", nil}, {"b", NewInt(1).ToObject()}, {"c", None}}, 0, fn), nil)), want: newTestTuple(1, None).ToObject()},
+		{args: wrapArgs(newBuiltinFunction("foo", func(*Frame, Args, KWArgs) (*Object, *BaseException) { return None, nil })), want: None},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFunctionDict(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, attr *Str, value *Object, del bool) (*Object, *BaseException) {
+		foo := newBuiltinFunction("foo", func(*Frame, Args, KWArgs) (*Object, *BaseException) { return None, nil }).ToObject()
+		if raised := SetAttr(f, foo, NewStr("bar"), NewInt(1).ToObject()); raised != nil {
+			return nil, raised
+		}
+		if value != None {
+			if raised := SetAttr(f, foo, attr, value); raised != nil {
+				return nil, raised
+			}
+		}
+		if del {
+			if raised := DelAttr(f, foo, attr); raised != nil {
+				return nil, raised
+			}
+		}
+		return GetAttr(f, foo, NewStr("__dict__"), nil)
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs("__dict__", None, false), want: newTestDict("bar", 1).ToObject()},
+		{args: wrapArgs("__dict__", newTestDict("baz", 2), false), want: newTestDict("baz", 2).ToObject()},
+		{args: wrapArgs("func_dict", NewDict(), false), want: NewDict().ToObject()},
+		{args: wrapArgs("__dict__", 123, false), wantExc: mustCreateException(TypeErrorType, "setting function's dictionary to a non-dict")},
+		{args: wrapArgs("__dict__", None, true), wantExc: mustCreateException(TypeErrorType, "function's dictionary may not be deleted")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFunctionDoc(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, attr *Str, doc *Object, del bool) (*Tuple, *BaseException) {
+		foo := newBuiltinFunction("foo", func(*Frame, Args, KWArgs) (*Object, *BaseException) { return None, nil }).ToObject()
+		if doc != None {
+			if raised := SetAttr(f, foo, attr, doc); raised != nil {
+				return nil, raised
+			}
+		}
+		if del {
+			if raised := DelAttr(f, foo, attr); raised != nil {
+				return nil, raised
+			}
+		}
+		doc, raised := GetAttr(f, foo, NewStr("__doc__"), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		funcDoc, raised := GetAttr(f, foo, NewStr("func_doc"), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return NewTuple2(doc, funcDoc), nil
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs("__doc__", None, false), want: newTestTuple(None, None).ToObject()},
+		{args: wrapArgs("__doc__", "bar", false), want: newTestTuple("bar", "bar").ToObject()},
+		{args: wrapArgs("func_doc", 123, false), want: newTestTuple(123, 123).ToObject()},
+		{args: wrapArgs("__doc__", "bar", true), want: newTestTuple(None, None).ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestFunctionGet(t *testing.T) {
 	appendMethod := mustNotRaise(GetAttr(NewRootFrame(), NewList().ToObject(), NewStr("append"), nil))
 	if !appendMethod.isInstance(MethodType) {
@@ -49,13 +135,64 @@ func TestFunctionGet(t *testing.T) {
 func TestFunctionName(t *testing.T) {
 	fun := newBuiltinFunction("TestFunctionName", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
 		foo := newBuiltinFunction("foo", func(*Frame, Args, KWArgs) (*Object, *BaseException) { return None, nil })
-		return GetAttr(f, foo.ToObject(), NewStr("__name__"), nil)
+		name, raised := GetAttr(f, foo.ToObject(), NewStr("__name__"), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		funcName, raised := GetAttr(f, foo.ToObject(), NewStr("func_name"), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return NewTuple2(name, funcName).ToObject(), nil
 	}).ToObject()
-	if err := runInvokeTestCase(fun, &invokeTestCase{want: NewStr("foo").ToObject()}); err != "" {
+	if err := runInvokeTestCase(fun, &invokeTestCase{want: newTestTuple("foo", "foo").ToObject()}); err != "" {
 		t.Error(err)
 	}
 }
 
+func TestFunctionModule(t *testing.T) {
+	code := NewCode("foo", "foo.py", nil, 0, func(*Frame, []*Object) (*Object, *BaseException) { return None, nil })
+	fun := wrapFuncForTest(func(f *Frame, fn *Function, module *Object) (*Object, *BaseException) {
+		if module != None {
+			if raised := SetAttr(f, fn.ToObject(), NewStr("__module__"), module); raised != nil {
+				return nil, raised
+			}
+		}
+		return GetAttr(f, fn.ToObject(), NewStr("__module__"), nil)
+	})
+	builtin := newBuiltinFunction("foo", func(*Frame, Args, KWArgs) (*Object, *BaseException) { return None, nil })
+	cases := []invokeTestCase{
+		{args: wrapArgs(NewFunction(code, newStringDict(map[string]*Object{"__name__": NewStr("foo").ToObject()})), None), want: NewStr("foo").ToObject()},
+		{args: wrapArgs(NewFunction(code, NewDict()), None), want: None},
+		{args: wrapArgs(NewFunction(code, NewDict()), "bar"), want: NewStr("bar").ToObject()},
+		{args: wrapArgs(builtin, None), want: NewStr("__builtin__").ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFunctionSetName(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, name *Object) (*Object, *BaseException) {
+		foo := newBuiltinFunction("foo", func(*Frame, Args, KWArgs) (*Object, *BaseException) { return None, nil })
+		if raised := SetAttr(f, foo.ToObject(), NewStr("__name__"), name); raised != nil {
+			return nil, raised
+		}
+		return NewStr(foo.Name()).ToObject(), nil
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs("bar"), want: NewStr("bar").ToObject()},
+		{args: wrapArgs(123), wantExc: mustCreateException(TypeErrorType, "__name__ must be set to a string object")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestFunctionStrRepr(t *testing.T) {
 	fn := func(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) { return nil, nil }
 	cases := []struct {
@@ -102,6 +239,26 @@ func TestStaticMethodGet(t *testing.T) {
 	}
 }
 
+func TestStaticMethodFunc(t *testing.T) {
+	fun := newBuiltinFunction("fun", func(*Frame, Args, KWArgs) (*Object, *BaseException) {
+		return None, nil
+	}).ToObject()
+	getFunc := wrapFuncForTest(func(f *Frame, o *Object) (*Object, *BaseException) {
+		return GetAttr(f, o, NewStr("__func__"), nil)
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(newStaticMethod(fun)), want: fun},
+		{args: wrapArgs(newClassMethod(fun)), want: fun},
+		{args: wrapArgs(newStaticMethod(nil)), want: None},
+		{args: wrapArgs(newClassMethod(nil)), want: None},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(getFunc, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestStaticMethodInit(t *testing.T) {
 	fun := wrapFuncForTest(func(f *Frame, args ...*Object) (*Object, *BaseException) {
 		m, raised := StaticMethodType.Call(f, args, nil)
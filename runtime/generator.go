@@ -15,6 +15,7 @@
 package grumpy
 
 import (
+	"fmt"
 	"reflect"
 	"sync"
 )
@@ -43,6 +44,8 @@ type Generator struct {
 }
 
 // NewGenerator returns a new Generator object that runs the given Block b.
+// When an exception is thrown into the generator, fn is called with a nil
+// value after f has been unwound to the innermost handler.
 func NewGenerator(f *Frame, fn func(*Object) (*Object, *BaseException)) *Generator {
 	f.taken = true // Claim the frame from being returned.
 
@@ -57,13 +60,21 @@ func toGeneratorUnsafe(o *Object) *Generator {
 	return (*Generator)(o.toPointer())
 }
 
-func (g *Generator) resume(f *Frame, sendValue *Object) (*Object, *BaseException) {
+// resume continues execution of g from the point at which it was suspended.
+// The yield expression there evaluates to sendValue, or if throwArgs is
+// non-nil, raises the exception they describe like the raise statement would.
+func (g *Generator) resume(f *Frame, sendValue *Object, throwArgs Args) (*Object, *BaseException) {
 	var raised *BaseException
 	g.mutex.Lock()
 	oldState := g.state
 	switch oldState {
 	case generatorStateCreated:
-		if sendValue != None {
+		if throwArgs != nil {
+			// The generator never ran so there is nothing that
+			// could handle the exception.
+			g.state = generatorStateDone
+			raised = f.Raise(throwArgs[0], throwArgs[1], throwArgs[2])
+		} else if sendValue != None {
 			raised = f.RaiseType(TypeErrorType, "can't send non-None value to a just-started generator")
 		} else {
 			g.state = generatorStateRunning
@@ -73,7 +84,11 @@ func (g *Generator) resume(f *Frame, sendValue *Object) (*Object, *BaseException
 	case generatorStateRunning:
 		raised = f.RaiseType(ValueErrorType, "generator already executing")
 	case generatorStateDone:
-		raised = f.Raise(StopIterationType.ToObject(), nil, nil)
+		if throwArgs != nil {
+			raised = f.Raise(throwArgs[0], throwArgs[1], throwArgs[2])
+		} else {
+			raised = f.Raise(StopIterationType.ToObject(), nil, nil)
+		}
 	}
 	g.mutex.Unlock()
 	// Concurrent attempts to transition to running state will raise here
@@ -83,7 +98,20 @@ func (g *Generator) resume(f *Frame, sendValue *Object) (*Object, *BaseException
 		return nil, raised
 	}
 	g.frame.pushFrame(f)
-	result, raised := g.fn(sendValue)
+	var result *Object
+	if throwArgs == nil {
+		result, raised = g.fn(sendValue)
+	} else {
+		// Raise the exception at the yield and unwind to the innermost
+		// handler. Only handlers remain on the checkpoint stack
+		// while the generator is suspended. The nil value signals fn
+		// to pick up the exception from the frame.
+		raised = g.frame.Raise(throwArgs[0], throwArgs[1], throwArgs[2])
+		g.frame.PopCheckpoint()
+		if g.frame.State() >= 0 {
+			result, raised = g.fn(nil)
+		}
+	}
 	g.mutex.Lock()
 	if result == nil && raised == nil {
 		raised = f.Raise(StopIterationType.ToObject(), nil, nil)
@@ -107,19 +135,66 @@ func generatorIter(f *Frame, o *Object) (*Object, *BaseException) {
 	return o, nil
 }
 
+func generatorClose(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "close", args, GeneratorType); raised != nil {
+		return nil, raised
+	}
+	// Preserve the exception being handled by the caller, if any, so
+	// that it can be re-raised after closing.
+	exc, tb := f.ExcInfo()
+	_, raised := toGeneratorUnsafe(args[0]).resume(f, None, Args{GeneratorExitType.ToObject(), None, None})
+	if raised == nil {
+		return nil, f.RaiseType(RuntimeErrorType, "generator ignored GeneratorExit")
+	}
+	if raised.isInstance(GeneratorExitType) || raised.isInstance(StopIterationType) {
+		f.RestoreExc(exc, tb)
+		return None, nil
+	}
+	return nil, raised
+}
+
 func generatorNext(f *Frame, o *Object) (*Object, *BaseException) {
-	return toGeneratorUnsafe(o).resume(f, None)
+	return toGeneratorUnsafe(o).resume(f, None, nil)
 }
 
 func generatorSend(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	if raised := checkMethodArgs(f, "send", args, GeneratorType, ObjectType); raised != nil {
 		return nil, raised
 	}
-	return toGeneratorUnsafe(args[0]).resume(f, args[1])
+	return toGeneratorUnsafe(args[0]).resume(f, args[1], nil)
+}
+
+func generatorThrow(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	expectedTypes := []*Type{GeneratorType, ObjectType, ObjectType, ObjectType}
+	argc := len(args)
+	if argc > 1 && argc < 4 {
+		expectedTypes = expectedTypes[:argc]
+	}
+	if raised := checkMethodArgs(f, "throw", args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	throwArgs := Args{args[1], None, None}
+	copy(throwArgs, args[1:])
+	typ, inst, tb := throwArgs[0], throwArgs[1], throwArgs[2]
+	// Reject invalid arguments before resuming like CPython does.
+	if tb != None && !tb.isInstance(TracebackType) {
+		return nil, f.RaiseType(TypeErrorType, "throw() third argument must be a traceback object")
+	}
+	isExcType := typ.isInstance(TypeType) && toTypeUnsafe(typ).isSubclass(BaseExceptionType)
+	if !isExcType && !typ.isInstance(BaseExceptionType) {
+		format := "exceptions must be classes, or instances, not %s"
+		return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, typ.typ.Name()))
+	}
+	if !isExcType && inst != None {
+		return nil, f.RaiseType(TypeErrorType, "instance exception may not have a separate value")
+	}
+	return toGeneratorUnsafe(args[0]).resume(f, None, throwArgs)
 }
 
 func initGeneratorType(dict map[string]*Object) {
+	dict["close"] = newBuiltinFunction("close", generatorClose).ToObject()
 	dict["send"] = newBuiltinFunction("send", generatorSend).ToObject()
+	dict["throw"] = newBuiltinFunction("throw", generatorThrow).ToObject()
 	GeneratorType.flags &= ^(typeFlagBasetype | typeFlagInstantiable)
 	GeneratorType.slots.Iter = &unaryOpSlot{generatorIter}
 	GeneratorType.slots.Next = &unaryOpSlot{generatorNext}
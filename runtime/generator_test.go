@@ -60,6 +60,89 @@ func TestGeneratorSend(t *testing.T) {
 	}
 }
 
+func TestGeneratorClose(t *testing.T) {
+	emptyFn := func(*Object) (*Object, *BaseException) {
+		return nil, nil
+	}
+	newYielder := func(catch bool) *Generator {
+		f := NewRootFrame()
+		return NewGenerator(f, func(sent *Object) (*Object, *BaseException) {
+			switch f.State() {
+			case 0:
+				if catch {
+					f.PushCheckpoint(1)
+				}
+				f.PushCheckpoint(2)
+				return None, nil
+			case 1:
+				// Handler that swallows GeneratorExit and yields.
+				f.RestoreExc(nil, nil)
+				f.PushCheckpoint(2)
+				return NewStr("foo").ToObject(), nil
+			}
+			return nil, f.RaiseType(RuntimeErrorType, "bar")
+		})
+	}
+	suspended := func(catch bool) *Generator {
+		g := newYielder(catch)
+		mustNotRaise(generatorNext(NewRootFrame(), g.ToObject()))
+		return g
+	}
+	cases := []invokeTestCase{
+		invokeTestCase{args: wrapArgs(NewGenerator(NewRootFrame(), emptyFn)), want: None},
+		invokeTestCase{args: wrapArgs(suspended(false)), want: None},
+		invokeTestCase{args: wrapArgs(suspended(true)), wantExc: mustCreateException(RuntimeErrorType, "generator ignored GeneratorExit")},
+		invokeTestCase{args: wrapArgs(NewGenerator(NewRootFrame(), emptyFn), "foo"), wantExc: mustCreateException(TypeErrorType, "'close' of 'generator' requires 1 arguments")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(GeneratorType, "close", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestGeneratorThrow(t *testing.T) {
+	emptyFn := func(*Object) (*Object, *BaseException) {
+		return nil, nil
+	}
+	newCatcher := func() *Generator {
+		f := NewRootFrame()
+		g := NewGenerator(f, func(sent *Object) (*Object, *BaseException) {
+			switch f.State() {
+			case 0:
+				f.PushCheckpoint(1)
+				f.PushCheckpoint(2)
+				return None, nil
+			case 1:
+				if sent != nil {
+					t.Errorf("handler got sent value %v, want nil", sent)
+				}
+				e, _ := f.ExcInfo()
+				f.RestoreExc(nil, nil)
+				f.PushCheckpoint(2)
+				return e.ToObject(), nil
+			}
+			return nil, nil
+		})
+		mustNotRaise(generatorNext(NewRootFrame(), g.ToObject()))
+		return g
+	}
+	fooErr := mustCreateException(ValueErrorType, "foo")
+	cases := []invokeTestCase{
+		invokeTestCase{args: wrapArgs(NewGenerator(NewRootFrame(), emptyFn), KeyErrorType), wantExc: mustCreateException(KeyErrorType, "")},
+		invokeTestCase{args: wrapArgs(newCatcher(), fooErr), want: fooErr.ToObject()},
+		invokeTestCase{args: wrapArgs(NewGenerator(NewRootFrame(), emptyFn), 123), wantExc: mustCreateException(TypeErrorType, "exceptions must be classes, or instances, not int")},
+		invokeTestCase{args: wrapArgs(NewGenerator(NewRootFrame(), emptyFn), fooErr, "bar"), wantExc: mustCreateException(TypeErrorType, "instance exception may not have a separate value")},
+		invokeTestCase{args: wrapArgs(NewGenerator(NewRootFrame(), emptyFn), ValueErrorType, None, "bar"), wantExc: mustCreateException(TypeErrorType, "throw() third argument must be a traceback object")},
+		invokeTestCase{args: wrapArgs(NewGenerator(NewRootFrame(), emptyFn)), wantExc: mustCreateException(TypeErrorType, "'throw' of 'generator' requires 4 arguments")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(GeneratorType, "throw", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestGeneratorSimple(t *testing.T) {
 	f := NewRootFrame()
 	fn := func(*Object) (*Object, *BaseException) {
@@ -18,8 +18,11 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"math/bits"
 	"reflect"
 	"strconv"
+	"strings"
+	"unicode"
 )
 
 const (
@@ -86,6 +89,66 @@ func intAnd(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return NewInt(toIntUnsafe(v).Value() & toIntUnsafe(w).Value()).ToObject(), nil
 }
 
+// intCoerceMethod implements int.__coerce__. Like CPython, only other ints
+// are accepted and conversions to wider types are left to their __coerce__.
+func intCoerceMethod(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "__coerce__", args, IntType, ObjectType); raised != nil {
+		return nil, raised
+	}
+	if !args[1].isInstance(IntType) {
+		return NotImplemented, nil
+	}
+	return NewTuple2(args[0], args[1]).ToObject(), nil
+}
+
+func intConjugate(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "conjugate", args, IntType); raised != nil {
+		return nil, raised
+	}
+	return intInt(f, args[0])
+}
+
+func intGetDenominator(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_get_denominator", args, IntType); raised != nil {
+		return nil, raised
+	}
+	return NewInt(1).ToObject(), nil
+}
+
+func intGetImag(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_get_imag", args, IntType); raised != nil {
+		return nil, raised
+	}
+	return NewInt(0).ToObject(), nil
+}
+
+func intGetNumerator(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_get_numerator", args, IntType); raised != nil {
+		return nil, raised
+	}
+	return intInt(f, args[0])
+}
+
+func intGetReal(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_get_real", args, IntType); raised != nil {
+		return nil, raised
+	}
+	return intInt(f, args[0])
+}
+
+func intBitLength(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "bit_length", args, IntType); raised != nil {
+		return nil, raised
+	}
+	v := toIntUnsafe(args[0]).Value()
+	// Negate as unsigned so that MinInt doesn't overflow.
+	u := uint(v)
+	if v < 0 {
+		u = -u
+	}
+	return NewInt(bits.Len(u)).ToObject(), nil
+}
+
 func intDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return intDivModOp(f, "__div__", v, w, intCheckedDiv, longDiv)
 }
@@ -119,7 +182,20 @@ func intFloat(f *Frame, o *Object) (*Object, *BaseException) {
 }
 
 func intHash(f *Frame, o *Object) (*Object, *BaseException) {
-	return o, nil
+	v := toIntUnsafe(o).Value()
+	if o.typ == IntType && v != -1 {
+		return o, nil
+	}
+	return NewInt(hashInt(v)).ToObject(), nil
+}
+
+// hashInt returns the hash of the int value v. Like CPython, -1 is reserved so
+// it hashes to -2.
+func hashInt(v int) int {
+	if v == -1 {
+		return -2
+	}
+	return v
 }
 
 func intHex(f *Frame, o *Object) (*Object, *BaseException) {
@@ -194,6 +270,10 @@ func intNew(f *Frame, t *Type, args Args, _ KWArgs) (*Object, *BaseException) {
 			return nil, raised
 		}
 		if t == IntType {
+			if i.typ == LongType {
+				// Like CPython, int() of a long that fits is an int.
+				return longInt(f, i)
+			}
 			return i, nil
 		}
 		n := 0
@@ -227,13 +307,14 @@ func intNew(f *Frame, t *Type, args Args, _ KWArgs) (*Object, *BaseException) {
 			return nil, raised
 		}
 		if base < 0 || base == 1 || base > 36 {
-			return nil, f.RaiseType(ValueErrorType, "int() base must be >= 2 and <= 36")
+			return nil, f.RaiseType(ValueErrorType, "int() base must be >= 2 and <= 36, or 0")
 		}
 	}
-	i, ok := numParseInteger(new(big.Int), s, base)
+	i, _, ok := numParseInteger(new(big.Int), s, base)
 	if !ok {
-		format := "invalid literal for int() with base %d: %s"
-		return nil, f.RaiseType(ValueErrorType, fmt.Sprintf(format, base, s))
+		// Like CPython, report the literal without its leading
+		// whitespace.
+		return nil, numInvalidLiteral(f, "int", base, strings.TrimLeftFunc(s, unicode.IsSpace))
 	}
 	if !numInIntRange(i) {
 		if t == IntType {
@@ -365,10 +446,24 @@ func intRSub(f *Frame, v, w *Object) (*Object, *BaseException) {
 	})
 }
 
+func intRTrueDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
+	if !w.isInstance(IntType) {
+		return NotImplemented, nil
+	}
+	return intTrueDivOp(f, toIntUnsafe(w).Value(), toIntUnsafe(v).Value())
+}
+
 func intSub(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return intAddMulOp(f, "__sub__", v, w, intCheckedSub, longSub)
 }
 
+func intTrueDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
+	if !w.isInstance(IntType) {
+		return NotImplemented, nil
+	}
+	return intTrueDivOp(f, toIntUnsafe(v).Value(), toIntUnsafe(w).Value())
+}
+
 func intXor(f *Frame, v, w *Object) (*Object, *BaseException) {
 	if !w.isInstance(IntType) {
 		return NotImplemented, nil
@@ -377,7 +472,14 @@ func intXor(f *Frame, v, w *Object) (*Object, *BaseException) {
 }
 
 func initIntType(dict map[string]*Object) {
+	dict["__coerce__"] = newBuiltinFunction("__coerce__", intCoerceMethod).ToObject()
 	dict["__getnewargs__"] = newBuiltinFunction("__getnewargs__", intGetNewArgs).ToObject()
+	dict["bit_length"] = newBuiltinFunction("bit_length", intBitLength).ToObject()
+	dict["conjugate"] = newBuiltinFunction("conjugate", intConjugate).ToObject()
+	dict["denominator"] = newProperty(newBuiltinFunction("_get_denominator", intGetDenominator).ToObject(), nil, nil).ToObject()
+	dict["imag"] = newProperty(newBuiltinFunction("_get_imag", intGetImag).ToObject(), nil, nil).ToObject()
+	dict["numerator"] = newProperty(newBuiltinFunction("_get_numerator", intGetNumerator).ToObject(), nil, nil).ToObject()
+	dict["real"] = newProperty(newBuiltinFunction("_get_real", intGetReal).ToObject(), nil, nil).ToObject()
 	IntType.slots.Abs = &unaryOpSlot{intAbs}
 	IntType.slots.Add = &binaryOpSlot{intAdd}
 	IntType.slots.And = &binaryOpSlot{intAnd}
@@ -421,8 +523,10 @@ func initIntType(dict map[string]*Object) {
 	IntType.slots.RRShift = &binaryOpSlot{intRRShift}
 	IntType.slots.RShift = &binaryOpSlot{intRShift}
 	IntType.slots.RSub = &binaryOpSlot{intRSub}
+	IntType.slots.RTrueDiv = &binaryOpSlot{intRTrueDiv}
 	IntType.slots.RXor = &binaryOpSlot{intXor}
 	IntType.slots.Sub = &binaryOpSlot{intSub}
+	IntType.slots.TrueDiv = &binaryOpSlot{intTrueDiv}
 	IntType.slots.Xor = &binaryOpSlot{intXor}
 }
 
@@ -566,6 +670,20 @@ func intDivAndModOp(f *Frame, method string, v, w *Object, fun func(v, w int) (i
 	return NewTuple2(NewInt(q).ToObject(), NewInt(m).ToObject()).ToObject(), nil
 }
 
+// intTrueDivOp returns x / y as a float. The quotient is computed directly when
+// both operands are exactly representable as floats, otherwise it is left to
+// longTrueDivOp so that the result is correctly rounded.
+func intTrueDivOp(f *Frame, x, y int) (*Object, *BaseException) {
+	const exact = 1 << 53
+	if y == 0 {
+		return nil, f.RaiseType(ZeroDivisionErrorType, "division by zero")
+	}
+	if -exact <= x && x <= exact && -exact <= y && y <= exact {
+		return NewFloat(float64(x) / float64(y)).ToObject(), nil
+	}
+	return longTrueDivOp(f, big.NewInt(int64(x)), big.NewInt(int64(y)))
+}
+
 func intShiftOp(f *Frame, v, w *Object, fun func(int, int) (int, int, bool)) (*Object, *BaseException) {
 	if !w.isInstance(IntType) {
 		return NotImplemented, nil
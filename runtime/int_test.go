@@ -15,6 +15,7 @@
 package grumpy
 
 import (
+	"fmt"
 	"math/big"
 	"runtime"
 	"testing"
@@ -55,16 +56,25 @@ func TestIntBinaryOps(t *testing.T) {
 		{LShift, NewInt(2).ToObject(), NewInt(4).ToObject(), NewInt(32).ToObject(), nil},
 		{LShift, NewInt(-12).ToObject(), NewInt(10).ToObject(), NewInt(-12288).ToObject(), nil},
 		{LShift, NewInt(10).ToObject(), NewInt(100).ToObject(), NewLong(new(big.Int).Lsh(big.NewInt(10), 100)).ToObject(), nil},
+		{LShift, NewInt(-1).ToObject(), NewInt(maxIntBig.BitLen()).ToObject(), NewInt(MinInt).ToObject(), nil},
+		{LShift, NewInt(1).ToObject(), NewInt(maxIntBig.BitLen()).ToObject(), NewLong(new(big.Int).Neg(minIntBig)).ToObject(), nil},
+		{LShift, NewInt(MaxInt).ToObject(), NewInt(1).ToObject(), NewLong(new(big.Int).Lsh(maxIntBig, 1)).ToObject(), nil},
 		{LShift, NewInt(2).ToObject(), NewInt(-5).ToObject(), nil, mustCreateException(ValueErrorType, "negative shift count")},
 		{LShift, NewInt(4).ToObject(), NewFloat(3.14).ToObject(), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for <<: 'int' and 'float'")},
 		{LShift, newObject(ObjectType), NewInt(4).ToObject(), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for <<: 'object' and 'int'")},
 		{RShift, NewInt(87).ToObject(), NewInt(3).ToObject(), NewInt(10).ToObject(), nil},
 		{RShift, NewInt(-101).ToObject(), NewInt(5).ToObject(), NewInt(-4).ToObject(), nil},
 		{RShift, NewInt(12).ToObject(), NewInt(10).ToObject(), NewInt(0).ToObject(), nil},
+		{RShift, NewInt(MinInt).ToObject(), NewInt(maxIntBig.BitLen()).ToObject(), NewInt(-1).ToObject(), nil},
+		{RShift, NewInt(-1).ToObject(), NewInt(1000).ToObject(), NewInt(-1).ToObject(), nil},
 		{RShift, NewInt(12).ToObject(), NewInt(-10).ToObject(), nil, mustCreateException(ValueErrorType, "negative shift count")},
 		{RShift, NewInt(4).ToObject(), NewFloat(3.14).ToObject(), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for >>: 'int' and 'float'")},
 		{RShift, newObject(ObjectType), NewInt(4).ToObject(), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for >>: 'object' and 'int'")},
 		{RShift, NewInt(4).ToObject(), newObject(ObjectType), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for >>: 'int' and 'object'")},
+		{Mod, NewInt(7).ToObject(), NewInt(3).ToObject(), NewInt(1).ToObject(), nil},
+		{Mod, NewInt(-7).ToObject(), NewInt(3).ToObject(), NewInt(2).ToObject(), nil},
+		{Mod, NewInt(7).ToObject(), NewInt(-3).ToObject(), NewInt(-2).ToObject(), nil},
+		{Mod, NewInt(-7).ToObject(), NewInt(-3).ToObject(), NewInt(-1).ToObject(), nil},
 		{Mod, NewInt(3).ToObject(), NewInt(-7).ToObject(), NewInt(-4).ToObject(), nil},
 		{Mod, NewInt(MaxInt).ToObject(), NewInt(MinInt).ToObject(), NewInt(-1).ToObject(), nil},
 		{Mod, NewInt(MinInt).ToObject(), NewInt(MaxInt).ToObject(), NewInt(MaxInt - 1).ToObject(), nil},
@@ -84,6 +94,12 @@ func TestIntBinaryOps(t *testing.T) {
 		{Sub, NewInt(22).ToObject(), NewInt(18).ToObject(), NewInt(4).ToObject(), nil},
 		{Sub, IntType.ToObject(), NewInt(42).ToObject(), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for -: 'type' and 'int'")},
 		{Sub, NewInt(MinInt).ToObject(), NewInt(1).ToObject(), NewLong(new(big.Int).Sub(minIntBig, big.NewInt(1))).ToObject(), nil},
+		{TrueDiv, NewInt(5).ToObject(), NewInt(2).ToObject(), NewFloat(2.5).ToObject(), nil},
+		{TrueDiv, NewInt(-7).ToObject(), NewInt(2).ToObject(), NewFloat(-3.5).ToObject(), nil},
+		{TrueDiv, NewInt(MaxInt).ToObject(), NewInt(MaxInt).ToObject(), NewFloat(1).ToObject(), nil},
+		{TrueDiv, NewInt(1).ToObject(), NewFloat(4).ToObject(), NewFloat(0.25).ToObject(), nil},
+		{TrueDiv, NewInt(1).ToObject(), NewInt(0).ToObject(), nil, mustCreateException(ZeroDivisionErrorType, "division by zero")},
+		{TrueDiv, NewList().ToObject(), NewInt(21).ToObject(), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for /: 'list' and 'int'")},
 		{Xor, NewInt(-100).ToObject(), NewInt(50).ToObject(), NewInt(-82).ToObject(), nil},
 		{Xor, NewInt(MaxInt).ToObject(), NewInt(MinInt).ToObject(), NewInt(-1).ToObject(), nil},
 		{Xor, newObject(ObjectType), NewInt(-100).ToObject(), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for ^: 'object' and 'int'")},
@@ -96,6 +112,70 @@ func TestIntBinaryOps(t *testing.T) {
 	}
 }
 
+func TestIntBitLength(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(0), want: NewInt(0).ToObject()},
+		{args: wrapArgs(1), want: NewInt(1).ToObject()},
+		{args: wrapArgs(-37), want: NewInt(6).ToObject()},
+		{args: wrapArgs(MaxInt), want: NewInt(63).ToObject()},
+		{args: wrapArgs(MinInt), want: NewInt(64).ToObject()},
+		{args: wrapArgs(true), want: NewInt(1).ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(IntType, "bit_length", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestIntRationalAttrs(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, o *Object) (*Object, *BaseException) {
+		var elems []*Object
+		for _, name := range []string{"numerator", "denominator", "real", "imag"} {
+			attr, raised := GetAttr(f, o, NewStr(name), nil)
+			if raised != nil {
+				return nil, raised
+			}
+			if attr.typ != IntType {
+				return nil, f.RaiseType(TypeErrorType, fmt.Sprintf("%s is a %s", name, attr.typ.Name()))
+			}
+			elems = append(elems, attr)
+		}
+		method, raised := GetAttr(f, o, NewStr("conjugate"), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		conjugate, raised := method.Call(f, nil, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return NewTuple(append(elems, conjugate)...).ToObject(), nil
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(42), want: newTestTuple(42, 1, 42, 0, 42).ToObject()},
+		{args: wrapArgs(-3), want: newTestTuple(-3, 1, -3, 0, -3).ToObject()},
+		{args: wrapArgs(true), want: newTestTuple(1, 1, 1, 0, 1).ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestIntRationalAttrGetters(t *testing.T) {
+	f := NewRootFrame()
+	for _, name := range []string{"numerator", "denominator", "real", "imag"} {
+		prop := mustNotRaise(GetAttr(f, IntType.ToObject(), NewStr(name), nil))
+		fget := mustNotRaise(GetAttr(f, prop, NewStr("fget"), nil))
+		msg := fmt.Sprintf("'_get_%s' requires a 'int' object but received a 'str'", name)
+		cas := invokeTestCase{args: wrapArgs("foo"), wantExc: mustCreateException(TypeErrorType, msg)}
+		if err := runInvokeTestCase(fget, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestIntCompare(t *testing.T) {
 	cases := []invokeTestCase{
 		{args: wrapArgs(1, 1), want: compareAllResultEq},
@@ -110,6 +190,22 @@ func TestIntCompare(t *testing.T) {
 	}
 }
 
+func TestIntHash(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(0), want: NewInt(0).ToObject()},
+		{args: wrapArgs(42), want: NewInt(42).ToObject()},
+		{args: wrapArgs(-1), want: NewInt(-2).ToObject()},
+		{args: wrapArgs(-2), want: NewInt(-2).ToObject()},
+		{args: wrapArgs(MinInt), want: NewInt(MinInt).ToObject()},
+		{args: wrapArgs(true), want: NewInt(1).ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(wrapFuncForTest(intHash), &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestIntInvert(t *testing.T) {
 	cases := []invokeTestCase{
 		{args: wrapArgs(2592), want: NewInt(-2593).ToObject()},
@@ -160,7 +256,22 @@ func TestIntNew(t *testing.T) {
 		{args: wrapArgs(IntType, "0b101", 0), want: NewInt(5).ToObject()},
 		{args: wrapArgs(IntType, "0o726", 0), want: NewInt(470).ToObject()},
 		{args: wrapArgs(IntType, "0726", 0), want: NewInt(470).ToObject()},
+		{args: wrapArgs(IntType, "025"), want: NewInt(25).ToObject()},
+		{args: wrapArgs(IntType, "025", 16), want: NewInt(37).ToObject()},
 		{args: wrapArgs(IntType, "102", 0), want: NewInt(102).ToObject()},
+		{args: wrapArgs(IntType, "  10  ", 2), want: NewInt(2).ToObject()},
+		{args: wrapArgs(IntType, "-0b1010", 0), want: NewInt(-10).ToObject()},
+		{args: wrapArgs(IntType, "- 17", 8), want: NewInt(-15).ToObject()},
+		{args: wrapArgs(IntType, "+0x1f", 0), want: NewInt(31).ToObject()},
+		{args: wrapArgs(IntType, "0b", 16), want: NewInt(11).ToObject()},
+		{args: wrapArgs(IntType, "Zz", 36), want: NewInt(1295).ToObject()},
+		{args: wrapArgs(IntType, "00", 0), want: NewInt(0).ToObject()},
+		{args: wrapArgs(IntType, "09", 0), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 0: '09'")},
+		{args: wrapArgs(IntType, "0x", 16), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 16: '0x'")},
+		{args: wrapArgs(IntType, "10L", 0), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 0: '10L'")},
+		{args: wrapArgs(IntType, "1_000"), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 10: '1_000'")},
+		{args: wrapArgs(IntType, "--1"), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 10: '--1'")},
+		{args: wrapArgs(IntType, " 12 ", 2), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 2: '12 '")},
 		{args: wrapArgs(IntType, 42), want: NewInt(42).ToObject()},
 		{args: wrapArgs(IntType, -3.14), want: NewInt(-3).ToObject()},
 		{args: wrapArgs(subType, overflowLong), wantExc: mustCreateException(OverflowErrorType, "Python int too large to convert to a Go int")},
@@ -170,13 +281,13 @@ func TestIntNew(t *testing.T) {
 		{args: wrapArgs(IntType, newObject(slotSubTypeType)), want: subTypeObject},
 		{args: wrapArgs(strictEqType, newObject(goodSlotType)), want: (&Int{Object{typ: strictEqType}, 3}).ToObject()},
 		{args: wrapArgs(strictEqType, newObject(badSlotType)), wantExc: mustCreateException(TypeErrorType, "__int__ returned non-int (type object)")},
-		{args: wrapArgs(IntType, "0xff"), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 10: 0xff")},
-		{args: wrapArgs(IntType, ""), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 10: ")},
-		{args: wrapArgs(IntType, " "), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 10:  ")},
+		{args: wrapArgs(IntType, "0xff"), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 10: '0xff'")},
+		{args: wrapArgs(IntType, ""), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 10: ''")},
+		{args: wrapArgs(IntType, " "), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 10: ''")},
 		{args: wrapArgs(FloatType), wantExc: mustCreateException(TypeErrorType, "int.__new__(float): float is not a subtype of int")},
-		{args: wrapArgs(IntType, "asldkfj", 1), wantExc: mustCreateException(ValueErrorType, "int() base must be >= 2 and <= 36")},
-		{args: wrapArgs(IntType, "asldkfj", 37), wantExc: mustCreateException(ValueErrorType, "int() base must be >= 2 and <= 36")},
-		{args: wrapArgs(IntType, "@#%*(#", 36), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 36: @#%*(#")},
+		{args: wrapArgs(IntType, "asldkfj", 1), wantExc: mustCreateException(ValueErrorType, "int() base must be >= 2 and <= 36, or 0")},
+		{args: wrapArgs(IntType, "asldkfj", 37), wantExc: mustCreateException(ValueErrorType, "int() base must be >= 2 and <= 36, or 0")},
+		{args: wrapArgs(IntType, "@#%*(#", 36), wantExc: mustCreateException(ValueErrorType, "invalid literal for int() with base 36: '@#%*(#'")},
 		{args: wrapArgs(IntType, "123", overflowLong), wantExc: mustCreateException(OverflowErrorType, "Python int too large to convert to a Go int")},
 		{args: wrapArgs(IntType, "32059823095809238509238590835"), want: NewLong(func() *big.Int { i, _ := new(big.Int).SetString("32059823095809238509238590835", 0); return i }()).ToObject()},
 		{args: wrapArgs(IntType, newObject(ObjectType)), wantExc: mustCreateException(TypeErrorType, "int() argument must be a string or a number, not 'object'")},
@@ -192,6 +303,26 @@ func TestIntNew(t *testing.T) {
 	}
 }
 
+func TestIntNewLong(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, o *Object) (*Type, *BaseException) {
+		i, raised := IntType.Call(f, Args{o}, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return i.typ, nil
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(NewLong(big.NewInt(5))), want: IntType.ToObject()},
+		{args: wrapArgs(NewLong(big.NewInt(-5))), want: IntType.ToObject()},
+		{args: wrapArgs(NewLong(new(big.Int).Lsh(big.NewInt(1), 70))), want: LongType.ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestIntNewInterned(t *testing.T) {
 	// Make sure small integers are interned.
 	fun := wrapFuncForTest(func(f *Frame, i *Int) (bool, *BaseException) {
@@ -61,6 +61,46 @@ func (l *List) Append(o *Object) {
 	l.mutex.Unlock()
 }
 
+// DelItem removes the index'th element of l.
+func (l *List) DelItem(f *Frame, index int) *BaseException {
+	l.mutex.Lock()
+	numElems := len(l.elems)
+	i, raised := seqCheckedIndex(f, numElems, index)
+	if raised == nil {
+		copy(l.elems[i:numElems-1], l.elems[i+1:numElems])
+		l.elems = l.elems[:numElems-1]
+	}
+	l.mutex.Unlock()
+	return raised
+}
+
+// DelSlice removes the slice of l specified by s.
+func (l *List) DelSlice(f *Frame, s *Slice) *BaseException {
+	l.mutex.Lock()
+	numListElems := len(l.elems)
+	start, stop, step, numSliceElems, raised := s.calcSlice(f, numListElems)
+	if raised == nil {
+		if step == 1 {
+			copy(l.elems[start:numListElems-numSliceElems], l.elems[stop:numListElems])
+		} else {
+			deleted := make([]bool, numListElems)
+			for i := start; i != stop; i += step {
+				deleted[i] = true
+			}
+			j := 0
+			for i, o := range l.elems {
+				if !deleted[i] {
+					l.elems[j] = o
+					j++
+				}
+			}
+		}
+		l.elems = l.elems[:numListElems-numSliceElems]
+	}
+	l.mutex.Unlock()
+	return raised
+}
+
 // SetItem sets the index'th element of l to value.
 func (l *List) SetItem(f *Frame, index int, value *Object) *BaseException {
 	l.mutex.RLock()
@@ -78,7 +118,12 @@ func (l *List) SetSlice(f *Frame, s *Slice, value *Object) *BaseException {
 	numListElems := len(l.elems)
 	start, stop, step, numSliceElems, raised := s.calcSlice(f, numListElems)
 	if raised == nil {
-		raised = seqApply(f, value, func(elems []*Object, _ bool) *BaseException {
+		raised = seqApply(f, value, func(elems []*Object, borrowed bool) *BaseException {
+			if borrowed && value == l.ToObject() {
+				// Copy l's elements so that e.g. l[::-1] = l doesn't
+				// read elements that have already been overwritten.
+				elems = append([]*Object(nil), elems...)
+			}
 			numElems := len(elems)
 			if step == 1 {
 				tailElems := l.elems[stop:numListElems]
@@ -102,24 +147,87 @@ func (l *List) SetSlice(f *Frame, s *Slice, value *Object) *BaseException {
 }
 
 // Sort reorders l so that its elements are in sorted order.
-func (l *List) Sort(f *Frame) (raised *BaseException) {
-	l.mutex.RLock()
-	sorter := &listSorter{f, l, nil}
-	defer func() {
-		l.mutex.RUnlock()
-		if val := recover(); val == nil {
-			return
-		} else if s, ok := val.(*listSorter); !ok || s != sorter {
-			panic(val)
+func (l *List) Sort(f *Frame) *BaseException {
+	return l.sort(f, nil, nil, false)
+}
+
+// sort reorders l like Sort but with the semantics of list.sort()'s optional
+// arguments. When key is not nil, elements are ordered by the result of
+// calling key on each of them. When cmp is not nil, it is called to compare
+// two elements (or keys) instead of using the < operator. When reverse is
+// true, elements are sorted in descending order. Like CPython, l appears
+// empty while it is being sorted and ValueError is raised if it was modified
+// in the meantime. If an exception is raised, l's original elements are
+// restored.
+func (l *List) sort(f *Frame, cmp, key *Object, reverse bool) *BaseException {
+	l.mutex.Lock()
+	saved := l.elems
+	l.elems = nil
+	l.mutex.Unlock()
+	elems := make([]*Object, len(saved))
+	copy(elems, saved)
+	raised := listSortElems(f, elems, cmp, key, reverse)
+	l.mutex.Lock()
+	modified := l.elems != nil
+	if raised == nil {
+		l.elems = elems
+	} else {
+		l.elems = saved
+	}
+	l.mutex.Unlock()
+	if raised == nil && modified {
+		raised = f.RaiseType(ValueErrorType, "list modified during sort")
+	}
+	return raised
+}
+
+func listSortElems(f *Frame, elems []*Object, cmp, key *Object, reverse bool) *BaseException {
+	sorter := &listSorter{f: f, elems: elems, cmp: cmp}
+	if key != nil {
+		sorter.keys = make([]*Object, len(elems))
+		for i, o := range elems {
+			k, raised := key.Call(f, Args{o}, nil)
+			if raised != nil {
+				return raised
+			}
+			sorter.keys[i] = k
 		}
-		raised = sorter.raised
-	}()
-	// Python guarantees stability.  See note (9) in:
-	// https://docs.python.org/2/library/stdtypes.html#mutable-sequence-types
-	sort.Stable(sorter)
+	}
+	// Reversing before and after a stable sort keeps equal elements in
+	// their original order, which is what Python guarantees.
+	if reverse {
+		sorter.reverse()
+	}
+	if raised := sorter.sort(); raised != nil {
+		return raised
+	}
+	if reverse {
+		sorter.reverse()
+	}
 	return nil
 }
 
+// listReserveLimit caps the number of elements reserve will make room for.
+// Lengths reported by __len__ are only a hint so a bogus one like sys.maxint
+// must not cause a huge allocation.
+const listReserveLimit = 1 << 16
+
+// reserve grows l's capacity so that n more elements can be appended without
+// reallocating. The length of l is unchanged. n is treated as a hint and is
+// capped at listReserveLimit.
+// NOTE: l.mutex must be locked when calling reserve.
+func (l *List) reserve(n int) {
+	if n > listReserveLimit {
+		n = listReserveLimit
+	}
+	numElems := len(l.elems)
+	if cap(l.elems)-numElems < n {
+		newElems := make([]*Object, numElems, numElems+n)
+		copy(newElems, l.elems)
+		l.elems = newElems
+	}
+}
+
 // resize ensures that len(l.elems) == newLen, reallocating if necessary.
 // NOTE: l.mutex must be locked when calling resize.
 func (l *List) resize(newLen int) {
@@ -235,6 +343,41 @@ func listGT(f *Frame, v, w *Object) (*Object, *BaseException) {
 
 func listIAdd(f *Frame, v, w *Object) (*Object, *BaseException) {
 	l := toListUnsafe(v)
+	// Like listextend in listobject.c, take a snapshot of exact lists and
+	// tuples up front. This also ensures that l += l terminates.
+	if w == v || w.typ == ListType || w.typ == TupleType {
+		var elems []*Object
+		if w.typ == TupleType {
+			elems = toTupleUnsafe(w).elems
+		} else {
+			src := toListUnsafe(w)
+			src.mutex.RLock()
+			elems = make([]*Object, len(src.elems))
+			copy(elems, src.elems)
+			src.mutex.RUnlock()
+		}
+		l.mutex.Lock()
+		numElems := len(l.elems)
+		l.resize(numElems + len(elems))
+		copy(l.elems[numElems:], elems)
+		l.mutex.Unlock()
+		return v, nil
+	}
+	// When the iterable knows its length, make room for all of its elements
+	// up front rather than growing l one append at a time.
+	if w.typ.slots.Len != nil {
+		n, raised := Len(f, w)
+		if raised != nil {
+			if !raised.isInstance(TypeErrorType) {
+				return nil, raised
+			}
+			f.RestoreExc(nil, nil)
+		} else if n.Value() > 0 {
+			l.mutex.Lock()
+			l.reserve(n.Value())
+			l.mutex.Unlock()
+		}
+	}
 	raised := seqForEach(f, w, func(o *Object) *BaseException {
 		l.Append(o)
 		return nil
@@ -338,31 +481,31 @@ func listIndex(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	if raised = checkMethodArgs(f, "index", args, expectedTypes...); raised != nil {
 		return nil, raised
 	}
-	l := toListUnsafe(args[0])
-	l.mutex.RLock()
-	numElems := len(l.elems)
-	start, stop := 0, numElems
+	start, stop := 0, MaxInt
 	if argc > 2 {
-		start, raised = IndexInt(f, args[2])
-		if raised != nil {
-			l.mutex.RUnlock()
+		if start, raised = IndexInt(f, args[2]); raised != nil {
 			return nil, raised
 		}
 	}
 	if argc > 3 {
-		stop, raised = IndexInt(f, args[3])
-		if raised != nil {
-			l.mutex.RUnlock()
+		if stop, raised = IndexInt(f, args[3]); raised != nil {
 			return nil, raised
 		}
 	}
+	// Search a copy of the elements so that comparisons are free to modify
+	// the list without deadlocking.
+	l := toListUnsafe(args[0])
+	l.mutex.RLock()
+	numElems := len(l.elems)
 	start, stop = adjustIndex(start, stop, numElems)
-	value := args[1]
-	index := -1
+	var elems []*Object
 	if start < numElems && start < stop {
-		index, raised = seqFindElem(f, l.elems[start:stop], value)
+		elems = make([]*Object, stop-start)
+		copy(elems, l.elems[start:stop])
 	}
 	l.mutex.RUnlock()
+	value := args[1]
+	index, raised := seqFindElem(f, elems, value)
 	if raised != nil {
 		return nil, raised
 	}
@@ -437,6 +580,21 @@ func listReverse(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	return None, nil
 }
 
+func listDelItem(f *Frame, o, key *Object) *BaseException {
+	l := toListUnsafe(o)
+	if key.typ.slots.Int != nil {
+		i, raised := IndexInt(f, key)
+		if raised != nil {
+			return raised
+		}
+		return l.DelItem(f, i)
+	}
+	if key.isInstance(SliceType) {
+		return l.DelSlice(f, toSliceUnsafe(key))
+	}
+	return f.RaiseType(TypeErrorType, fmt.Sprintf("list indices must be integers, not %s", key.Type().Name()))
+}
+
 func listSetItem(f *Frame, o, key, value *Object) *BaseException {
 	l := toListUnsafe(o)
 	if key.typ.slots.Int != nil {
@@ -452,16 +610,55 @@ func listSetItem(f *Frame, o, key, value *Object) *BaseException {
 	return f.RaiseType(TypeErrorType, fmt.Sprintf("list indices must be integers, not %s", key.Type().Name()))
 }
 
-func listSort(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
-	// TODO: Support (cmp=None, key=None, reverse=False)
-	if raised := checkMethodArgs(f, "sort", args, ListType); raised != nil {
+func listSort(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodVarArgs(f, "sort", args, ListType); raised != nil {
+		return nil, raised
+	}
+	cmp, key, reverse, raised := listSortArgs(f, "sort", 0, args[1:], kwargs)
+	if raised != nil {
+		return nil, raised
+	}
+	if raised := toListUnsafe(args[0]).sort(f, cmp, key, reverse); raised != nil {
 		return nil, raised
 	}
-	l := toListUnsafe(args[0])
-	l.Sort(f)
 	return None, nil
 }
 
+// listSortArgs extracts the cmp, key and reverse arguments accepted by
+// list.sort() and sorted() from args and kwargs. numLeading is the number of
+// arguments preceding args that count towards the totals in error messages.
+// None values for cmp and key are returned as nil.
+func listSortArgs(f *Frame, name string, numLeading int, args Args, kwargs KWArgs) (cmp, key *Object, reverse bool, raised *BaseException) {
+	names := []string{"cmp", "key", "reverse"}
+	if argc := len(args); argc > len(names) {
+		format := "%s() takes at most %d arguments (%d given)"
+		return nil, nil, false, f.RaiseType(TypeErrorType, fmt.Sprintf(format, name, numLeading+len(names), numLeading+argc))
+	}
+	values := []*Object{None, None, False.ToObject()}
+	for _, kwarg := range kwargs {
+		i := 0
+		for i < len(names) && names[i] != kwarg.Name {
+			i++
+		}
+		if i == len(names) {
+			return nil, nil, false, f.RaiseType(TypeErrorType, fmt.Sprintf("'%s' is an invalid keyword argument for this function", kwarg.Name))
+		}
+		if i < len(args) {
+			return nil, nil, false, f.RaiseType(TypeErrorType, fmt.Sprintf("Argument given by name ('%s') and position (%d)", kwarg.Name, numLeading+i+1))
+		}
+		values[i] = kwarg.Value
+	}
+	copy(values, args)
+	if cmp = values[0]; cmp == None {
+		cmp = nil
+	}
+	if key = values[1]; key == None {
+		key = nil
+	}
+	reverse, raised = IsTrue(f, values[2])
+	return cmp, key, reverse, raised
+}
+
 func initListType(dict map[string]*Object) {
 	dict["append"] = newBuiltinFunction("append", listAppend).ToObject()
 	dict["count"] = newBuiltinFunction("count", listCount).ToObject()
@@ -474,6 +671,7 @@ func initListType(dict map[string]*Object) {
 	dict["sort"] = newBuiltinFunction("sort", listSort).ToObject()
 	ListType.slots.Add = &binaryOpSlot{listAdd}
 	ListType.slots.Contains = &binaryOpSlot{listContains}
+	ListType.slots.DelItem = &delItemSlot{listDelItem}
 	ListType.slots.Eq = &binaryOpSlot{listEq}
 	ListType.slots.GE = &binaryOpSlot{listGE}
 	ListType.slots.GetItem = &binaryOpSlot{listGetItem}
@@ -555,17 +753,38 @@ func listCompare(f *Frame, v *List, w *Object, cmp binaryOpFunc) (*Object, *Base
 }
 
 type listSorter struct {
-	f      *Frame
-	l      *List
+	f     *Frame
+	elems []*Object
+	// keys holds the result of the key function for each element, or nil
+	// when elements are compared directly.
+	keys   []*Object
+	cmp    *Object
 	raised *BaseException
 }
 
 func (s *listSorter) Len() int {
-	return len(s.l.elems)
+	return len(s.elems)
 }
 
 func (s *listSorter) Less(i, j int) bool {
-	lt, raised := LT(s.f, s.l.elems[i], s.l.elems[j])
+	a, b := s.elems[i], s.elems[j]
+	if s.keys != nil {
+		a, b = s.keys[i], s.keys[j]
+	}
+	if s.cmp != nil {
+		result, raised := s.cmp.Call(s.f, Args{a, b}, nil)
+		if raised != nil {
+			s.raised = raised
+			panic(s)
+		}
+		if !result.isInstance(IntType) {
+			format := "comparison function must return int, not %s"
+			s.raised = s.f.RaiseType(TypeErrorType, fmt.Sprintf(format, result.typ.Name()))
+			panic(s)
+		}
+		return toIntUnsafe(result).Value() < 0
+	}
+	lt, raised := LT(s.f, a, b)
 	if raised != nil {
 		s.raised = raised
 		panic(s)
@@ -579,5 +798,31 @@ func (s *listSorter) Less(i, j int) bool {
 }
 
 func (s *listSorter) Swap(i, j int) {
-	s.l.elems[i], s.l.elems[j] = s.l.elems[j], s.l.elems[i]
+	s.elems[i], s.elems[j] = s.elems[j], s.elems[i]
+	if s.keys != nil {
+		s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	}
+}
+
+func (s *listSorter) reverse() {
+	for i, j := 0, len(s.elems)-1; i < j; i, j = i+1, j-1 {
+		s.Swap(i, j)
+	}
+}
+
+// sort stably sorts s.elems, returning the exception raised by a comparison,
+// if any.
+func (s *listSorter) sort() (raised *BaseException) {
+	defer func() {
+		if val := recover(); val == nil {
+			return
+		} else if sorter, ok := val.(*listSorter); !ok || sorter != s {
+			panic(val)
+		}
+		raised = s.raised
+	}()
+	// Python guarantees stability.  See note (9) in:
+	// https://docs.python.org/2/library/stdtypes.html#mutable-sequence-types
+	sort.Stable(s)
+	return nil
 }
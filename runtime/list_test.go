@@ -15,6 +15,7 @@
 package grumpy
 
 import (
+	"math"
 	"math/big"
 	"reflect"
 	"testing"
@@ -97,6 +98,24 @@ func TestListIndex(t *testing.T) {
 			return NewInt(0).ToObject(), nil
 		}).ToObject(),
 	}))
+	nan := NewFloat(math.NaN()).ToObject()
+	eqType := newTestClass("Eq", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__eq__": newBuiltinFunction("__eq__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return True.ToObject(), nil
+		}).ToObject(),
+	}))
+	victim := NewList()
+	clearType := newTestClass("Clear", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__eq__": newBuiltinFunction("__eq__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			if raised := victim.DelSlice(f, toSliceUnsafe(newTestSlice(None, None))); raised != nil {
+				return nil, raised
+			}
+			return False.ToObject(), nil
+		}).ToObject(),
+	}))
+	for i := 0; i < 3; i++ {
+		victim.Append(newObject(clearType))
+	}
 	cases := []invokeTestCase{
 		// {args: wrapArgs(newTestList(), 1, "foo"), wantExc: mustCreateException(TypeErrorType, "slice indices must be integers or None or have an __index__ method")},
 		{args: wrapArgs(newTestList(10, 20, 30), 20), want: NewInt(1).ToObject()},
@@ -114,7 +133,12 @@ func TestListIndex(t *testing.T) {
 		{args: wrapArgs(newTestList(0, 1, 2, 3, 4), 3, 0, 999), want: NewInt(3).ToObject()},
 		{args: wrapArgs(newTestList(0, 1, 2, 3, 4), "foo", 0, 999), wantExc: mustCreateException(ValueErrorType, "'foo' is not in list")},
 		{args: wrapArgs(newTestList(0, 1, 2, 3, 4), 3, 999), wantExc: mustCreateException(ValueErrorType, "3 is not in list")},
+		{args: wrapArgs(newTestList(1, 2), 1, NewLong(new(big.Int).Lsh(minIntBig, 2))), want: NewInt(0).ToObject()},
+		{args: wrapArgs(newTestList(1, 2), 1, NewLong(new(big.Int).Lsh(maxIntBig, 2))), wantExc: mustCreateException(ValueErrorType, "1 is not in list")},
 		{args: wrapArgs(newTestList(0, 1, 2, 3, 4), 3, 5, 0), wantExc: mustCreateException(ValueErrorType, "3 is not in list")},
+		{args: wrapArgs(newTestList(0, nan, 1), nan), want: NewInt(1).ToObject()},
+		{args: wrapArgs(newTestList(0, 1, 2), newObject(eqType)), want: NewInt(0).ToObject()},
+		{args: wrapArgs(victim, 42), wantExc: mustCreateException(ValueErrorType, "42 is not in list")},
 	}
 	for _, cas := range cases {
 		if err := runInvokeMethodTestCase(ListType, "index", &cas); err != "" {
@@ -124,6 +148,12 @@ func TestListIndex(t *testing.T) {
 }
 
 func TestListRemove(t *testing.T) {
+	nan := NewFloat(math.NaN()).ToObject()
+	eqType := newTestClass("Eq", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__eq__": newBuiltinFunction("__eq__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return True.ToObject(), nil
+		}).ToObject(),
+	}))
 	fun := newBuiltinFunction("TestListRemove", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 		rem, raised := GetAttr(f, ListType.ToObject(), NewStr("remove"), nil)
 		if raised != nil {
@@ -139,6 +169,8 @@ func TestListRemove(t *testing.T) {
 		{args: wrapArgs(newTestList(1, 2, 3, 2, 1), 2), want: newTestList(1, 3, 2, 1).ToObject()},
 		{args: wrapArgs(NewList()), wantExc: mustCreateException(TypeErrorType, "'remove' of 'list' requires 2 arguments")},
 		{args: wrapArgs(NewList(), 1), wantExc: mustCreateException(ValueErrorType, "list.remove(x): x not in list")},
+		{args: wrapArgs(newTestList(nan, 1), nan), want: newTestList(1).ToObject()},
+		{args: wrapArgs(newTestList(1, 2), newObject(eqType)), want: newTestList(2).ToObject()},
 	}
 	for _, cas := range cases {
 		if err := runInvokeTestCase(fun, &cas); err != "" {
@@ -222,6 +254,9 @@ func TestListGetItem(t *testing.T) {
 }
 
 func TestListInplaceOps(t *testing.T) {
+	selfList := newTestList(1, 2).ToObject()
+	subList := newObject(newTestClass("Foo", []*Type{ListType}, NewDict()))
+	listAppend(NewRootFrame(), wrapArgs(subList, "foo"), nil)
 	cases := []struct {
 		fun     func(f *Frame, v, w *Object) (*Object, *BaseException)
 		v, w    *Object
@@ -231,6 +266,10 @@ func TestListInplaceOps(t *testing.T) {
 		{IAdd, newTestList(3).ToObject(), newTestList("foo").ToObject(), newTestList(3, "foo").ToObject(), nil},
 		{IAdd, NewList(None).ToObject(), NewList().ToObject(), NewList(None).ToObject(), nil},
 		{IAdd, NewList().ToObject(), newObject(ObjectType), nil, mustCreateException(TypeErrorType, "'object' object is not iterable")},
+		{IAdd, NewList().ToObject(), newTestTuple("foo", "bar").ToObject(), newTestList("foo", "bar").ToObject(), nil},
+		{IAdd, newTestList(1).ToObject(), newTestDict("foo", 2).ToObject(), newTestList(1, "foo").ToObject(), nil},
+		{IAdd, selfList, selfList, newTestList(1, 2, 1, 2).ToObject(), nil},
+		{IAdd, subList, subList, newTestList("foo", "foo").ToObject(), nil},
 		{IMul, NewList().ToObject(), NewInt(10).ToObject(), NewList().ToObject(), nil},
 		{IMul, newTestList("baz").ToObject(), NewInt(-2).ToObject(), NewList().ToObject(), nil},
 		{IMul, NewList().ToObject(), None, nil, mustCreateException(TypeErrorType, "can't multiply sequence by non-int of type 'NoneType'")},
@@ -296,6 +335,23 @@ func TestListExtend(t *testing.T) {
 		}
 		return args[0], nil
 	}).ToObject()
+	iterType := newTestClass("Iter", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__iter__": newBuiltinFunction("__iter__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return Iter(f, newTestList(1, 2).ToObject())
+		}).ToObject(),
+	}))
+	// A failing __len__ is only a hint: TypeError is ignored but other
+	// exceptions propagate.
+	typeErrorLenType := newTestClass("TypeErrorLen", []*Type{iterType}, newStringDict(map[string]*Object{
+		"__len__": newBuiltinFunction("__len__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return nil, f.RaiseType(TypeErrorType, "foo")
+		}).ToObject(),
+	}))
+	raisingLenType := newTestClass("RaisingLen", []*Type{iterType}, newStringDict(map[string]*Object{
+		"__len__": newBuiltinFunction("__len__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return nil, f.RaiseType(RuntimeErrorType, "foo")
+		}).ToObject(),
+	}))
 	cases := []invokeTestCase{
 		{args: wrapArgs(newTestList(), newTestTuple()), want: newTestList().ToObject()},
 		{args: wrapArgs(newTestList(), newTestList()), want: newTestList().ToObject()},
@@ -309,6 +365,11 @@ func TestListExtend(t *testing.T) {
 		{args: wrapArgs(newTestRange(5), newTestList(3)), want: newTestList(0, 1, 2, 3, 4, 3).ToObject()},
 		{args: wrapArgs(newTestTuple(1, 2, 3), newTestList(3)), wantExc: mustCreateException(TypeErrorType, "unbound method extend() must be called with list instance as first argument (got tuple instance instead)")},
 		{args: wrapArgs(newTestList(4), newTestTuple(1, 2, 3)), want: newTestList(4, 1, 2, 3).ToObject()},
+		{args: wrapArgs(newTestList(), "abc"), want: newTestList("a", "b", "c").ToObject()},
+		{args: wrapArgs(newTestList(1), newTestDict("foo", 2)), want: newTestList(1, "foo").ToObject()},
+		{args: wrapArgs(newTestList(1), newTestSet(2)), want: newTestList(1, 2).ToObject()},
+		{args: wrapArgs(newTestList(), newObject(typeErrorLenType)), want: newTestList(1, 2).ToObject()},
+		{args: wrapArgs(newTestList(), newObject(raisingLenType)), wantExc: mustCreateException(RuntimeErrorType, "foo")},
 		{args: wrapArgs(newTestList()), wantExc: mustCreateException(TypeErrorType, "extend() takes exactly one argument (1 given)")},
 		{args: wrapArgs(newTestList(), newTestTuple(), newTestTuple()), wantExc: mustCreateException(TypeErrorType, "extend() takes exactly one argument (3 given)")},
 	}
@@ -319,6 +380,38 @@ func TestListExtend(t *testing.T) {
 	}
 }
 
+func TestListExtendReservesLength(t *testing.T) {
+	l := NewList()
+	set := NewSet()
+	for i := 0; i < 100; i++ {
+		set.Add(NewRootFrame(), NewInt(i).ToObject())
+	}
+	if _, raised := listIAdd(NewRootFrame(), l.ToObject(), set.ToObject()); raised != nil {
+		t.Fatalf("listIAdd raised %v", raised)
+	}
+	if len(l.elems) != 100 || cap(l.elems) != 100 {
+		t.Errorf("extend with 100 elements gave len %d and cap %d, want 100 and 100", len(l.elems), cap(l.elems))
+	}
+}
+
+func TestListExtendHugeLengthHint(t *testing.T) {
+	liarType := newTestClass("Liar", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__iter__": newBuiltinFunction("__iter__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return Iter(f, newTestTuple(1, 2, 3).ToObject())
+		}).ToObject(),
+		"__len__": newBuiltinFunction("__len__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return NewInt(MaxInt).ToObject(), nil
+		}).ToObject(),
+	}))
+	l := NewList()
+	if _, raised := listIAdd(NewRootFrame(), l.ToObject(), newObject(liarType)); raised != nil {
+		t.Fatalf("listIAdd raised %v", raised)
+	}
+	if len(l.elems) != 3 || cap(l.elems) > listReserveLimit {
+		t.Errorf("extend with a length hint of MaxInt gave len %d and cap %d, want 3 and at most %d", len(l.elems), cap(l.elems), listReserveLimit)
+	}
+}
+
 func TestListLen(t *testing.T) {
 	cases := []invokeTestCase{
 		{args: wrapArgs(NewList()), want: NewInt(0).ToObject()},
@@ -481,6 +574,36 @@ func TestListPop(t *testing.T) {
 	}
 }
 
+func TestListDelItem(t *testing.T) {
+	fun := newBuiltinFunction("TestListDelItem", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+		if raised := checkFunctionArgs(f, "TestListDelItem", args, ListType, ObjectType); raised != nil {
+			return nil, raised
+		}
+		if raised := DelItem(f, args[0], args[1]); raised != nil {
+			return nil, raised
+		}
+		return args[0], nil
+	}).ToObject()
+	cases := []invokeTestCase{
+		{args: wrapArgs(newTestList("foo", "bar"), 0), want: newTestList("bar").ToObject()},
+		{args: wrapArgs(newTestList("foo", "bar"), -1), want: newTestList("foo").ToObject()},
+		{args: wrapArgs(newTestList(1, 2, 3), newTestSlice(1, None)), want: newTestList(1).ToObject()},
+		{args: wrapArgs(newTestList(1, 2, 3), newTestSlice(None, None)), want: NewList().ToObject()},
+		{args: wrapArgs(newTestList(1, 2, 3), newTestSlice(100, None)), want: newTestList(1, 2, 3).ToObject()},
+		{args: wrapArgs(newTestList(1, 2, 3, 4, 5), newTestSlice(None, None, 2)), want: newTestList(2, 4).ToObject()},
+		{args: wrapArgs(newTestList(1, 2, 3, 4, 5), newTestSlice(None, None, -2)), want: newTestList(2, 4).ToObject()},
+		{args: wrapArgs(newTestList(1, 2, 3), newTestSlice(4, 8, 0)), wantExc: mustCreateException(ValueErrorType, "slice step cannot be zero")},
+		{args: wrapArgs(newTestList("foo"), 1), wantExc: mustCreateException(IndexErrorType, "index out of range")},
+		{args: wrapArgs(NewList(), -1), wantExc: mustCreateException(IndexErrorType, "index out of range")},
+		{args: wrapArgs(newTestList(true), None), wantExc: mustCreateException(TypeErrorType, "list indices must be integers, not NoneType")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestListSetItem(t *testing.T) {
 	fun := newBuiltinFunction("TestListSetItem", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 		// Check that there is at least one arg, but otherwise leave
@@ -498,6 +621,7 @@ func TestListSetItem(t *testing.T) {
 		}
 		return args[0], nil
 	}).ToObject()
+	selfList := newTestList(0, 1, 2, 3)
 	cases := []invokeTestCase{
 		{args: wrapArgs(newTestList("foo", "bar"), 1, None), want: newTestList("foo", None).ToObject()},
 		{args: wrapArgs(newTestList(1, 2, 3), newTestSlice(0), newTestList(0)), want: newTestList(0, 1, 2, 3).ToObject()},
@@ -507,6 +631,7 @@ func TestListSetItem(t *testing.T) {
 		{args: wrapArgs(newTestList(1, 2, 4, 5), newTestSlice(1, None, 2), newTestTuple("foo", "bar")), want: newTestList(1, "foo", 4, "bar").ToObject()},
 		{args: wrapArgs(newTestList(1, 2, 3), newTestSlice(None, None, 2), newTestList("foo")), wantExc: mustCreateException(ValueErrorType, "attempt to assign sequence of size 1 to extended slice of size 2")},
 		{args: wrapArgs(newTestRange(100), newTestSlice(None, None), NewList()), want: NewList().ToObject()},
+		{args: wrapArgs(selfList, newTestSlice(None, None, -1), selfList), want: newTestList(3, 2, 1, 0).ToObject()},
 		{args: wrapArgs(NewList(), newTestSlice(4, 8, 0), NewList()), wantExc: mustCreateException(ValueErrorType, "slice step cannot be zero")},
 		{args: wrapArgs(newTestList("foo", "bar"), -100, None), wantExc: mustCreateException(IndexErrorType, "index out of range")},
 		{args: wrapArgs(NewList(), 101, None), wantExc: mustCreateException(IndexErrorType, "index out of range")},
@@ -521,20 +646,41 @@ func TestListSetItem(t *testing.T) {
 
 func TestListSort(t *testing.T) {
 	sort := mustNotRaise(GetAttr(NewRootFrame(), ListType.ToObject(), NewStr("sort"), nil))
-	fun := newBuiltinFunction("TestListSort", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
-		if _, raised := sort.Call(f, args, nil); raised != nil {
+	fun := newBuiltinFunction("TestListSort", func(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
+		if _, raised := sort.Call(f, args, kwargs); raised != nil {
 			return nil, raised
 		}
 		return args[0], nil
 	}).ToObject()
+	first := wrapFuncForTest(func(f *Frame, t *Tuple) *Object { return t.GetItem(0) })
+	revCmp := wrapFuncForTest(func(f *Frame, a, b int) int { return b - a })
+	raiseKey := wrapFuncForTest(func(f *Frame, o *Object) *BaseException { return f.RaiseType(RuntimeErrorType, "foo") })
+	badCmp := wrapFuncForTest(func(f *Frame, a, b *Object) string { return "foo" })
+	raiseLTType := newTestClass("RaiseLT", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__lt__": newBuiltinFunction("__lt__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return nil, f.RaiseType(RuntimeErrorType, "foo")
+		}).ToObject(),
+	}))
 	cases := []invokeTestCase{
 		{args: wrapArgs(NewList()), want: NewList().ToObject()},
 		{args: wrapArgs(newTestList("foo", "bar")), want: newTestList("bar", "foo").ToObject()},
 		{args: wrapArgs(newTestList(true, false)), want: newTestList(false, true).ToObject()},
 		{args: wrapArgs(newTestList(1, 2, 0, 3)), want: newTestRange(4).ToObject()},
 		{args: wrapArgs(newTestRange(100)), want: newTestRange(100).ToObject()},
+		{args: wrapArgs(newTestList(newTestTuple(2, "a"), newTestTuple(1, "b"), newTestTuple(2, "c"))), kwargs: wrapKWArgs("key", first), want: newTestList(newTestTuple(1, "b"), newTestTuple(2, "a"), newTestTuple(2, "c")).ToObject()},
+		{args: wrapArgs(newTestList(newTestTuple(2, "a"), newTestTuple(1, "b"), newTestTuple(2, "c"))), kwargs: wrapKWArgs("key", first, "reverse", true), want: newTestList(newTestTuple(2, "a"), newTestTuple(2, "c"), newTestTuple(1, "b")).ToObject()},
+		{args: wrapArgs(newTestList(1, 3, 2)), kwargs: wrapKWArgs("cmp", revCmp), want: newTestList(3, 2, 1).ToObject()},
+		{args: wrapArgs(newTestList(1, 3, 2)), kwargs: wrapKWArgs("cmp", badCmp), wantExc: mustCreateException(TypeErrorType, "comparison function must return int, not str")},
+		{args: wrapArgs(newTestList(1, 2)), kwargs: wrapKWArgs("key", raiseKey), wantExc: mustCreateException(RuntimeErrorType, "foo")},
+		{args: wrapArgs(newTestList(3, NewComplex(1i), 2, NewComplex(2i))), wantExc: mustCreateException(TypeErrorType, "no ordering relation is defined for complex numbers")},
+		{args: wrapArgs(newTestList(newObject(raiseLTType), newObject(raiseLTType))), wantExc: mustCreateException(RuntimeErrorType, "foo")},
 		{args: wrapArgs(1), wantExc: mustCreateException(TypeErrorType, "unbound method sort() must be called with list instance as first argument (got int instance instead)")},
-		{args: wrapArgs(NewList(), 1), wantExc: mustCreateException(TypeErrorType, "'sort' of 'list' requires 1 arguments")},
+		{args: wrapArgs(newTestList(1, 3, 2), revCmp), want: newTestList(3, 2, 1).ToObject()},
+		{args: wrapArgs(newTestList(1, 3, 2), None, None, true), want: newTestList(3, 2, 1).ToObject()},
+		{args: wrapArgs(NewList(), 1), want: NewList().ToObject()},
+		{args: wrapArgs(NewList(), None, None, false, None), wantExc: mustCreateException(TypeErrorType, "sort() takes at most 3 arguments (4 given)")},
+		{args: wrapArgs(NewList(), None), kwargs: wrapKWArgs("cmp", None), wantExc: mustCreateException(TypeErrorType, "Argument given by name ('cmp') and position (1)")},
+		{args: wrapArgs(NewList()), kwargs: wrapKWArgs("foo", None), wantExc: mustCreateException(TypeErrorType, "'foo' is an invalid keyword argument for this function")},
 	}
 	for _, cas := range cases {
 		if err := runInvokeTestCase(fun, &cas); err != "" {
@@ -543,6 +689,37 @@ func TestListSort(t *testing.T) {
 	}
 }
 
+func TestListSortModified(t *testing.T) {
+	l := newTestList(3, 1, 2)
+	lens := NewList()
+	appendKey := wrapFuncForTest(func(f *Frame, o *Object) (*Object, *BaseException) {
+		n, raised := Len(f, l.ToObject())
+		if raised != nil {
+			return nil, raised
+		}
+		lens.Append(n.ToObject())
+		l.Append(None)
+		return o, nil
+	})
+	fun := wrapFuncForTest(func(f *Frame) (*Tuple, *BaseException) {
+		raised := l.sort(f, nil, appendKey, false)
+		if raised == nil {
+			return nil, f.RaiseType(AssertionErrorType, "expected ValueError")
+		}
+		if !raised.isInstance(ValueErrorType) {
+			return nil, raised
+		}
+		f.RestoreExc(nil, nil)
+		return NewTuple2(lens.ToObject(), l.ToObject()), nil
+	})
+	// The list looks empty while being sorted and appends made by the key
+	// function are discarded in favor of the sorted elements.
+	cas := invokeTestCase{want: newTestTuple(newTestList(0, 1, 2), newTestList(1, 2, 3)).ToObject()}
+	if err := runInvokeTestCase(fun, &cas); err != "" {
+		t.Error(err)
+	}
+}
+
 func newTestRange(n int) *List {
 	elems := make([]*Object, n)
 	for i := 0; i < n; i++ {
@@ -18,9 +18,11 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"math/bits"
 	"reflect"
 	"strings"
 	"sync"
+	"unicode"
 )
 
 // By convention in this file, we always use the variable
@@ -124,6 +126,62 @@ func longGE(x, y *big.Int) bool {
 	return x.Cmp(y) >= 0
 }
 
+func longCoerceMethod(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "__coerce__", args, LongType, ObjectType); raised != nil {
+		return nil, raised
+	}
+	w := args[1]
+	switch {
+	case w.isInstance(LongType):
+		return NewTuple2(args[0], w).ToObject(), nil
+	case w.isInstance(IntType):
+		return NewTuple2(args[0], intToLong(toIntUnsafe(w)).ToObject()).ToObject(), nil
+	}
+	return NotImplemented, nil
+}
+
+func longConjugate(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "conjugate", args, LongType); raised != nil {
+		return nil, raised
+	}
+	return longLong(f, args[0])
+}
+
+func longGetDenominator(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_get_denominator", args, LongType); raised != nil {
+		return nil, raised
+	}
+	return NewLong(big.NewInt(1)).ToObject(), nil
+}
+
+func longGetImag(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_get_imag", args, LongType); raised != nil {
+		return nil, raised
+	}
+	return NewLong(big.NewInt(0)).ToObject(), nil
+}
+
+func longGetNumerator(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_get_numerator", args, LongType); raised != nil {
+		return nil, raised
+	}
+	return longLong(f, args[0])
+}
+
+func longGetReal(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "_get_real", args, LongType); raised != nil {
+		return nil, raised
+	}
+	return longLong(f, args[0])
+}
+
+func longBitLength(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "bit_length", args, LongType); raised != nil {
+		return nil, raised
+	}
+	return NewInt(toLongUnsafe(args[0]).value.BitLen()).ToObject(), nil
+}
+
 func longGetNewArgs(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	if raised := checkMethodArgs(f, "__getnewargs__", args, LongType); raised != nil {
 		return nil, raised
@@ -143,9 +201,38 @@ func longFloat(f *Frame, o *Object) (*Object, *BaseException) {
 	return NewFloat(flt).ToObject(), nil
 }
 
+// hashBigInt computes the hash of x the same way as CPython's long_hash does
+// with 30 bit digits, so that it's consistent with int and float hashes.
 func hashBigInt(x *big.Int) int {
-	// TODO: Make this hash match that of cpython.
-	return hashString(x.Text(36))
+	if numInIntRange(x) {
+		return hashInt(int(x.Int64()))
+	}
+	const shift = 30
+	words := new(big.Int).Abs(x).Bits()
+	digit := func(i int) uint64 {
+		pos := i * shift
+		w, off := pos/bits.UintSize, uint(pos%bits.UintSize)
+		d := uint64(words[w]) >> off
+		if off+shift > bits.UintSize && w+1 < len(words) {
+			d |= uint64(words[w+1]) << (bits.UintSize - off)
+		}
+		return d & (1<<shift - 1)
+	}
+	// Accumulate the absolute value modulo 2**64 - 1 by rotating in the
+	// digits from most to least significant.
+	var h uint64
+	for i := (x.BitLen()+shift-1)/shift - 1; i >= 0; i-- {
+		d := digit(i)
+		h = h>>(64-shift) | h<<shift
+		h += d
+		if h < d {
+			h++
+		}
+	}
+	if x.Sign() < 0 {
+		h = -h
+	}
+	return hashInt(int(int64(h)))
 }
 
 func longHex(f *Frame, o *Object) (*Object, *BaseException) {
@@ -156,10 +243,6 @@ func longHex(f *Frame, o *Object) (*Object, *BaseException) {
 func longHash(f *Frame, o *Object) (*Object, *BaseException) {
 	l := toLongUnsafe(o)
 	l.hashOnce.Do(func() {
-		// Be compatible with int hashes.
-		if numInIntRange(&l.value) {
-			l.hash = int(l.value.Int64())
-		}
 		l.hash = hashBigInt(&l.value)
 	})
 	return NewInt(l.hash).ToObject(), nil
@@ -261,36 +344,18 @@ func longNew(f *Frame, t *Type, args Args, _ KWArgs) (*Object, *BaseException) {
 		}
 		baseArg = toIntUnsafe(args[1]).Value()
 		if baseArg != 0 && (baseArg < 2 || baseArg > 36) {
-			return nil, f.RaiseType(ValueErrorType, "long() base must be >= 2 and <= 36")
+			return nil, f.RaiseType(ValueErrorType, "long() base must be >= 2 and <= 36, or 0")
 		}
 	}
 	s := strings.TrimSpace(toStrUnsafe(o).Value())
-	if len(s) > 0 && (s[len(s)-1] == 'L' || s[len(s)-1] == 'l') {
-		s = s[:len(s)-1]
-	}
-	base := baseArg
-	if len(s) > 2 {
-		detectedBase := 0
-		switch s[:2] {
-		case "0b", "0B":
-			detectedBase = 2
-		case "0o", "0O":
-			detectedBase = 8
-		case "0x", "0X":
-			detectedBase = 16
-		}
-		if detectedBase != 0 && (baseArg == 0 || baseArg == detectedBase) {
-			s = s[2:]
-			base = detectedBase
-		}
-	}
-	if base == 0 {
-		base = 10
+	// Unlike int(), long() accepts an L suffix, except in bases where L is
+	// a digit.
+	if n := len(s); n > 1 && (s[n-1] == 'L' || s[n-1] == 'l') && !unicode.IsSpace(rune(s[n-2])) && baseArg < 22 {
+		s = s[:n-1]
 	}
 	i := big.Int{}
-	if _, ok := i.SetString(s, base); !ok {
-		format := "invalid literal for long() with base %d: %s"
-		return nil, f.RaiseType(ValueErrorType, fmt.Sprintf(format, baseArg, toStrUnsafe(o).Value()))
+	if _, base, ok := numParseInteger(&i, s, baseArg); !ok {
+		return nil, numInvalidLiteral(f, "long", base, toStrUnsafe(o).Value())
 	}
 	return NewLong(&i).ToObject(), nil
 }
@@ -331,12 +396,31 @@ func longSub(z, x, y *big.Int) {
 	z.Sub(x, y)
 }
 
+// longTrueDivOp returns x / y as a correctly rounded float.
+func longTrueDivOp(f *Frame, x, y *big.Int) (*Object, *BaseException) {
+	if y.Sign() == 0 {
+		return nil, f.RaiseType(ZeroDivisionErrorType, "division by zero")
+	}
+	r, _ := new(big.Rat).SetFrac(x, y).Float64()
+	if math.IsInf(r, 0) {
+		return nil, f.RaiseType(OverflowErrorType, "integer division result too large for a float")
+	}
+	return NewFloat(r).ToObject(), nil
+}
+
 func longXor(z, x, y *big.Int) {
 	z.Xor(x, y)
 }
 
 func initLongType(dict map[string]*Object) {
+	dict["__coerce__"] = newBuiltinFunction("__coerce__", longCoerceMethod).ToObject()
 	dict["__getnewargs__"] = newBuiltinFunction("__getnewargs__", longGetNewArgs).ToObject()
+	dict["bit_length"] = newBuiltinFunction("bit_length", longBitLength).ToObject()
+	dict["conjugate"] = newBuiltinFunction("conjugate", longConjugate).ToObject()
+	dict["denominator"] = newProperty(newBuiltinFunction("_get_denominator", longGetDenominator).ToObject(), nil, nil).ToObject()
+	dict["imag"] = newProperty(newBuiltinFunction("_get_imag", longGetImag).ToObject(), nil, nil).ToObject()
+	dict["numerator"] = newProperty(newBuiltinFunction("_get_numerator", longGetNumerator).ToObject(), nil, nil).ToObject()
+	dict["real"] = newProperty(newBuiltinFunction("_get_real", longGetReal).ToObject(), nil, nil).ToObject()
 	LongType.slots.Abs = longUnaryOpSlot(longAbs)
 	LongType.slots.Add = longBinaryOpSlot(longAdd)
 	LongType.slots.And = longBinaryOpSlot(longAnd)
@@ -383,9 +467,13 @@ func initLongType(dict map[string]*Object) {
 	LongType.slots.RRShift = longRShiftOpSlot(longRShift)
 	LongType.slots.RShift = longShiftOpSlot(longRShift)
 	LongType.slots.RSub = longRBinaryOpSlot(longSub)
+	// This operation returns a float, it must use binaryOpSlot directly.
+	LongType.slots.RTrueDiv = &binaryOpSlot{longRTrueDiv}
 	LongType.slots.RXor = longRBinaryOpSlot(longXor)
 	LongType.slots.Str = &unaryOpSlot{longStr}
 	LongType.slots.Sub = longBinaryOpSlot(longSub)
+	// This operation returns a float, it must use binaryOpSlot directly.
+	LongType.slots.TrueDiv = &binaryOpSlot{longTrueDiv}
 	LongType.slots.Xor = longBinaryOpSlot(longXor)
 }
 
@@ -615,6 +703,56 @@ func longPow(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return NewLong(big.NewInt(0).Exp(vLong, wLong, nil)).ToObject(), nil
 }
 
+// longPowMod implements pow(v, w, z) for int and long arguments. The result is
+// an int unless one of the arguments is a long.
+func longPowMod(f *Frame, v, w, z *Object) (*Object, *BaseException) {
+	isLong := false
+	values := make([]*big.Int, 3)
+	for i, o := range []*Object{v, w, z} {
+		if o.isInstance(LongType) {
+			isLong = true
+			values[i] = toLongUnsafe(o).Value()
+		} else {
+			values[i] = big.NewInt(int64(toIntUnsafe(o).Value()))
+		}
+	}
+	x, y, m := values[0], values[1], values[2]
+	if y.Sign() < 0 {
+		return nil, f.RaiseType(TypeErrorType, "pow() 2nd argument cannot be negative when 3rd argument specified")
+	}
+	if m.Sign() == 0 {
+		return nil, f.RaiseType(ValueErrorType, "pow() 3rd argument cannot be 0")
+	}
+	// Exp ignores the sign of m and yields a result in [0, |m|), whereas
+	// in Python the result takes the sign of the modulus.
+	r := new(big.Int).Exp(x, y, m)
+	if m.Sign() < 0 && r.Sign() != 0 {
+		r.Add(r, m)
+	}
+	if isLong {
+		return NewLong(r).ToObject(), nil
+	}
+	return NewInt(int(r.Int64())).ToObject(), nil
+}
+
+func longRTrueDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
+	if w.isInstance(IntType) {
+		w = intToLong(toIntUnsafe(w)).ToObject()
+	} else if !w.isInstance(LongType) {
+		return NotImplemented, nil
+	}
+	return longTrueDivOp(f, toLongUnsafe(w).Value(), toLongUnsafe(v).Value())
+}
+
+func longTrueDiv(f *Frame, v, w *Object) (*Object, *BaseException) {
+	if w.isInstance(IntType) {
+		w = intToLong(toIntUnsafe(w)).ToObject()
+	} else if !w.isInstance(LongType) {
+		return NotImplemented, nil
+	}
+	return longTrueDivOp(f, toLongUnsafe(v).Value(), toLongUnsafe(w).Value())
+}
+
 func longRPow(f *Frame, v, w *Object) (*Object, *BaseException) {
 	if w.isInstance(LongType) {
 		return longPow(f, w, v)
@@ -5,6 +5,7 @@ import (
 	"math"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -87,17 +88,26 @@ func TestLongNew(t *testing.T) {
 		{args: wrapArgs(LongType, "0b101L", 0), want: NewLong(big.NewInt(5)).ToObject()},
 		{args: wrapArgs(LongType, "0o726", 0), want: NewLong(big.NewInt(470)).ToObject()},
 		{args: wrapArgs(LongType, "102", 0), want: NewLong(big.NewInt(102)).ToObject()},
+		{args: wrapArgs(LongType, "  10  ", 2), want: NewLong(big.NewInt(2)).ToObject()},
+		{args: wrapArgs(LongType, "-0b1010", 0), want: NewLong(big.NewInt(-10)).ToObject()},
+		{args: wrapArgs(LongType, "017", 0), want: NewLong(big.NewInt(15)).ToObject()},
+		{args: wrapArgs(LongType, "10L", 16), want: NewLong(big.NewInt(16)).ToObject()},
+		{args: wrapArgs(LongType, "10L", 36), want: NewLong(big.NewInt(1317)).ToObject()},
+		{args: wrapArgs(LongType, "09", 0), wantExc: mustCreateException(ValueErrorType, "invalid literal for long() with base 8: '09'")},
+		{args: wrapArgs(LongType, "0b", 0), wantExc: mustCreateException(ValueErrorType, "invalid literal for long() with base 2: '0b'")},
+		{args: wrapArgs(LongType, "10 L"), wantExc: mustCreateException(ValueErrorType, "invalid literal for long() with base 10: '10 L'")},
+		{args: wrapArgs(LongType, "1_000"), wantExc: mustCreateException(ValueErrorType, "invalid literal for long() with base 10: '1_000'")},
 		{args: wrapArgs(LongType, 42), want: NewLong(big.NewInt(42)).ToObject()},
 		{args: wrapArgs(LongType, -3.14), want: NewLong(big.NewInt(-3)).ToObject()},
 		{args: wrapArgs(LongType, newObject(longSubType)), want: NewLong(big.NewInt(0)).ToObject()},
 		{args: wrapArgs(strictEqType, big.NewInt(42)), want: newStrictEq(big.NewInt(42))},
-		{args: wrapArgs(LongType, "0xff"), wantExc: mustCreateException(ValueErrorType, "invalid literal for long() with base 10: 0xff")},
-		{args: wrapArgs(LongType, ""), wantExc: mustCreateException(ValueErrorType, "invalid literal for long() with base 10: ")},
-		{args: wrapArgs(LongType, " "), wantExc: mustCreateException(ValueErrorType, "invalid literal for long() with base 10:  ")},
+		{args: wrapArgs(LongType, "0xff"), wantExc: mustCreateException(ValueErrorType, "invalid literal for long() with base 10: '0xff'")},
+		{args: wrapArgs(LongType, ""), wantExc: mustCreateException(ValueErrorType, "invalid literal for long() with base 10: ''")},
+		{args: wrapArgs(LongType, " "), wantExc: mustCreateException(ValueErrorType, "invalid literal for long() with base 10: ' '")},
 		{args: wrapArgs(FloatType), wantExc: mustCreateException(TypeErrorType, "long.__new__(float): float is not a subtype of long")},
-		{args: wrapArgs(LongType, "asldkfj", 1), wantExc: mustCreateException(ValueErrorType, "long() base must be >= 2 and <= 36")},
-		{args: wrapArgs(LongType, "asldkfj", 37), wantExc: mustCreateException(ValueErrorType, "long() base must be >= 2 and <= 36")},
-		{args: wrapArgs(LongType, "@#%*(#", 36), wantExc: mustCreateException(ValueErrorType, "invalid literal for long() with base 36: @#%*(#")},
+		{args: wrapArgs(LongType, "asldkfj", 1), wantExc: mustCreateException(ValueErrorType, "long() base must be >= 2 and <= 36, or 0")},
+		{args: wrapArgs(LongType, "asldkfj", 37), wantExc: mustCreateException(ValueErrorType, "long() base must be >= 2 and <= 36, or 0")},
+		{args: wrapArgs(LongType, "@#%*(#", 36), wantExc: mustCreateException(ValueErrorType, "invalid literal for long() with base 36: '@#%*(#'")},
 		{args: wrapArgs(LongType, "32059823095809238509238590835"), want: NewLong(func() *big.Int { i, _ := new(big.Int).SetString("32059823095809238509238590835", 0); return i }()).ToObject()},
 		{args: wrapArgs(LongType, big.NewInt(3)), want: NewLong(big.NewInt(3)).ToObject()},
 		{args: wrapArgs(LongType, NewInt(3)), want: NewLong(big.NewInt(3)).ToObject()},
@@ -126,6 +136,8 @@ func TestLongBinaryOps(t *testing.T) {
 		{Add, MaxInt, 1, NewLong(new(big.Int).Add(maxIntBig, big.NewInt(1))).ToObject(), nil},
 		{And, -100, 50, NewLong(big.NewInt(16)).ToObject(), nil},
 		{And, MaxInt, MinInt, NewLong(big.NewInt(0)).ToObject(), nil},
+		{And, -1, 0xff, NewLong(big.NewInt(0xff)).ToObject(), nil},
+		{And, new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(3), 100)), new(big.Int).Lsh(big.NewInt(0xff), 96), NewLong(new(big.Int).Lsh(big.NewInt(0xd0), 96)).ToObject(), nil},
 		{And, newObject(ObjectType), -100, nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for &: 'object' and 'long'")},
 		{Div, 7, 3, NewLong(big.NewInt(2)).ToObject(), nil},
 		{Div, MaxInt, MinInt, NewLong(big.NewInt(-1)).ToObject(), nil},
@@ -150,16 +162,25 @@ func TestLongBinaryOps(t *testing.T) {
 		{LShift, 2, 4, NewLong(big.NewInt(32)).ToObject(), nil},
 		{LShift, 12, 10, NewLong(big.NewInt(12288)).ToObject(), nil},
 		{LShift, 10, 100, NewLong(new(big.Int).Lsh(big.NewInt(10), 100)).ToObject(), nil},
+		{LShift, -1, 1000, NewLong(new(big.Int).Lsh(big.NewInt(-1), 1000)).ToObject(), nil},
+		{LShift, 1, new(big.Int).Lsh(big.NewInt(1), 70), nil, mustCreateException(OverflowErrorType, "long int too large to convert to int")},
 		{LShift, 2, -5, nil, mustCreateException(ValueErrorType, "negative shift count")},
 		{LShift, 4, NewFloat(3.14).ToObject(), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for <<: 'long' and 'float'")},
 		{LShift, newObject(ObjectType), 4, nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for <<: 'object' and 'long'")},
 		{RShift, 87, 3, NewLong(big.NewInt(10)).ToObject(), nil},
 		{RShift, -101, 5, NewLong(big.NewInt(-4)).ToObject(), nil},
 		{RShift, 12, NewInt(10).ToObject(), NewLong(big.NewInt(0)).ToObject(), nil},
+		{RShift, new(big.Int).Lsh(big.NewInt(1), 1000), 999, NewLong(big.NewInt(2)).ToObject(), nil},
+		{RShift, new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 100)), 99, NewLong(big.NewInt(-2)).ToObject(), nil},
+		{RShift, new(big.Int).Lsh(big.NewInt(-3), 100), 1000, NewLong(big.NewInt(-1)).ToObject(), nil},
 		{RShift, 12, -10, nil, mustCreateException(ValueErrorType, "negative shift count")},
 		{RShift, 4, NewFloat(3.14).ToObject(), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for >>: 'long' and 'float'")},
 		{RShift, newObject(ObjectType), 4, nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for >>: 'object' and 'long'")},
 		{RShift, 4, newObject(ObjectType), nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for >>: 'long' and 'object'")},
+		{Mod, 7, 3, NewLong(big.NewInt(1)).ToObject(), nil},
+		{Mod, -7, 3, NewLong(big.NewInt(2)).ToObject(), nil},
+		{Mod, 7, -3, NewLong(big.NewInt(-2)).ToObject(), nil},
+		{Mod, -7, -3, NewLong(big.NewInt(-1)).ToObject(), nil},
 		{Mod, 3, -7, NewLong(big.NewInt(-4)).ToObject(), nil},
 		{Mod, MaxInt, MinInt, NewLong(big.NewInt(-1)).ToObject(), nil},
 		{Mod, MinInt, MaxInt, NewLong(big.NewInt(int64(MaxInt) - 1)).ToObject(), nil},
@@ -171,6 +192,7 @@ func TestLongBinaryOps(t *testing.T) {
 		{Mul, int64(4294967295), int64(2147483649), NewLong(new(big.Int).Mul(big.NewInt(4294967295), big.NewInt(2147483649))).ToObject(), nil},
 		{Or, -100, 50, NewLong(big.NewInt(-66)).ToObject(), nil},
 		{Or, MaxInt, MinInt, NewLong(big.NewInt(-1)).ToObject(), nil},
+		{Or, -5, new(big.Int).Lsh(big.NewInt(1), 70), NewLong(big.NewInt(-5)).ToObject(), nil},
 		{Or, newObject(ObjectType), 100, nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for |: 'object' and 'long'")},
 		{Pow, 2, 128, NewLong(big.NewInt(0).Exp(big.NewInt(2), big.NewInt(128), nil)).ToObject(), nil},
 		{Pow, 2, -2, NewFloat(0.25).ToObject(), nil},
@@ -178,8 +200,15 @@ func TestLongBinaryOps(t *testing.T) {
 		{Sub, 22, 18, NewLong(big.NewInt(4)).ToObject(), nil},
 		{Sub, IntType.ToObject(), 42, nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for -: 'type' and 'long'")},
 		{Sub, MinInt, 1, NewLong(new(big.Int).Sub(minIntBig, big.NewInt(1))).ToObject(), nil},
+		{TrueDiv, 5, 2, NewFloat(2.5).ToObject(), nil},
+		{TrueDiv, NewInt(-7).ToObject(), 2, NewFloat(-3.5).ToObject(), nil},
+		{TrueDiv, new(big.Int).Lsh(big.NewInt(1), 1100), new(big.Int).Lsh(big.NewInt(1), 1099), NewFloat(2).ToObject(), nil},
+		{TrueDiv, new(big.Int).Lsh(big.NewInt(1), 1100), 3, nil, mustCreateException(OverflowErrorType, "integer division result too large for a float")},
+		{TrueDiv, 1, 0, nil, mustCreateException(ZeroDivisionErrorType, "division by zero")},
+		{TrueDiv, NewList().ToObject(), 21, nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for /: 'list' and 'long'")},
 		{Xor, -100, 50, NewLong(big.NewInt(-82)).ToObject(), nil},
 		{Xor, MaxInt, MinInt, NewLong(big.NewInt(-1)).ToObject(), nil},
+		{Xor, -5, new(big.Int).Lsh(big.NewInt(1), 70), NewLong(new(big.Int).Sub(big.NewInt(-5), new(big.Int).Lsh(big.NewInt(1), 70))).ToObject(), nil},
 		{Xor, newObject(ObjectType), 100, nil, mustCreateException(TypeErrorType, "unsupported operand type(s) for ^: 'object' and 'long'")},
 	}
 	for _, cas := range cases {
@@ -218,6 +247,66 @@ func TestLongBinaryOps(t *testing.T) {
 	}
 }
 
+func TestLongBitLength(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(big.NewInt(0)), want: NewInt(0).ToObject()},
+		{args: wrapArgs(big.NewInt(-37)), want: NewInt(6).ToObject()},
+		{args: wrapArgs(new(big.Int).Lsh(big.NewInt(1), 100)), want: NewInt(101).ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(LongType, "bit_length", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestLongRationalAttrs(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, o *Object) (*Object, *BaseException) {
+		var elems []*Object
+		for _, name := range []string{"numerator", "denominator", "real", "imag"} {
+			attr, raised := GetAttr(f, o, NewStr(name), nil)
+			if raised != nil {
+				return nil, raised
+			}
+			if attr.typ != LongType {
+				return nil, f.RaiseType(TypeErrorType, fmt.Sprintf("%s is a %s", name, attr.typ.Name()))
+			}
+			elems = append(elems, attr)
+		}
+		method, raised := GetAttr(f, o, NewStr("conjugate"), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		conjugate, raised := method.Call(f, nil, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return NewTuple(append(elems, conjugate)...).ToObject(), nil
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(big.NewInt(42)), want: newTestTuple(big.NewInt(42), big.NewInt(1), big.NewInt(42), big.NewInt(0), big.NewInt(42)).ToObject()},
+		{args: wrapArgs(new(big.Int).Lsh(big.NewInt(1), 100)), want: newTestTuple(new(big.Int).Lsh(big.NewInt(1), 100), big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), 100), big.NewInt(0), new(big.Int).Lsh(big.NewInt(1), 100)).ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestLongRationalAttrGetters(t *testing.T) {
+	f := NewRootFrame()
+	for _, name := range []string{"numerator", "denominator", "real", "imag"} {
+		prop := mustNotRaise(GetAttr(f, LongType.ToObject(), NewStr(name), nil))
+		fget := mustNotRaise(GetAttr(f, prop, NewStr("fget"), nil))
+		msg := fmt.Sprintf("'_get_%s' requires a 'long' object but received a 'str'", name)
+		cas := invokeTestCase{args: wrapArgs("foo"), wantExc: mustCreateException(TypeErrorType, msg)}
+		if err := runInvokeTestCase(fget, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestLongCompare(t *testing.T) {
 	// Equivalence classes of sample numbers, sorted from least to greatest, nil-separated
 	googol, _ := big.NewFloat(1e100).Int(nil)
@@ -252,12 +341,33 @@ func TestLongCompare(t *testing.T) {
 	}
 }
 
+func TestLongHash(t *testing.T) {
+	googol, _ := new(big.Int).SetString("1"+strings.Repeat("0", 30), 10)
+	cases := []invokeTestCase{
+		{args: wrapArgs(big.NewInt(42)), want: NewInt(42).ToObject()},
+		{args: wrapArgs(big.NewInt(-1)), want: NewInt(-2).ToObject()},
+		{args: wrapArgs(new(big.Int).Lsh(big.NewInt(1), 63)), want: NewInt(MinInt).ToObject()},
+		{args: wrapArgs(new(big.Int).Lsh(big.NewInt(1), 64)), want: NewInt(1).ToObject()},
+		{args: wrapArgs(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(1))), want: NewInt(-2).ToObject()},
+		{args: wrapArgs(new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 64))), want: NewInt(-2).ToObject()},
+		{args: wrapArgs(new(big.Int).Lsh(big.NewInt(1), 100)), want: NewInt(68719476736).ToObject()},
+		{args: wrapArgs(new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 100))), want: NewInt(-68719476736).ToObject()},
+		{args: wrapArgs(googol), want: NewInt(5076944324515372240).ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(wrapFuncForTest(longHash), &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestLongInvert(t *testing.T) {
 	googol, _ := big.NewFloat(1e100).Int(nil)
 	cases := []invokeTestCase{
 		{args: wrapArgs(big.NewInt(2592)), want: NewLong(big.NewInt(-2593)).ToObject()},
 		{args: wrapArgs(big.NewInt(0)), want: NewLong(big.NewInt(-1)).ToObject()},
 		{args: wrapArgs(big.NewInt(-43)), want: NewLong(big.NewInt(42)).ToObject()},
+		{args: wrapArgs(big.NewInt(-1)), want: NewLong(big.NewInt(0)).ToObject()},
 		{args: wrapArgs(maxIntBig), want: NewLong(minIntBig).ToObject()},
 		{args: wrapArgs(minIntBig), want: NewLong(maxIntBig).ToObject()},
 		{args: wrapArgs(googol),
@@ -22,10 +22,10 @@ import (
 // Method represents Python 'instancemethod' objects.
 type Method struct {
 	Object
-	function *Function
-	self     *Object
-	class    *Type
-	name     string `attr:"__name__"`
+	function *Function `attr:"im_func"`
+	self     *Object   `attr:"im_self"`
+	class    *Type     `attr:"im_class"`
+	name     string    `attr:"__name__"`
 }
 
 // NewMethod returns a method wrapping the given function belonging to class.
@@ -68,6 +68,40 @@ func methodCall(f *Frame, callable *Object, args Args, kwargs KWArgs) (*Object,
 	return m.function.Call(f, methodArgs, kwargs)
 }
 
+func methodGet(f *Frame, desc, instance *Object, owner *Type) (*Object, *BaseException) {
+	m := toMethodUnsafe(desc)
+	if m.self != None {
+		// Don't rebind an already bound method.
+		return desc, nil
+	}
+	if m.class != nil && owner != nil {
+		// Nor an unbound method of a class unrelated to owner. Like
+		// CPython, the check honors __subclasscheck__ so that methods
+		// of ABCs bind to registered virtual subclasses.
+		related, raised := IsSubclass(f, owner.ToObject(), m.class.ToObject())
+		if raised != nil {
+			return nil, raised
+		}
+		if !related {
+			return desc, nil
+		}
+	}
+	// Like CPython, an unbound method stored as a class attribute binds to
+	// instances of that class, e.g. "update = MutableMapping.update".
+	return NewMethod(m.function, instance, owner).ToObject(), nil
+}
+
+func methodGetAttribute(f *Frame, o *Object, name *Str) (*Object, *BaseException) {
+	attr, raised := objectGetAttribute(f, o, name)
+	if raised != nil && raised.isInstance(AttributeErrorType) {
+		// Like CPython, fall back to the attributes of the underlying
+		// function, e.g. __isabstractmethod__.
+		f.RestoreExc(nil, nil)
+		return GetAttr(f, toMethodUnsafe(o).function.ToObject(), name, nil)
+	}
+	return attr, raised
+}
+
 func methodRepr(f *Frame, o *Object) (*Object, *BaseException) {
 	m := toMethodUnsafe(o)
 	s := ""
@@ -83,9 +117,13 @@ func methodRepr(f *Frame, o *Object) (*Object, *BaseException) {
 	return NewStr(s).ToObject(), nil
 }
 
-func initMethodType(map[string]*Object) {
+func initMethodType(dict map[string]*Object) {
+	dict["__func__"] = makeStructFieldDescriptor(MethodType, "function", "__func__")
+	dict["__self__"] = makeStructFieldDescriptor(MethodType, "self", "__self__")
 	// TODO: Should be instantiable.
 	MethodType.flags &= ^(typeFlagBasetype | typeFlagInstantiable)
 	MethodType.slots.Call = &callSlot{methodCall}
+	MethodType.slots.Get = &getSlot{methodGet}
+	MethodType.slots.GetAttribute = &getAttributeSlot{methodGetAttribute}
 	MethodType.slots.Repr = &unaryOpSlot{methodRepr}
 }
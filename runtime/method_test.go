@@ -42,6 +42,66 @@ func TestMethodCall(t *testing.T) {
 	}
 }
 
+func TestMethodGet(t *testing.T) {
+	fun := NewFunction(NewCode("foo", "foo.py", nil, CodeFlagVarArg, func(f *Frame, args []*Object) (*Object, *BaseException) {
+		return args[0], nil
+	}), nil)
+	fooType := newTestClass("Foo", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"bound":   NewMethod(fun, NewInt(42).ToObject(), IntType).ToObject(),
+		"unbound": NewMethod(fun, None, ObjectType).ToObject(),
+		"foreign": NewMethod(fun, None, IntType).ToObject(),
+	}))
+	foo := newObject(fooType)
+	callAttr := wrapFuncForTest(func(f *Frame, o *Object, name *Str) (*Object, *BaseException) {
+		method, raised := GetAttr(f, o, name, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return method.Call(f, nil, nil)
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(foo, "bound"), want: newTestTuple(42).ToObject()},
+		{args: wrapArgs(foo, "unbound"), want: NewTuple(foo).ToObject()},
+		{args: wrapArgs(fooType, "unbound"), wantExc: mustCreateException(TypeErrorType, "unbound method foo() must be called with Foo instance as first argument (got nothing instead)")},
+		{args: wrapArgs(foo, "foreign"), wantExc: mustCreateException(TypeErrorType, "unbound method foo() must be called with int instance as first argument (got nothing instead)")},
+		{args: wrapArgs(fooType, "foreign"), wantExc: mustCreateException(TypeErrorType, "unbound method foo() must be called with int instance as first argument (got nothing instead)")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(callAttr, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestMethodGetAttribute(t *testing.T) {
+	fun := NewFunction(NewCode("foo", "foo.py", nil, 0, nil), nil)
+	if raised := SetAttr(NewRootFrame(), fun.ToObject(), NewStr("bar"), NewInt(42).ToObject()); raised != nil {
+		t.Fatal(raised)
+	}
+	method := NewMethod(fun, None, ObjectType)
+	self := newObject(ObjectType)
+	bound := NewMethod(fun, self, ObjectType)
+	getAttr := wrapFuncForTest(func(f *Frame, o *Object, name *Str) (*Object, *BaseException) {
+		return GetAttr(f, o, name, nil)
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(method, "__name__"), want: NewStr("foo").ToObject()},
+		{args: wrapArgs(method, "bar"), want: NewInt(42).ToObject()},
+		{args: wrapArgs(method, "im_self"), want: None},
+		{args: wrapArgs(bound, "im_self"), want: self},
+		{args: wrapArgs(bound, "__self__"), want: self},
+		{args: wrapArgs(bound, "im_func"), want: fun.ToObject()},
+		{args: wrapArgs(bound, "__func__"), want: fun.ToObject()},
+		{args: wrapArgs(bound, "im_class"), want: ObjectType.ToObject()},
+		{args: wrapArgs(method, "qux"), wantExc: mustCreateException(AttributeErrorType, "'function' object has no attribute 'qux'")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(getAttr, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestMethodStrRepr(t *testing.T) {
 	foo := newBuiltinFunction("foo", func(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) { return None, nil })
 	cases := []invokeTestCase{
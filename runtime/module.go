@@ -17,6 +17,7 @@ package grumpy
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"runtime/pprof"
 	"strings"
@@ -37,6 +38,10 @@ var (
 	ModuleType = newBasisType("module", reflect.TypeOf(Module{}), toModuleUnsafe, ObjectType)
 	// SysModules is the global dict of imported modules, aka sys.modules.
 	SysModules = NewDict()
+	// moduleRegistry maps the names of the modules linked into the program
+	// to the code objects that initialize them. It is populated by
+	// RegisterModule before main runs and is read only thereafter.
+	moduleRegistry = map[string]*Code{}
 )
 
 // Module represents Python 'module' objects.
@@ -44,6 +49,9 @@ type Module struct {
 	Object
 	mutex recursiveMutex
 	state moduleState
+	// code is the code object that initialized the module. It is nil for
+	// native modules and those created by calling the module type.
+	code *Code
 }
 
 // ModuleInit functions are called when importing Grumpy modules to execute the
@@ -77,61 +85,10 @@ func ImportModule(f *Frame, name string, codeObjs []*Code) ([]*Object, *BaseExce
 	result := make([]*Object, numParts)
 	var prev *Object
 	for i := 0; i < numParts; i++ {
-		name := strings.Join(parts[:i+1], ".")
-		// We do very limited locking here resulting in some
-		// sys.modules consistency gotchas.
-		importMutex.Lock()
-		o, raised := SysModules.GetItemString(f, name)
-		if raised == nil && o == nil {
-			o = newModule(name, codeObjs[i].filename).ToObject()
-			raised = SysModules.SetItemString(f, name, o)
-		}
-		importMutex.Unlock()
+		o, raised := importOne(f, strings.Join(parts[:i+1], "."), codeObjs[i])
 		if raised != nil {
 			return nil, raised
 		}
-		if o.isInstance(ModuleType) {
-			var raised *BaseException
-			m := toModuleUnsafe(o)
-			m.mutex.Lock(f)
-			if m.state == moduleStateNew {
-				m.state = moduleStateInitializing
-				if _, raised = codeObjs[i].Eval(f, m.Dict(), nil, nil); raised == nil {
-					m.state = moduleStateReady
-				} else {
-					// If the module failed to initialize
-					// then before we relinquish the module
-					// lock, remove it from sys.modules.
-					// Threads waiting on this module will
-					// fail when they don't find it in
-					// sys.modules below.
-					e, tb := f.ExcInfo()
-					if _, raised := SysModules.DelItemString(f, name); raised != nil {
-						f.RestoreExc(e, tb)
-					}
-				}
-			}
-			m.mutex.Unlock(f)
-			if raised != nil {
-				return nil, raised
-			}
-			// The result should be what's in sys.modules, not
-			// necessarily the originally created module since this
-			// is CPython's behavior.
-			o, raised = SysModules.GetItemString(f, name)
-			if raised != nil {
-				return nil, raised
-			}
-			if o == nil {
-				// This can happen in the pathological case
-				// where the module clears itself from
-				// sys.modules during execution and is handled
-				// by CPython in PyImport_ExecCodeModuleEx in
-				// import.c.
-				format := "Loaded module %s not found in sys.modules"
-				return nil, f.RaiseType(ImportErrorType, fmt.Sprintf(format, name))
-			}
-		}
 		if prev != nil {
 			if raised := SetAttr(f, prev, NewStr(parts[i]), o); raised != nil {
 				return nil, raised
@@ -143,6 +100,63 @@ func ImportModule(f *Frame, name string, codeObjs []*Code) ([]*Object, *BaseExce
 	return result, nil
 }
 
+// importOne looks in sys.modules for the module with the given fully
+// qualified name and if not present creates and initializes it with code. The
+// module found in sys.modules once initialization completes is returned.
+func importOne(f *Frame, name string, code *Code) (*Object, *BaseException) {
+	// We do very limited locking here resulting in some sys.modules
+	// consistency gotchas.
+	importMutex.Lock()
+	o, raised := SysModules.GetItemString(f, name)
+	if raised == nil && o == nil {
+		o = newModule(name, code.filename).ToObject()
+		raised = SysModules.SetItemString(f, name, o)
+	}
+	importMutex.Unlock()
+	if raised != nil {
+		return nil, raised
+	}
+	if !o.isInstance(ModuleType) {
+		return o, nil
+	}
+	m := toModuleUnsafe(o)
+	m.mutex.Lock(f)
+	if m.state == moduleStateNew {
+		m.state = moduleStateInitializing
+		m.code = code
+		if _, raised = code.Eval(f, m.Dict(), nil, nil); raised == nil {
+			m.state = moduleStateReady
+		} else {
+			// If the module failed to initialize then before we
+			// relinquish the module lock, remove it from
+			// sys.modules. Threads waiting on this module will fail
+			// when they don't find it in sys.modules below.
+			e, tb := f.ExcInfo()
+			if _, raised := SysModules.DelItemString(f, name); raised != nil {
+				f.RestoreExc(e, tb)
+			}
+		}
+	}
+	m.mutex.Unlock(f)
+	if raised != nil {
+		return nil, raised
+	}
+	// The result should be what's in sys.modules, not necessarily the
+	// originally created module since this is CPython's behavior.
+	o, raised = SysModules.GetItemString(f, name)
+	if raised != nil {
+		return nil, raised
+	}
+	if o == nil {
+		// This can happen in the pathological case where the module
+		// clears itself from sys.modules during execution and is
+		// handled by CPython in PyImport_ExecCodeModuleEx in import.c.
+		format := "Loaded module %s not found in sys.modules"
+		return nil, f.RaiseType(ImportErrorType, fmt.Sprintf(format, name))
+	}
+	return o, nil
+}
+
 // ImportNativeModule takes a fully qualified native module name (e.g.
 // grumpy.native.fmt) and a mapping of module members that will be used to
 // populate the module. The same logic is used as ImportModule for looking in
@@ -179,10 +193,258 @@ func ImportNativeModule(f *Frame, name string, members map[string]*Object) (*Obj
 	return prev, nil
 }
 
+// RegisterModule records code as the initializer for the module with the
+// given fully qualified name so that it can be imported dynamically, e.g. by
+// __import__. Compiled modules call it from their init functions.
+func RegisterModule(name string, code *Code) {
+	moduleRegistry[name] = code
+}
+
+// importModuleLevel implements the __import__ builtin. The named module is
+// resolved relative to the package described by globals when level is
+// non-zero: a positive level gives the number of packages to ascend and -1
+// tries a relative import before an absolute one. Only modules that have been
+// registered with RegisterModule or are already in sys.modules can be found.
+func importModuleLevel(f *Frame, name string, globals *Dict, fromlist *Object, level int) (*Object, *BaseException) {
+	if name == "" && level == 0 {
+		return nil, f.RaiseType(ValueErrorType, "Empty module name")
+	}
+	parent := ""
+	if level != 0 && globals != nil {
+		var raised *BaseException
+		if parent, raised = importParentName(f, globals, level); raised != nil {
+			return nil, raised
+		}
+	}
+	var mods []*Object
+	if parent != "" {
+		fullName := parent
+		if name != "" {
+			fullName += "." + name
+		}
+		var raised *BaseException
+		mods, raised = importByName(f, fullName)
+		if raised != nil {
+			if level > 0 || !raised.isInstance(ImportErrorType) {
+				return nil, raised
+			}
+			// Fall back to an absolute import.
+			f.RestoreExc(nil, nil)
+			parent = ""
+		} else {
+			name = fullName
+		}
+	}
+	if parent == "" {
+		if name == "" {
+			return nil, f.RaiseType(ValueErrorType, "Empty module name")
+		}
+		var raised *BaseException
+		if mods, raised = importByName(f, name); raised != nil {
+			return nil, raised
+		}
+	}
+	if fromlist != nil {
+		nonEmpty, raised := IsTrue(f, fromlist)
+		if raised != nil {
+			return nil, raised
+		}
+		if nonEmpty {
+			leaf := mods[len(mods)-1]
+			if raised := importFromList(f, leaf, name, fromlist, false); raised != nil {
+				return nil, raised
+			}
+			return leaf, nil
+		}
+	}
+	// Without a fromlist, the first module named relative to parent is
+	// returned, e.g. the top level package for absolute imports.
+	head := 0
+	if parent != "" {
+		head = strings.Count(parent, ".")
+		if name != parent {
+			head++
+		}
+	}
+	return mods[head], nil
+}
+
+// importByName imports the module with the given fully qualified name and its
+// parent packages, returning them in order. Each is found in sys.modules or
+// initialized from the module registry.
+func importByName(f *Frame, name string) ([]*Object, *BaseException) {
+	parts := strings.Split(name, ".")
+	result := make([]*Object, len(parts))
+	var prev *Object
+	for i := range parts {
+		name := strings.Join(parts[:i+1], ".")
+		var o *Object
+		var raised *BaseException
+		if code, ok := moduleRegistry[name]; ok {
+			o, raised = importOne(f, name, code)
+		} else if o, raised = SysModules.GetItemString(f, name); raised == nil && o == nil {
+			raised = f.RaiseType(ImportErrorType, fmt.Sprintf("No module named %s", parts[i]))
+		}
+		if raised != nil {
+			return nil, raised
+		}
+		if prev != nil {
+			if raised := SetAttr(f, prev, NewStr(parts[i]), o); raised != nil {
+				return nil, raised
+			}
+		}
+		result[i] = o
+		prev = o
+	}
+	return result, nil
+}
+
+// importFromList imports the submodules of the package o named in fromlist
+// that are not already attributes of o. A "*" entry stands for the names in
+// o.__all__. Names that are neither attributes nor submodules are ignored.
+func importFromList(f *Frame, o *Object, name string, fromlist *Object, recursive bool) *BaseException {
+	if !o.isInstance(ModuleType) {
+		return nil
+	}
+	if isPackage, raised := isPackageDict(f, toModuleUnsafe(o).Dict()); raised != nil || !isPackage {
+		return raised
+	}
+	return seqForEach(f, fromlist, func(item *Object) *BaseException {
+		if !item.isInstance(StrType) {
+			format := "Item in ``from list'' must be str, not %s"
+			return f.RaiseType(TypeErrorType, fmt.Sprintf(format, item.typ.Name()))
+		}
+		s := toStrUnsafe(item)
+		if s.Value() == "*" {
+			if recursive {
+				return nil
+			}
+			all, raised := GetAttr(f, o, NewStr("__all__"), None)
+			if raised != nil || all == None {
+				return raised
+			}
+			return importFromList(f, o, name, all, true)
+		}
+		if _, raised := GetAttr(f, o, s, nil); raised == nil {
+			return nil
+		} else if !raised.isInstance(AttributeErrorType) {
+			return raised
+		}
+		f.RestoreExc(nil, nil)
+		subName := name + "." + s.Value()
+		if _, ok := moduleRegistry[subName]; !ok {
+			return nil
+		}
+		_, raised := importByName(f, subName)
+		return raised
+	})
+}
+
+// importParentName returns the name of the package that a relative import
+// from the module with the given globals is resolved against, or the empty
+// string if there is no such package and level is -1.
+func importParentName(f *Frame, globals *Dict, level int) (string, *BaseException) {
+	var parent string
+	pkg, raised := globals.GetItemString(f, "__package__")
+	if raised != nil {
+		return "", raised
+	}
+	if pkg != nil && pkg.isInstance(StrType) && toStrUnsafe(pkg).Value() != "" {
+		parent = toStrUnsafe(pkg).Value()
+	} else {
+		modName, raised := globals.GetItemString(f, "__name__")
+		if raised != nil || modName == nil || !modName.isInstance(StrType) {
+			return "", raised
+		}
+		parent = toStrUnsafe(modName).Value()
+		isPackage, raised := isPackageDict(f, globals)
+		if raised != nil {
+			return "", raised
+		}
+		if !isPackage {
+			i := strings.LastIndex(parent, ".")
+			if i < 0 {
+				if level > 0 {
+					return "", f.RaiseType(ValueErrorType, "Attempted relative import in non-package")
+				}
+				return "", nil
+			}
+			parent = parent[:i]
+		}
+	}
+	for i := 1; i < level; i++ {
+		j := strings.LastIndex(parent, ".")
+		if j < 0 {
+			return "", f.RaiseType(ValueErrorType, "Attempted relative import beyond toplevel package")
+		}
+		parent = parent[:j]
+	}
+	return parent, nil
+}
+
+// isPackageDict returns true if d is the namespace of a package. Grumpy
+// packages have no __path__ so they are recognized by their __init__.py
+// filename.
+func isPackageDict(f *Frame, d *Dict) (bool, *BaseException) {
+	path, raised := d.GetItemString(f, "__path__")
+	if raised != nil || path != nil {
+		return path != nil, raised
+	}
+	file, raised := d.GetItemString(f, "__file__")
+	if raised != nil || file == nil || !file.isInstance(StrType) {
+		return false, raised
+	}
+	return filepath.Base(toStrUnsafe(file).Value()) == "__init__.py", nil
+}
+
+// reloadModule re-executes the code that initialized m in m's existing
+// namespace so that references to m observe the new definitions. Since Grumpy
+// modules are compiled ahead of time, the code run is the one m was originally
+// built from and changes to its source file have no effect. Modules that
+// were not initialized from a code object, e.g. native modules, cannot be
+// reloaded.
+func reloadModule(f *Frame, m *Module) (*Object, *BaseException) {
+	name, raised := m.GetName(f)
+	if raised != nil {
+		return nil, raised
+	}
+	o, raised := SysModules.GetItem(f, name.ToObject())
+	if raised != nil {
+		return nil, raised
+	}
+	if o != m.ToObject() {
+		format := "reload(): module %s not in sys.modules"
+		return nil, f.RaiseType(ImportErrorType, fmt.Sprintf(format, name.Value()))
+	}
+	m.mutex.Lock(f)
+	code := m.code
+	if code != nil {
+		_, raised = code.Eval(f, m.Dict(), nil, nil)
+	}
+	m.mutex.Unlock(f)
+	if code == nil {
+		return nil, f.RaiseType(ImportErrorType, fmt.Sprintf("No module named %s", name.Value()))
+	}
+	if raised != nil {
+		return nil, raised
+	}
+	// Like ImportModule, return whatever is now in sys.modules.
+	o, raised = SysModules.GetItem(f, name.ToObject())
+	if raised != nil {
+		return nil, raised
+	}
+	if o == nil {
+		format := "Loaded module %s not found in sys.modules"
+		return nil, f.RaiseType(ImportErrorType, fmt.Sprintf(format, name.Value()))
+	}
+	return o, nil
+}
+
 // newModule creates a new Module object with the given fully qualified name
 // (e.g a.b.c) and its corresponding Python filename.
 func newModule(name, filename string) *Module {
 	d := newStringDict(map[string]*Object{
+		"__doc__":  None,
 		"__file__": NewStr(filename).ToObject(),
 		"__name__": NewStr(name).ToObject(),
 	})
@@ -236,10 +498,12 @@ func moduleInit(f *Frame, o *Object, args Args, _ KWArgs) (*Object, *BaseExcepti
 	if raised := SetAttr(f, o, NewStr("__name__"), args[0]); raised != nil {
 		return nil, raised
 	}
+	doc := None
 	if argc > 1 {
-		if raised := SetAttr(f, o, NewStr("__doc__"), args[1]); raised != nil {
-			return nil, raised
-		}
+		doc = args[1]
+	}
+	if raised := SetAttr(f, o, NewStr("__doc__"), doc); raised != nil {
+		return nil, raised
 	}
 	return None, nil
 }
@@ -287,6 +551,7 @@ func RunMain(code *Code) int {
 	}
 	m := newModule("__main__", code.filename)
 	m.state = moduleStateInitializing
+	m.code = code
 	f := NewRootFrame()
 	if raised := SysModules.SetItemString(f, "__main__", m.ToObject()); raised != nil {
 		Stderr.writeString(raised.String())
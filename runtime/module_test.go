@@ -204,6 +204,133 @@ func TestImportNativeModule(t *testing.T) {
 	}
 }
 
+func TestImportModuleLevel(t *testing.T) {
+	oldSysModules, oldRegistry := SysModules, moduleRegistry
+	defer func() {
+		SysModules, moduleRegistry = oldSysModules, oldRegistry
+	}()
+	SysModules = NewDict()
+	moduleRegistry = map[string]*Code{}
+	for name, filename := range map[string]string{
+		"pkg":         "pkg/__init__.py",
+		"pkg.other":   "pkg/other.py",
+		"pkg.sub":     "pkg/sub/__init__.py",
+		"pkg.sub.mod": "pkg/sub/mod.py",
+		"top":         "top.py",
+	} {
+		RegisterModule(name, NewCode("<module>", filename, nil, 0, func(*Frame, []*Object) (*Object, *BaseException) { return None, nil }))
+	}
+	// importName calls __import__ and returns the name of the result.
+	importName := newBuiltinFunction("importName", func(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
+		o, raised := builtinImport(f, args, kwargs)
+		if raised != nil {
+			return nil, raised
+		}
+		return GetAttr(f, o, NewStr("__name__"), nil)
+	}).ToObject()
+	modGlobals := newTestDict("__name__", "pkg.sub.mod", "__file__", "pkg/sub/mod.py")
+	subGlobals := newTestDict("__name__", "pkg.sub", "__file__", "pkg/sub/__init__.py")
+	cases := []invokeTestCase{
+		{args: wrapArgs("pkg.sub.mod"), want: NewStr("pkg").ToObject()},
+		{args: wrapArgs("pkg.sub.mod", None, None, NewList(NewStr("x").ToObject())), want: NewStr("pkg.sub.mod").ToObject()},
+		{args: wrapArgs("pkg.sub"), kwargs: wrapKWArgs("fromlist", newTestTuple("mod")), want: NewStr("pkg.sub").ToObject()},
+		{args: wrapArgs("pkg", None, None, newTestTuple("*")), want: NewStr("pkg").ToObject()},
+		{args: wrapArgs("", modGlobals, None, newTestTuple("mod"), 1), want: NewStr("pkg.sub").ToObject()},
+		{args: wrapArgs("other", modGlobals, None, None, 2), want: NewStr("pkg.other").ToObject()},
+		{args: wrapArgs("other.x", modGlobals, None, None, 2), wantExc: mustCreateException(ImportErrorType, "No module named x")},
+		{args: wrapArgs("mod", subGlobals, None, None, 1), want: NewStr("pkg.sub.mod").ToObject()},
+		{args: wrapArgs("sub.mod", modGlobals), kwargs: wrapKWArgs("level", 2), want: NewStr("pkg.sub").ToObject()},
+		{args: wrapArgs("mod", modGlobals), want: NewStr("pkg.sub.mod").ToObject()},
+		{args: wrapArgs("top", modGlobals), want: NewStr("top").ToObject()},
+		{args: wrapArgs("mod", newTestDict("__package__", "pkg.sub"), None, newTestTuple("x"), 1), want: NewStr("pkg.sub.mod").ToObject()},
+		{args: wrapArgs("top", modGlobals, None, None, 1), wantExc: mustCreateException(ImportErrorType, "No module named top")},
+		{args: wrapArgs("x", modGlobals, None, None, 3), wantExc: mustCreateException(ValueErrorType, "Attempted relative import beyond toplevel package")},
+		{args: wrapArgs("x", newTestDict("__name__", "top"), None, None, 1), wantExc: mustCreateException(ValueErrorType, "Attempted relative import in non-package")},
+		{args: wrapArgs(""), wantExc: mustCreateException(ValueErrorType, "Empty module name")},
+		{args: wrapArgs("nope"), wantExc: mustCreateException(ImportErrorType, "No module named nope")},
+		{args: wrapArgs("pkg.nope"), wantExc: mustCreateException(ImportErrorType, "No module named nope")},
+		{args: wrapArgs("pkg", None, None, newTestTuple(1)), wantExc: mustCreateException(TypeErrorType, "Item in ``from list'' must be str, not int")},
+		{args: wrapArgs("pkg"), kwargs: wrapKWArgs("level", "x"), wantExc: mustCreateException(TypeErrorType, "an integer is required")},
+		{args: wrapArgs("pkg", None), kwargs: wrapKWArgs("globals", None), wantExc: mustCreateException(TypeErrorType, "Argument given by name ('globals') and position (2)")},
+		{args: wrapArgs("pkg"), kwargs: wrapKWArgs("foo", None), wantExc: mustCreateException(TypeErrorType, "'foo' is an invalid keyword argument for this function")},
+		{args: wrapArgs("pkg", None, None, None, -1, None), wantExc: mustCreateException(TypeErrorType, "__import__() takes at most 5 arguments (6 given)")},
+		{args: wrapArgs(123), wantExc: mustCreateException(TypeErrorType, "'__import__' requires a 'str' object but received a \"int\"")},
+	}
+	// The fromlist imports submodules that are not yet attributes.
+	f := NewRootFrame()
+	sub := mustNotRaise(builtinImport(f, wrapArgs("pkg.sub", None, None, newTestTuple("mod")), nil))
+	mod := mustNotRaise(SysModules.GetItemString(f, "pkg.sub.mod"))
+	if got := mustNotRaise(GetAttr(f, sub, NewStr("mod"), None)); mod == nil || got != mod {
+		t.Errorf("pkg.sub.mod = %v, want %v", got, mod)
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(importName, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestReloadModule(t *testing.T) {
+	f := NewRootFrame()
+	oldSysModules := SysModules
+	defer func() {
+		SysModules = oldSysModules
+	}()
+	SysModules = NewDict()
+	// The module's top level code records the current value of version so
+	// that reloads can be observed.
+	version := 1
+	fooCode := NewCode("<module>", "foo.py", nil, 0, func(f *Frame, _ []*Object) (*Object, *BaseException) {
+		globals := f.Globals()
+		if raised := globals.SetItemString(f, "version", NewInt(version).ToObject()); raised != nil {
+			return nil, raised
+		}
+		if version < 0 {
+			return nil, f.RaiseType(ValueErrorType, "bad version")
+		}
+		return None, nil
+	})
+	mods, raised := ImportModule(f, "foo", []*Code{fooCode})
+	if raised != nil {
+		t.Fatal(raised)
+	}
+	foo := mods[0]
+	if raised := SetAttr(f, foo, NewStr("extra"), NewStr("bar").ToObject()); raised != nil {
+		t.Fatal(raised)
+	}
+	version = 2
+	if got := mustNotRaise(reloadModule(f, toModuleUnsafe(foo))); got != foo {
+		t.Errorf("reload(foo) = %v, want %v", got, foo)
+	}
+	// The existing namespace is updated in place, not replaced.
+	if got := mustNotRaise(GetAttr(f, foo, NewStr("version"), nil)); !got.isInstance(IntType) || toIntUnsafe(got).Value() != 2 {
+		t.Errorf("foo.version = %v, want 2", got)
+	}
+	if got := mustNotRaise(GetAttr(f, foo, NewStr("extra"), nil)); !got.isInstance(StrType) || toStrUnsafe(got).Value() != "bar" {
+		t.Errorf("foo.extra = %v, want 'bar'", got)
+	}
+	version = -1
+	if _, raised := reloadModule(f, toModuleUnsafe(foo)); raised == nil || !raised.isInstance(ValueErrorType) {
+		t.Errorf("reload(foo) raised %v, want ValueError", raised)
+	}
+	f.RestoreExc(nil, nil)
+	if got := mustNotRaise(SysModules.GetItemString(f, "foo")); got != foo {
+		t.Errorf("after failed reload sys.modules['foo'] = %v, want %v", got, foo)
+	}
+	native := mustNotRaise(ImportNativeModule(f, "grumpy.native.foo", nil))
+	cases := []invokeTestCase{
+		{args: wrapArgs(native), wantExc: mustCreateException(ImportErrorType, "No module named grumpy.native.foo")},
+		{args: wrapArgs(newModule("bar", "bar.py")), wantExc: mustCreateException(ImportErrorType, "reload(): module bar not in sys.modules")},
+		{args: wrapArgs(123), wantExc: mustCreateException(TypeErrorType, "'reload' requires a 'module' object but received a \"int\"")},
+	}
+	reload := mustNotRaise(Builtins.GetItemString(f, "reload"))
+	for _, cas := range cases {
+		if err := runInvokeTestCase(reload, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestModuleGetNameAndFilename(t *testing.T) {
 	fun := wrapFuncForTest(func(f *Frame, m *Module) (*Tuple, *BaseException) {
 		name, raised := m.GetName(f)
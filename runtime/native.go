@@ -320,8 +320,10 @@ func WrapNative(f *Frame, v reflect.Value) (*Object, *BaseException) {
 		// TODO: Switch this over to calling the type when `complex.__new__`
 		// gets implemented.
 		return NewComplex(c).ToObject(), nil
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
 		return t.Call(f, Args{NewInt(int(v.Int())).ToObject()}, nil)
+	case reflect.Uint8, reflect.Uint16:
+		return t.Call(f, Args{NewInt(int(v.Uint())).ToObject()}, nil)
 	// Handle potentially large ints separately in case of overflow.
 	case reflect.Int64:
 		i := v.Int()
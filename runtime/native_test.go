@@ -99,7 +99,7 @@ func TestNativeFuncName(t *testing.T) {
 	})
 	cases := []invokeTestCase{
 		{args: wrapArgs(TestNativeFuncName), want: NewStr("grumpy.TestNativeFuncName").ToObject()},
-		{args: wrapArgs(None), wantExc: mustCreateException(TypeErrorType, "'_get_name' requires a 'func' object but received a 'NoneType'")},
+		{args: wrapArgs(123), wantExc: mustCreateException(TypeErrorType, "'_get_name' requires a 'func' object but received a 'int'")},
 	}
 	for _, cas := range cases {
 		if err := runInvokeTestCase(fun, &cas); err != "" {
@@ -218,6 +218,8 @@ func TestWrapNative(t *testing.T) {
 		{True, True.ToObject(), nil},
 		{123, NewInt(123).ToObject(), nil},
 		{int8(10), NewInt(10).ToObject(), nil},
+		{uint8(255), NewInt(255).ToObject(), nil},
+		{uint16(65535), NewInt(65535).ToObject(), nil},
 		{float32(0.5), NewFloat(0.5).ToObject(), nil},
 		{NewFloat(3.14), NewFloat(3.14).ToObject(), nil},
 		{uint(MaxInt), NewInt(MaxInt).ToObject(), nil},
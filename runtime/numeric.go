@@ -1,8 +1,10 @@
 package grumpy
 
 import (
+	"fmt"
 	"math/big"
 	"strings"
+	"unicode"
 )
 
 const (
@@ -36,9 +38,19 @@ var (
 	minIntBig = big.NewInt(MinInt)
 )
 
-func numParseInteger(z *big.Int, s string, base int) (*big.Int, bool) {
+// numParseInteger parses s as an integer in the given base the way int() and
+// long() do. A base of 0 means the base is determined by the prefix of s as
+// for integer literals. Leading and trailing whitespace and whitespace after
+// the sign are ignored. The base that was actually used is returned along
+// with the result.
+func numParseInteger(z *big.Int, s string, base int) (*big.Int, int, bool) {
 	s = strings.TrimSpace(s)
-	if len(s) > 2 && s[0] == '0' {
+	neg := false
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		neg = s[0] == '-'
+		s = strings.TrimLeftFunc(s[1:], unicode.IsSpace)
+	}
+	if len(s) > 1 && s[0] == '0' {
 		switch s[1] {
 		case 'b', 'B':
 			if base == 0 || base == 2 {
@@ -56,13 +68,39 @@ func numParseInteger(z *big.Int, s string, base int) (*big.Int, bool) {
 				s = s[2:]
 			}
 		default:
-			base = 8
+			if base == 0 {
+				base = 8
+			}
 		}
 	}
 	if base == 0 {
 		base = 10
 	}
-	return z.SetString(s, base)
+	// big.Int accepts a sign of its own which must not follow ours.
+	if len(s) == 0 || s[0] == '+' || s[0] == '-' {
+		return nil, base, false
+	}
+	if _, ok := z.SetString(s, base); !ok {
+		return nil, base, false
+	}
+	if neg {
+		z.Neg(z)
+	}
+	return z, base, true
+}
+
+// numInvalidLiteral raises the ValueError for an invalid int() or long()
+// literal s, truncating s to 200 bytes like CPython.
+func numInvalidLiteral(f *Frame, typeName string, base int, s string) *BaseException {
+	if len(s) > 200 {
+		s = s[:200]
+	}
+	repr, raised := Repr(f, NewStr(s).ToObject())
+	if raised != nil {
+		return raised
+	}
+	format := "invalid literal for %s() with base %d: %s"
+	return f.RaiseType(ValueErrorType, fmt.Sprintf(format, typeName, base, repr.Value()))
 }
 
 func numInIntRange(i *big.Int) bool {
@@ -22,6 +22,7 @@ import (
 
 var (
 	objectBasis             = reflect.TypeOf(Object{})
+	objectNewObjFunc        = newBuiltinFunction("__newobj__", objectNewObj).ToObject()
 	objectReconstructorFunc = newBuiltinFunction("_reconstructor", objectReconstructor).ToObject()
 	objectReduceFunc        = newBuiltinFunction("__reduce__", objectReduce).ToObject()
 	// ObjectType is the object representing the Python 'object' type.
@@ -107,6 +108,11 @@ func objectDelAttr(f *Frame, o *Object, name *Str) *BaseException {
 		if del := desc.Type().slots.Delete; del != nil {
 			return del.Fn(f, desc, o)
 		}
+		if desc.Type().slots.Set != nil {
+			// Like CPython, a data descriptor without __delete__
+			// still takes precedence over the instance dict.
+			return f.RaiseType(AttributeErrorType, "__delete__")
+		}
 	}
 	deleted := false
 	if o.dict != nil {
@@ -160,14 +166,59 @@ func objectHash(f *Frame, o *Object) (*Object, *BaseException) {
 	return NewInt(int(uintptr(o.toPointer()))).ToObject(), nil
 }
 
-func objectNew(f *Frame, t *Type, _ Args, _ KWArgs) (*Object, *BaseException) {
+func objectInit(f *Frame, o *Object, args Args, kwargs KWArgs) (*Object, *BaseException) {
+	if len(args) > 0 || len(kwargs) > 0 {
+		// Like CPython, excess arguments are an error unless they may
+		// have been meant for an overridden __new__.
+		if o.typ.slots.New == ObjectType.slots.New {
+			return nil, f.RaiseType(TypeErrorType, "object.__init__() takes no parameters")
+		}
+	}
+	return None, nil
+}
+
+func objectNew(f *Frame, t *Type, args Args, kwargs KWArgs) (*Object, *BaseException) {
+	if (len(args) > 0 || len(kwargs) > 0) && t.slots.Init == ObjectType.slots.Init {
+		// Like CPython, excess arguments are an error unless they may
+		// have been meant for an overridden __init__.
+		return nil, f.RaiseType(TypeErrorType, "object() takes no parameters")
+	}
 	if t.flags&typeFlagInstantiable == 0 {
 		format := "object.__new__(%s) is not safe, use %s.__new__()"
 		return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, t.Name(), t.Name()))
 	}
+	if t.flags&typeFlagAbstract != 0 {
+		return nil, raiseAbstractInstantiation(f, t)
+	}
 	return newObject(t), nil
 }
 
+// raiseAbstractInstantiation raises TypeError listing the abstract methods that
+// prevent t from being instantiated.
+func raiseAbstractInstantiation(f *Frame, t *Type) *BaseException {
+	methods, raised := t.dict.GetItemString(f, "__abstractmethods__")
+	if raised != nil {
+		return raised
+	}
+	names, raised := ListType.Call(f, Args{methods}, nil)
+	if raised != nil {
+		return raised
+	}
+	if raised := toListUnsafe(names).Sort(f); raised != nil {
+		return raised
+	}
+	joined, raised := strJoin(f, Args{NewStr(", ").ToObject(), names}, nil)
+	if raised != nil {
+		return raised
+	}
+	s, raised := ToStr(f, joined)
+	if raised != nil {
+		return raised
+	}
+	format := "Can't instantiate abstract class %s with abstract methods %s"
+	return f.RaiseType(TypeErrorType, fmt.Sprintf(format, t.Name(), s.Value()))
+}
+
 func objectReduce(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	expectedTypes := []*Type{ObjectType, IntType}
 	argc := len(args)
@@ -194,8 +245,9 @@ func objectReduceEx(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 		return nil, raised
 	}
 	if reduce != nil && reduce != objectReduceFunc {
-		// __reduce__ is overridden so prefer using it.
-		return reduce.Call(f, args, nil)
+		// __reduce__ is overridden so prefer using it. Like CPython,
+		// the protocol is not passed along.
+		return reduce.Call(f, args[:1], nil)
 	}
 	return objectReduceCommon(f, args)
 }
@@ -207,26 +259,53 @@ func objectSetAttr(f *Frame, o *Object, name *Str, value *Object) *BaseException
 		if typeSet := typeAttr.typ.slots.Set; typeSet != nil {
 			return typeSet.Fn(f, typeAttr, o, value)
 		}
+		if typeAttr.typ.slots.Delete != nil {
+			// Like CPython, a data descriptor without __set__ still
+			// takes precedence over the instance dict.
+			return f.RaiseType(AttributeErrorType, "__set__")
+		}
 	}
 	if o.dict != nil {
 		if raised := o.dict.SetItem(f, name.ToObject(), value); raised == nil || !raised.isInstance(KeyErrorType) {
 			return nil
 		}
 	}
-	return f.RaiseType(AttributeErrorType, fmt.Sprintf("'%s' has no attribute '%s'", o.typ.Name(), name.Value()))
+	return f.RaiseType(AttributeErrorType, fmt.Sprintf("'%s' object has no attribute '%s'", o.typ.Name(), name.Value()))
+}
+
+func objectSubclassHook(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	// Defer to the default issubclass() behavior. Abstract base classes
+	// override this to customize it.
+	return NotImplemented, nil
 }
 
 func initObjectType(dict map[string]*Object) {
 	ObjectType.typ = TypeType
 	dict["__reduce__"] = objectReduceFunc
 	dict["__reduce_ex__"] = newBuiltinFunction("__reduce_ex__", objectReduceEx).ToObject()
+	dict["__subclasshook__"] = newClassMethod(newBuiltinFunction("__subclasshook__", objectSubclassHook).ToObject()).ToObject()
 	ObjectType.slots.DelAttr = &delAttrSlot{objectDelAttr}
 	ObjectType.slots.GetAttribute = &getAttributeSlot{objectGetAttribute}
 	ObjectType.slots.Hash = &unaryOpSlot{objectHash}
+	ObjectType.slots.Init = &initSlot{objectInit}
 	ObjectType.slots.New = &newSlot{objectNew}
 	ObjectType.slots.SetAttr = &setAttrSlot{objectSetAttr}
 }
 
+// objectNewObj creates a new instance of the class passed as the first argument
+// by calling its __new__ method with all the arguments. It is similar to the
+// copy_reg.__newobj__ function in CPython.
+func objectNewObj(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if len(args) < 1 || !args[0].isInstance(TypeType) {
+		return nil, f.RaiseType(TypeErrorType, "__newobj__() requires a type as its first argument")
+	}
+	newMethod, raised := GetAttr(f, args[0], NewStr("__new__"), nil)
+	if raised != nil {
+		return nil, raised
+	}
+	return newMethod.Call(f, args, nil)
+}
+
 // objectReconstructor builds an object from a class, its basis type and its
 // state (e.g. its string or integer value). It is similar to the
 // copy_reg._reconstructor function in CPython.
@@ -239,7 +318,11 @@ func objectReconstructor(f *Frame, args Args, _ KWArgs) (*Object, *BaseException
 	if raised != nil {
 		return nil, raised
 	}
-	o, raised := newMethod.Call(f, Args{t.ToObject(), state}, nil)
+	newArgs := Args{t.ToObject()}
+	if basisType != ObjectType {
+		newArgs = append(newArgs, state)
+	}
+	o, raised := newMethod.Call(f, newArgs, nil)
 	if raised != nil {
 		return nil, raised
 	}
@@ -257,8 +340,23 @@ func objectReconstructor(f *Frame, args Args, _ KWArgs) (*Object, *BaseException
 	return o, nil
 }
 
+// objectGetState returns the state of o to be pickled: the result of its
+// __getstate__ method when it has one, otherwise its __dict__ or None.
+func objectGetState(f *Frame, o *Object) (*Object, *BaseException) {
+	getState, raised := GetAttr(f, o, NewStr("__getstate__"), None)
+	if raised != nil {
+		return nil, raised
+	}
+	if getState != None {
+		return getState.Call(f, nil, nil)
+	}
+	if d := o.Dict(); d != nil {
+		return d.ToObject(), nil
+	}
+	return None, nil
+}
+
 func objectReduceCommon(f *Frame, args Args) (*Object, *BaseException) {
-	// TODO: Support __getstate__ and __getnewargs__.
 	o := args[0]
 	t := o.Type()
 	proto := 0
@@ -284,8 +382,12 @@ func objectReduceCommon(f *Frame, args Args) (*Object, *BaseException) {
 			}
 		}
 		newArgs := NewTuple3(t.ToObject(), basisType.ToObject(), state).ToObject()
-		if d := o.Dict(); d != nil {
-			return NewTuple3(objectReconstructorFunc, newArgs, d.ToObject()).ToObject(), nil
+		objState, raised := objectGetState(f, o)
+		if raised != nil {
+			return nil, raised
+		}
+		if objState != None {
+			return NewTuple3(objectReconstructorFunc, newArgs, objState).ToObject(), nil
 		}
 		return NewTuple2(objectReconstructorFunc, newArgs).ToObject(), nil
 	}
@@ -305,9 +407,9 @@ func objectReduceCommon(f *Frame, args Args) (*Object, *BaseException) {
 		}
 		newArgs = append(newArgs, toTupleUnsafe(extraNewArgs).elems...)
 	}
-	dict := None
-	if o.dict != nil {
-		dict = o.dict.ToObject()
+	dict, raised := objectGetState(f, o)
+	if raised != nil {
+		return nil, raised
 	}
 	// For proto >= 2 include list and dict items.
 	listItems := None
@@ -328,9 +430,5 @@ func objectReduceCommon(f *Frame, args Args) (*Object, *BaseException) {
 			}
 		}
 	}
-	newFunc, raised := GetAttr(f, t.ToObject(), NewStr("__new__"), nil)
-	if raised != nil {
-		return nil, raised
-	}
-	return NewTuple5(newFunc, NewTuple(newArgs...).ToObject(), dict, listItems, dictItems).ToObject(), nil
+	return NewTuple5(objectNewObjFunc, NewTuple(newArgs...).ToObject(), dict, listItems, dictItems).ToObject(), nil
 }
@@ -39,6 +39,7 @@ func TestObjectCall(t *testing.T) {
 		"__call__": newBuiltinFunction("__call__", fn).ToObject(),
 	}))
 	callable := newObject(typ)
+	subCallable := newObject(newTestClass("Bar", []*Type{typ}, NewDict()))
 	raisesFunc := newBuiltinFunction("bar", func(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 		return nil, f.RaiseType(RuntimeErrorType, "bar")
 	}).ToObject()
@@ -52,6 +53,7 @@ func TestObjectCall(t *testing.T) {
 		{foo, invokeTestCase{want: newTestTuple(NewTuple(), None).ToObject()}},
 		{foo, invokeTestCase{args: wrapArgs(arg0), want: newTestTuple(NewTuple(arg0), None).ToObject()}},
 		{callable, invokeTestCase{args: args, kwargs: kwargs, want: newTestTuple(NewTuple(callable, arg0, arg1), kwargsDict).ToObject()}},
+		{subCallable, invokeTestCase{args: args, want: newTestTuple(NewTuple(subCallable, arg0, arg1), None).ToObject()}},
 		{newObject(ObjectType), invokeTestCase{wantExc: mustCreateException(TypeErrorType, "'object' object is not callable")}},
 		{raisesFunc, invokeTestCase{wantExc: mustCreateException(RuntimeErrorType, "bar")}},
 	}
@@ -125,13 +127,24 @@ func TestObjectDelAttr(t *testing.T) {
 			return None, nil
 		}).ToObject(),
 	}))
-	fooType := newTestClass("Foo", []*Type{ObjectType}, newStringDict(map[string]*Object{"deller": newObject(dellerType)}))
+	setterType := newTestClass("Setter", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__set__": newBuiltinFunction("__set__", func(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
+			return None, nil
+		}).ToObject(),
+	}))
+	fooType := newTestClass("Foo", []*Type{ObjectType}, newStringDict(map[string]*Object{"deller": newObject(dellerType), "setter": newObject(setterType)}))
 	foo := newObject(fooType)
 	if raised := foo.dict.SetItemString(NewRootFrame(), "attr", NewInt(123).ToObject()); raised != nil {
 		t.Fatal(raised)
 	}
+	// The data descriptor shadows the instance attribute of the same name.
+	shadowed := newObject(fooType)
+	if raised := shadowed.dict.SetItemString(NewRootFrame(), "setter", NewInt(123).ToObject()); raised != nil {
+		t.Fatal(raised)
+	}
 	cases := []invokeTestCase{
 		{args: wrapArgs(foo, "deller"), want: None},
+		{args: wrapArgs(shadowed, "setter"), wantExc: mustCreateException(AttributeErrorType, "__delete__")},
 		{args: wrapArgs(newObject(fooType), "foo"), wantExc: mustCreateException(AttributeErrorType, "'Foo' object has no attribute 'foo'")},
 		{args: wrapArgs(newObject(fooType), "deller"), wantExc: mustCreateException(AttributeErrorType, "attr")},
 	}
@@ -205,16 +218,86 @@ func TestObjectGetAttribute(t *testing.T) {
 	}
 }
 
+func TestObjectInit(t *testing.T) {
+	newOverrideType := newTestClass("NewOverride", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__new__": newStaticMethod(newBuiltinFunction("__new__", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+			return newObject(toTypeUnsafe(args[0])), nil
+		}).ToObject()).ToObject(),
+	}))
+	cases := []invokeTestCase{
+		{args: wrapArgs(newObject(ObjectType)), want: None},
+		{args: wrapArgs(newObject(ObjectType), 123), wantExc: mustCreateException(TypeErrorType, "object.__init__() takes no parameters")},
+		{args: wrapArgs(newObject(ObjectType)), kwargs: wrapKWArgs("foo", 123), wantExc: mustCreateException(TypeErrorType, "object.__init__() takes no parameters")},
+		{args: wrapArgs(newObject(newOverrideType), 123), want: None},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(ObjectType, "__init__", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestObjectNewExcessArgs(t *testing.T) {
+	initOverrideType := newTestClass("InitOverride", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__init__": newBuiltinFunction("__init__", func(*Frame, Args, KWArgs) (*Object, *BaseException) {
+			return None, nil
+		}).ToObject(),
+	}))
+	fun := wrapFuncForTest(func(f *Frame, t *Type, args ...*Object) (*Type, *BaseException) {
+		o, raised := objectNew(f, t, args, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return o.typ, nil
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(initOverrideType, 123), want: initOverrideType.ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestObjectNewObj(t *testing.T) {
+	fooType := newTestClass("Foo", []*Type{IntType}, NewDict())
+	fun := wrapFuncForTest(func(f *Frame, args ...*Object) (*Tuple, *BaseException) {
+		o, raised := objectNewObjFunc.Call(f, args, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return NewTuple2(o.typ.ToObject(), o), nil
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(fooType, 123), want: newTestTuple(fooType, 123).ToObject()},
+		{args: wrapArgs(StrType, "abc"), want: newTestTuple(StrType, "abc").ToObject()},
+		{args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "__newobj__() requires a type as its first argument")},
+		{args: wrapArgs(123), wantExc: mustCreateException(TypeErrorType, "__newobj__() requires a type as its first argument")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestObjectNew(t *testing.T) {
 	foo := makeTestType("Foo", ObjectType)
 	foo.flags &= ^typeFlagInstantiable
 	prepareType(foo)
+	abstractType := newTestClass("Abstract", []*Type{ObjectType}, NewDict())
+	if raised := SetAttr(NewRootFrame(), abstractType.ToObject(), NewStr("__abstractmethods__"), newTestTuple("foo", "bar").ToObject()); raised != nil {
+		t.Fatal(raised)
+	}
 	cases := []invokeTestCase{
 		{args: wrapArgs(ExceptionType), want: newObject(ExceptionType)},
 		{args: wrapArgs(IntType), want: NewInt(0).ToObject()},
 		{wantExc: mustCreateException(TypeErrorType, "'__new__' requires 1 arguments")},
 		{args: wrapArgs(None), wantExc: mustCreateException(TypeErrorType, `'__new__' requires a 'type' object but received a "NoneType"`)},
 		{args: wrapArgs(foo), wantExc: mustCreateException(TypeErrorType, "object.__new__(Foo) is not safe, use Foo.__new__()")},
+		{args: wrapArgs(abstractType), wantExc: mustCreateException(TypeErrorType, "Can't instantiate abstract class Abstract with abstract methods bar, foo")},
+		{args: wrapArgs(ObjectType, 123), wantExc: mustCreateException(TypeErrorType, "object() takes no parameters")},
 	}
 	for _, cas := range cases {
 		if err := runInvokeMethodTestCase(ObjectType, "__new__", &cas); err != "" {
@@ -223,6 +306,26 @@ func TestObjectNew(t *testing.T) {
 	}
 }
 
+func TestObjectSubclassHook(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, o, arg *Object) (*Object, *BaseException) {
+		hook, raised := GetAttr(f, o, NewStr("__subclasshook__"), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return hook.Call(f, Args{arg}, nil)
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(ObjectType, IntType), want: NotImplemented},
+		{args: wrapArgs(StrType, IntType), want: NotImplemented},
+		{args: wrapArgs(newObject(ObjectType), IntType), want: NotImplemented},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestObjectReduce(t *testing.T) {
 	fun := wrapFuncForTest(func(f *Frame, method *Str, o *Object, args Args) (*Object, *BaseException) {
 		// Call __reduce/reduce_ex__.
@@ -278,6 +381,9 @@ func TestObjectReduce(t *testing.T) {
 	// forward to the call to __reduce__.
 	reduceOverrideType := newTestClass("ReduceOverride", []*Type{ObjectType}, newStringDict(map[string]*Object{
 		"__reduce__": newBuiltinFunction("__reduce__", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+			if len(args) != 1 {
+				return nil, f.RaiseType(TypeErrorType, "__reduce__ takes no arguments")
+			}
 			strNew, raised := GetAttr(f, StrType.ToObject(), NewStr("__new__"), nil)
 			if raised != nil {
 				return nil, raised
@@ -295,6 +401,12 @@ func TestObjectReduce(t *testing.T) {
 			return NewInt(123).ToObject(), nil
 		}).ToObject(),
 	}))
+	getStateType := newTestClass("GetState", []*Type{StrType}, newStringDict(map[string]*Object{
+		"__getstate__": newBuiltinFunction("__getstate__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return NewStr("state").ToObject(), nil
+		}).ToObject(),
+	}))
+	getStateInst := &Str{Object: Object{typ: getStateType}, value: "getState"}
 	// Attempting to reduce an int will fail with "can't pickle" but
 	// subclasses can be reduced.
 	intSubclass := newTestClass("IntSubclass", []*Type{IntType}, NewDict())
@@ -309,6 +421,7 @@ func TestObjectReduce(t *testing.T) {
 		{args: wrapArgs("__reduce__", newObject(fooType), wrapArgs(2)), want: newTestTuple("", NewDict(), None, None).ToObject()},
 		{args: wrapArgs("__reduce_ex__", newObject(fooType), Args{}), want: newTestTuple("", NewDict(), None, None).ToObject()},
 		{args: wrapArgs("__reduce_ex__", newObject(reduceOverrideType), Args{}), want: newTestTuple("ReduceOverride", None, None, None).ToObject()},
+		{args: wrapArgs("__reduce_ex__", newObject(reduceOverrideType), wrapArgs(2)), want: newTestTuple("ReduceOverride", None, None, None).ToObject()},
 		{args: wrapArgs("__reduce__", fooNoDict, Args{}), want: newTestTuple("fooNoDict", None, None, None).ToObject()},
 		{args: wrapArgs("__reduce__", newTestList(1, 2, 3), wrapArgs(2)), want: newTestTuple(NewList(), None, newTestList(1, 2, 3), None).ToObject()},
 		{args: wrapArgs("__reduce__", newTestDict("a", 1, "b", 2), wrapArgs(2)), want: newTestTuple(NewDict(), None, None, newTestDict("a", 1, "b", 2)).ToObject()},
@@ -318,6 +431,8 @@ func TestObjectReduce(t *testing.T) {
 		{args: wrapArgs("__reduce__", 3.14, wrapArgs(2)), want: newTestTuple(3.14, None, None, None).ToObject()},
 		{args: wrapArgs("__reduce__", NewUnicode("abc"), wrapArgs(2)), want: newTestTuple(NewUnicode("abc"), None, None, None).ToObject()},
 		{args: wrapArgs("__reduce__", intSubclassInst, Args{}), want: newTestTuple(intSubclassInst, None, None, None).ToObject()},
+		{args: wrapArgs("__reduce__", getStateInst, Args{}), want: newTestTuple("getState", "state", None, None).ToObject()},
+		{args: wrapArgs("__reduce_ex__", getStateInst, wrapArgs(2)), want: newTestTuple("getState", "state", None, None).ToObject()},
 	}
 	for _, cas := range cases {
 		if err := runInvokeTestCase(fun, &cas); err != "" {
@@ -351,13 +466,19 @@ func TestObjectSetAttr(t *testing.T) {
 			return None, nil
 		}).ToObject(),
 	}))
+	dellerType := newTestClass("Deller", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__delete__": newBuiltinFunction("__delete__", func(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
+			return None, nil
+		}).ToObject(),
+	}))
 	setter := newObject(setterType)
-	fooType := newTestClass("Foo", []*Type{ObjectType}, newStringDict(map[string]*Object{"setter": setter}))
+	fooType := newTestClass("Foo", []*Type{ObjectType}, newStringDict(map[string]*Object{"deller": newObject(dellerType), "setter": setter}))
 	foo := newObject(fooType)
 	cases := []invokeTestCase{
+		{args: wrapArgs(newObject(fooType), "deller", "abc"), wantExc: mustCreateException(AttributeErrorType, "__set__")},
 		{args: wrapArgs(newObject(fooType), "foo", "abc"), want: NewStr("abc").ToObject()},
 		{args: wrapArgs(foo, "setter", "baz"), want: NewTuple(setter, foo, NewStr("baz").ToObject()).ToObject()},
-		{args: wrapArgs(newObject(ObjectType), "foo", 10), wantExc: mustCreateException(AttributeErrorType, "'object' has no attribute 'foo'")},
+		{args: wrapArgs(newObject(ObjectType), "foo", 10), wantExc: mustCreateException(AttributeErrorType, "'object' object has no attribute 'foo'")},
 	}
 	for _, cas := range cases {
 		if err := runInvokeTestCase(fun, &cas); err != "" {
@@ -25,6 +25,8 @@ var (
 	enumerateType = newBasisType("enumerate", reflect.TypeOf(enumerate{}), toEnumerateUnsafe, ObjectType)
 	// rangeIteratorType is the object representing the Python 'rangeiterator' type.
 	rangeIteratorType = newBasisType("rangeiterator", reflect.TypeOf(rangeIterator{}), toRangeIteratorUnsafe, ObjectType)
+	// reversedType is the object representing the Python 'reversed' type.
+	reversedType = newBasisType("reversed", reflect.TypeOf(reversed{}), toReversedUnsafe, ObjectType)
 	// xrangeType is the object representing the Python 'xrange' type.
 	xrangeType = newBasisType("xrange", reflect.TypeOf(xrange{}), toXRangeUnsafe, ObjectType)
 )
@@ -149,6 +151,75 @@ func initRangeIteratorType(map[string]*Object) {
 	rangeIteratorType.slots.Next = &unaryOpSlot{rangeIteratorNext}
 }
 
+// reversed iterates over a sequence from its last element to its first using
+// __len__ and __getitem__.
+type reversed struct {
+	Object
+	mutex sync.Mutex
+	seq   *Object
+	index int
+}
+
+func toReversedUnsafe(o *Object) *reversed {
+	return (*reversed)(o.toPointer())
+}
+
+func reversedIter(f *Frame, o *Object) (*Object, *BaseException) {
+	return o, nil
+}
+
+func reversedNew(f *Frame, t *Type, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkFunctionArgs(f, "__new__", args, ObjectType); raised != nil {
+		return nil, raised
+	}
+	seq := args[0]
+	reversedMethod, raised := seq.typ.mroLookup(f, NewStr("__reversed__"))
+	if raised != nil {
+		return nil, raised
+	}
+	if reversedMethod != nil {
+		return reversedMethod.Call(f, Args{seq}, nil)
+	}
+	if seq.typ.slots.GetItem == nil || seq.isInstance(DictType) {
+		return nil, f.RaiseType(TypeErrorType, "argument to reversed() must be a sequence")
+	}
+	n, raised := Len(f, seq)
+	if raised != nil {
+		return nil, raised
+	}
+	var d *Dict
+	if t != reversedType {
+		d = NewDict()
+	}
+	r := &reversed{Object: Object{typ: t, dict: d}, seq: seq, index: n.Value() - 1}
+	return &r.Object, nil
+}
+
+func reversedNext(f *Frame, o *Object) (item *Object, raised *BaseException) {
+	r := toReversedUnsafe(o)
+	r.mutex.Lock()
+	if r.index >= 0 {
+		item, raised = GetItem(f, r.seq, NewInt(r.index).ToObject())
+		r.index--
+		if raised != nil && (raised.isInstance(IndexErrorType) || raised.isInstance(StopIterationType)) {
+			f.RestoreExc(nil, nil)
+			item, raised = nil, nil
+			r.index = -1
+		}
+	}
+	r.mutex.Unlock()
+	if item == nil && raised == nil {
+		raised = f.Raise(StopIterationType.ToObject(), nil, nil)
+	}
+	return item, raised
+}
+
+func initReversedType(map[string]*Object) {
+	reversedType.slots.Iter = &unaryOpSlot{reversedIter}
+	reversedType.slots.Next = &unaryOpSlot{reversedNext}
+	reversedType.slots.New = &newSlot{reversedNew}
+}
+
 type xrange struct {
 	Object
 	start int
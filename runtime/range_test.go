@@ -70,6 +70,37 @@ func TestRangeIteratorIter(t *testing.T) {
 	}
 }
 
+func TestReversed(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, args ...*Object) (*Object, *BaseException) {
+		r, raised := reversedType.Call(f, args, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return ListType.Call(f, Args{r}, nil)
+	})
+	reversedMethodType := newTestClass("ReversedMethod", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__reversed__": newBuiltinFunction("__reversed__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return newTestTuple("foo", "bar").ToObject(), nil
+		}).ToObject(),
+	}))
+	cases := []invokeTestCase{
+		{args: wrapArgs(NewList()), want: NewList().ToObject()},
+		{args: wrapArgs(newTestList(1, 2, 3)), want: newTestList(3, 2, 1).ToObject()},
+		{args: wrapArgs(newTestTuple("foo", "bar")), want: newTestList("bar", "foo").ToObject()},
+		{args: wrapArgs("abc"), want: newTestList("c", "b", "a").ToObject()},
+		{args: wrapArgs(mustNotRaise(xrangeType.Call(NewRootFrame(), wrapArgs(4), nil))), want: newTestList(3, 2, 1, 0).ToObject()},
+		{args: wrapArgs(newObject(reversedMethodType)), want: newTestList("foo", "bar").ToObject()},
+		{args: wrapArgs(newTestDict(1, 2)), wantExc: mustCreateException(TypeErrorType, "argument to reversed() must be a sequence")},
+		{args: wrapArgs(123), wantExc: mustCreateException(TypeErrorType, "argument to reversed() must be a sequence")},
+		{args: wrapArgs(), wantExc: mustCreateException(TypeErrorType, "'__new__' requires 1 arguments")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestXRangeGetItem(t *testing.T) {
 	cases := []invokeTestCase{
 		{args: wrapArgs(newTestXRange(10), 3), want: NewInt(3).ToObject()},
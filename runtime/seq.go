@@ -175,6 +175,11 @@ func seqFindFirst(f *Frame, iterable *Object, pred func(*Object) (bool, *BaseExc
 
 func seqFindElem(f *Frame, elems []*Object, o *Object) (int, *BaseException) {
 	for i, elem := range elems {
+		// Like CPython, identical objects are considered equal without
+		// consulting __eq__.
+		if elem == o {
+			return i, nil
+		}
 		eq, raised := Eq(f, elem, o)
 		if raised != nil {
 			return -1, raised
@@ -124,6 +124,16 @@ func setAdd(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	return None, nil
 }
 
+// setCmp implements __cmp__ for both set and frozenset. Like CPython, sets
+// refuse 3-way comparison with other sets since their ordering is only
+// partial, but fall back to the default ordering for other operands.
+func setCmp(f *Frame, v, w *Object) (*Object, *BaseException) {
+	if w.isInstance(SetType) || w.isInstance(FrozenSetType) {
+		return nil, f.RaiseType(TypeErrorType, "cannot compare sets using cmp()")
+	}
+	return NewInt(compareDefault(f, v, w)).ToObject(), nil
+}
+
 func setContains(f *Frame, seq, value *Object) (*Object, *BaseException) {
 	contains, raised := toSetUnsafe(seq).Contains(f, value)
 	if raised != nil {
@@ -246,6 +256,7 @@ func initSetType(dict map[string]*Object) {
 	dict["issuperset"] = newBuiltinFunction("issuperset", setIsSuperset).ToObject()
 	dict["remove"] = newBuiltinFunction("remove", setRemove).ToObject()
 	dict["update"] = newBuiltinFunction("update", setUpdate).ToObject()
+	SetType.slots.Cmp = &binaryOpSlot{setCmp}
 	SetType.slots.Contains = &binaryOpSlot{setContains}
 	SetType.slots.Eq = &binaryOpSlot{setEq}
 	SetType.slots.GE = &binaryOpSlot{setGE}
@@ -298,6 +309,23 @@ func frozenSetGT(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return setCompare(f, compareOpGT, (*setBase)(toFrozenSetUnsafe(v)), w)
 }
 
+func frozenSetHash(f *Frame, o *Object) (*Object, *BaseException) {
+	s := toFrozenSetUnsafe(o)
+	// Borrowed from CPython's frozenset_hash() in setobject.c. Entries are
+	// combined with xor so the result does not depend on their order.
+	hash := 1927868237 * (s.dict.Len() + 1)
+	iter := newDictEntryIterator(s.dict)
+	for entry := iter.next(); entry != nil; entry = iter.next() {
+		h := entry.hash
+		hash ^= int(uint(h^(h<<16)^89869747) * 3644798167)
+	}
+	hash = hash*69069 + 907133923
+	if hash == -1 {
+		hash = 590923713
+	}
+	return NewInt(hash).ToObject(), nil
+}
+
 func frozenSetIsSubset(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	if raised := checkMethodArgs(f, "issubset", args, FrozenSetType, ObjectType); raised != nil {
 		return nil, raised
@@ -362,12 +390,12 @@ func frozenSetRepr(f *Frame, o *Object) (*Object, *BaseException) {
 func initFrozenSetType(dict map[string]*Object) {
 	dict["issubset"] = newBuiltinFunction("issubset", frozenSetIsSubset).ToObject()
 	dict["issuperset"] = newBuiltinFunction("issuperset", frozenSetIsSuperset).ToObject()
+	FrozenSetType.slots.Cmp = &binaryOpSlot{setCmp}
 	FrozenSetType.slots.Contains = &binaryOpSlot{frozenSetContains}
 	FrozenSetType.slots.Eq = &binaryOpSlot{frozenSetEq}
 	FrozenSetType.slots.GE = &binaryOpSlot{frozenSetGE}
 	FrozenSetType.slots.GT = &binaryOpSlot{frozenSetGT}
-	// TODO: Implement hash for frozenset.
-	FrozenSetType.slots.Hash = &unaryOpSlot{hashNotImplemented}
+	FrozenSetType.slots.Hash = &unaryOpSlot{frozenSetHash}
 	FrozenSetType.slots.Iter = &unaryOpSlot{frozenSetIter}
 	FrozenSetType.slots.LE = &binaryOpSlot{frozenSetLE}
 	FrozenSetType.slots.Len = &unaryOpSlot{frozenSetLen}
@@ -16,6 +16,7 @@ package grumpy
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
 )
 
@@ -114,6 +115,24 @@ func TestSetCompare(t *testing.T) {
 	}
 }
 
+func TestSetCmp(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(newTestSet(1), newTestSet(1)), wantExc: mustCreateException(TypeErrorType, "cannot compare sets using cmp()")},
+		{args: wrapArgs(newTestSet(1), newTestFrozenSet(2)), wantExc: mustCreateException(TypeErrorType, "cannot compare sets using cmp()")},
+		{args: wrapArgs(newTestFrozenSet(), newTestFrozenSet()), wantExc: mustCreateException(TypeErrorType, "cannot compare sets using cmp()")},
+		{args: wrapArgs(newTestSet(1), newTestFrozenSet(1)), want: NewInt(0).ToObject()},
+		{args: wrapArgs(newTestSet(1), newTestList(1)), want: NewInt(1).ToObject()},
+		{args: wrapArgs(newTestList(1), newTestSet(1)), want: NewInt(-1).ToObject()},
+		{args: wrapArgs(newTestList(1), newTestFrozenSet(1)), want: NewInt(1).ToObject()},
+		{args: wrapArgs(NewSet(), 123), want: NewInt(1).ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(wrapFuncForTest(Compare), &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestSetIsSubset(t *testing.T) {
 	f := NewRootFrame()
 	for _, typ := range []*Type{SetType, FrozenSetType} {
@@ -196,6 +215,46 @@ func TestSetIter(t *testing.T) {
 	}
 }
 
+func TestFrozenSetHash(t *testing.T) {
+	f := NewRootFrame()
+	// Expected values come from CPython 2.7 on a 64 bit platform.
+	cases := []struct {
+		s    *FrozenSet
+		want int64
+	}{
+		{newTestFrozenSet(), 133156838395276},
+		{newTestFrozenSet(1, 2, 3), -7699079583225461316},
+		{newTestFrozenSet(3, 2, 1), -7699079583225461316},
+		{newTestFrozenSet("b", "a"), 3138257626259684061},
+		{newTestFrozenSet(newTestFrozenSet(1)), -5738585316048246863},
+	}
+	for _, cas := range cases {
+		h, raised := Hash(f, cas.s.ToObject())
+		if raised != nil {
+			t.Errorf("hash(%v) raised %v", cas.s, raised)
+		} else if strconv.IntSize == 64 && int64(h.Value()) != cas.want {
+			t.Errorf("hash(%v) = %d, want %d", cas.s, h.Value(), cas.want)
+		}
+	}
+	// Equal sets built in different insertion orders are interchangeable
+	// as dict keys.
+	var forward, backward []*Object
+	for i := 0; i < 100; i++ {
+		forward = append(forward, NewInt(i).ToObject())
+		backward = append(backward, NewInt(99-i).ToObject())
+	}
+	s1 := mustNotRaise(FrozenSetType.Call(f, wrapArgs(NewTuple(forward...)), nil))
+	s2 := mustNotRaise(FrozenSetType.Call(f, wrapArgs(NewList(backward...)), nil))
+	d := NewDict()
+	if raised := d.SetItem(f, s1, NewStr("foo").ToObject()); raised != nil {
+		t.Fatal(raised)
+	}
+	cas := invokeTestCase{args: wrapArgs(d, s2), want: NewStr("foo").ToObject()}
+	if err := runInvokeMethodTestCase(DictType, "__getitem__", &cas); err != "" {
+		t.Error(err)
+	}
+}
+
 func TestSetLen(t *testing.T) {
 	f := NewRootFrame()
 	for _, typ := range []*Type{SetType, FrozenSetType} {
@@ -58,11 +58,11 @@ func (s *Slice) calcSlice(f *Frame, numElems int) (int, int, int, int, *BaseExce
 	} else {
 		startDef, stopDef = numElems-1, -1
 	}
-	start, raised := sliceClampIndex(f, s.start, startDef, numElems)
+	start, raised := sliceClampIndex(f, s.start, startDef, numElems, step)
 	if raised != nil {
 		return 0, 0, 0, 0, raised
 	}
-	stop, raised := sliceClampIndex(f, s.stop, stopDef, numElems)
+	stop, raised := sliceClampIndex(f, s.stop, stopDef, numElems, step)
 	if raised != nil {
 		return 0, 0, 0, 0, raised
 	}
@@ -156,7 +156,7 @@ func initSliceType(map[string]*Object) {
 	SliceType.slots.Repr = &unaryOpSlot{sliceRepr}
 }
 
-func sliceClampIndex(f *Frame, index *Object, def, seqLen int) (int, *BaseException) {
+func sliceClampIndex(f *Frame, index *Object, def, seqLen, step int) (int, *BaseException) {
 	if index == nil || index == None {
 		return def, nil
 	}
@@ -167,7 +167,20 @@ func sliceClampIndex(f *Frame, index *Object, def, seqLen int) (int, *BaseExcept
 	if raised != nil {
 		return 0, raised
 	}
-	return seqClampIndex(i, seqLen), nil
+	if step > 0 {
+		return seqClampIndex(i, seqLen), nil
+	}
+	// With a negative step, out of range indices are clamped to the range
+	// [-1, seqLen-1] so they refer to the ends of the reversed sequence.
+	if i < 0 {
+		i += seqLen
+		if i < 0 {
+			i = -1
+		}
+	} else if i >= seqLen {
+		i = seqLen - 1
+	}
+	return i, nil
 }
 
 func sliceCompare(f *Frame, v *Slice, w *Object, cmp binaryOpFunc) (*Object, *BaseException) {
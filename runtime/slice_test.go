@@ -30,6 +30,9 @@ func TestSliceCalcSize(t *testing.T) {
 		{args: wrapArgs(newTestSlice(4), 6), want: newTestTuple(0, 4, 1, 4).ToObject()},
 		{args: wrapArgs(newTestSlice(-8), 3), want: newTestTuple(0, 0, 1, 0).ToObject()},
 		{args: wrapArgs(newTestSlice(0, 10), 3), want: newTestTuple(0, 3, 1, 3).ToObject()},
+		{args: wrapArgs(newTestSlice(100, -100, -1), 5), want: newTestTuple(4, -1, -1, 5).ToObject()},
+		{args: wrapArgs(newTestSlice(-100, 100, -1), 5), want: newTestTuple(-1, -1, -1, 0).ToObject()},
+		{args: wrapArgs(newTestSlice(3, -100, -2), 5), want: newTestTuple(3, -1, -2, 2).ToObject()},
 		{args: wrapArgs(newTestSlice(1, 2, newObject(ObjectType)), 0), wantExc: mustCreateException(TypeErrorType, errBadSliceIndex)},
 		{args: wrapArgs(newTestSlice(newObject(ObjectType)), 10), wantExc: mustCreateException(TypeErrorType, errBadSliceIndex)},
 		{args: wrapArgs(newTestSlice(newObject(ObjectType), 4), 10), wantExc: mustCreateException(TypeErrorType, errBadSliceIndex)},
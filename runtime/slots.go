@@ -386,6 +386,7 @@ type typeSlots struct {
 	FloorDiv     *binaryOpSlot
 	GE           *binaryOpSlot
 	Get          *getSlot
+	GetAttr      *getAttributeSlot
 	GetAttribute *getAttributeSlot
 	GetItem      *binaryOpSlot
 	GT           *binaryOpSlot
@@ -407,6 +408,7 @@ type typeSlots struct {
 	IPow         *binaryOpSlot
 	IRShift      *binaryOpSlot
 	ISub         *binaryOpSlot
+	ITrueDiv     *binaryOpSlot
 	Iter         *unaryOpSlot
 	IXor         *binaryOpSlot
 	LE           *binaryOpSlot
@@ -440,12 +442,14 @@ type typeSlots struct {
 	RRShift      *binaryOpSlot
 	RShift       *binaryOpSlot
 	RSub         *binaryOpSlot
+	RTrueDiv     *binaryOpSlot
 	RXor         *binaryOpSlot
 	Set          *setSlot
 	SetAttr      *setAttrSlot
 	SetItem      *setItemSlot
 	Str          *unaryOpSlot
 	Sub          *binaryOpSlot
+	TrueDiv      *binaryOpSlot
 	Unicode      *unaryOpSlot
 	Xor          *binaryOpSlot
 }
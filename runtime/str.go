@@ -21,6 +21,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"unicode"
 	"unicode/utf8"
@@ -30,11 +31,16 @@ import (
 var (
 	// StrType is the object representing the Python 'str' type.
 	StrType                = newBasisType("str", reflect.TypeOf(Str{}), toStrUnsafe, BaseStringType)
-	whitespaceSplitRegexp  = regexp.MustCompile(`\s+`)
+	whitespaceSplitRegexp  = regexp.MustCompile(`[ \t\n\v\f\r]+`)
 	strASCIISpaces         = []byte(" \t\n\v\f\r")
 	strInterpolationRegexp = regexp.MustCompile(`^%([#0 +-]?)((\*|[0-9]+)?)((\.(\*|[0-9]+))?)[hlL]?([diouxXeEfFgGcrs%])`)
 	internedStrs           = map[string]*Str{}
-	caseOffset             = byte('a' - 'A')
+	// runtimeInternedStrs holds strings interned via the intern() builtin.
+	// Unlike internedStrs it may be written at any time so access is guarded
+	// by runtimeInternedStrsMutex.
+	runtimeInternedStrs      = map[string]*Str{}
+	runtimeInternedStrsMutex = sync.Mutex{}
+	caseOffset               = byte('a' - 'A')
 )
 
 type stripSide int
@@ -57,6 +63,23 @@ func InternStr(s string) *Str {
 	return str
 }
 
+// internStrValue returns the canonical Str holding the same value as s,
+// adding s to the runtime intern table if no such Str exists yet. Unlike
+// InternStr it is safe to call concurrently, but it does not affect the
+// result of NewStr().
+func internStrValue(s *Str) *Str {
+	if str := internedStrs[s.value]; str != nil {
+		return str
+	}
+	runtimeInternedStrsMutex.Lock()
+	defer runtimeInternedStrsMutex.Unlock()
+	if str := runtimeInternedStrs[s.value]; str != nil {
+		return str
+	}
+	runtimeInternedStrs[s.value] = s
+	return s
+}
+
 // Str represents Python 'str' objects.
 type Str struct {
 	Object
@@ -125,11 +148,12 @@ func hashString(s string) int {
 	if l == 0 {
 		return 0
 	}
-	h := int(s[0]) << 7
+	h := hashSecret.prefix ^ int(s[0])<<7
 	for i := 0; i < l; i++ {
 		h = (1000003 * h) ^ int(s[i])
 	}
 	h ^= l
+	h ^= hashSecret.suffix
 	if h == -1 {
 		h = -2
 	}
@@ -175,6 +199,10 @@ func strCapitalize(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException)
 	return NewStr(string(b)).ToObject(), nil
 }
 
+func strCenter(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	return strJustify(f, "center", args)
+}
+
 func strContains(f *Frame, o *Object, value *Object) (*Object, *BaseException) {
 	if value.isInstance(UnicodeType) {
 		decoded, raised := toStrUnsafe(o).Decode(f, EncodeDefault, EncodeStrict)
@@ -191,12 +219,25 @@ func strContains(f *Frame, o *Object, value *Object) (*Object, *BaseException) {
 }
 
 func strCount(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
-	if raised := checkMethodArgs(f, "count", args, StrType, ObjectType); raised != nil {
+	// TODO: Support for unicode substring.
+	expectedTypes := []*Type{StrType, StrType, ObjectType, ObjectType}
+	argc := len(args)
+	if argc == 2 || argc == 3 {
+		expectedTypes = expectedTypes[:argc]
+	}
+	if raised := checkMethodArgs(f, "count", args, expectedTypes...); raised != nil {
 		return nil, raised
 	}
 	s := toStrUnsafe(args[0]).Value()
+	start, end, raised := strSearchBounds(f, args, len(s))
+	if raised != nil {
+		return nil, raised
+	}
+	if start > end {
+		return NewInt(0).ToObject(), nil
+	}
 	sep := toStrUnsafe(args[1]).Value()
-	cnt := strings.Count(s, sep)
+	cnt := strings.Count(s[start:end], sep)
 	return NewInt(cnt).ToObject(), nil
 }
 
@@ -233,47 +274,50 @@ func strEq(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return strCompare(v, w, False, True, False), nil
 }
 
-// strFind returns the lowest index in s where the substring sub is found such
-// that sub is wholly contained in s[start:end]. Return -1 on failure.
-func strFind(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
-	var raised *BaseException
-	// TODO: Support for unicode substring.
-	expectedTypes := []*Type{StrType, StrType, ObjectType, ObjectType}
-	argc := len(args)
-	if argc == 2 || argc == 3 {
-		expectedTypes = expectedTypes[:argc]
+// strExpandTabs returns a copy of s where tab characters are replaced by
+// enough spaces to reach the next multiple of tabsize (default 8) columns.
+func strExpandTabs(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	expectedTypes := []*Type{StrType, ObjectType}
+	if len(args) == 1 {
+		expectedTypes = expectedTypes[:1]
 	}
-	if raised := checkMethodArgs(f, "find/index", args, expectedTypes...); raised != nil {
+	if raised := checkMethodArgs(f, "expandtabs", args, expectedTypes...); raised != nil {
 		return nil, raised
 	}
-	s := toStrUnsafe(args[0]).Value()
-	l := len(s)
-	start, end := 0, l
-	if argc >= 3 && args[2] != None {
-		start, raised = IndexInt(f, args[2])
+	tabSize := 8
+	if len(args) == 2 {
+		i, raised := ToIntValue(f, args[1])
 		if raised != nil {
 			return nil, raised
 		}
+		tabSize = i
 	}
-	if argc == 4 && args[3] != None {
-		end, raised = IndexInt(f, args[3])
-		if raised != nil {
-			return nil, raised
+	s := toStrUnsafe(args[0]).Value()
+	buf := bytes.Buffer{}
+	col := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\t':
+			if tabSize > 0 {
+				n := tabSize - col%tabSize
+				buf.WriteString(strings.Repeat(" ", n))
+				col += n
+			}
+		case '\n', '\r':
+			buf.WriteByte(c)
+			col = 0
+		default:
+			buf.WriteByte(c)
+			col++
 		}
 	}
-	if start > l {
-		return NewInt(-1).ToObject(), nil
-	}
-	start, end = adjustIndex(start, end, l)
-	if start > end {
-		return NewInt(-1).ToObject(), nil
-	}
-	sub := toStrUnsafe(args[1]).Value()
-	index := strings.Index(s[start:end], sub)
-	if index != -1 {
-		index += start
-	}
-	return NewInt(index).ToObject(), nil
+	return NewStr(buf.String()).ToObject(), nil
+}
+
+// strFind returns the lowest index in s where the substring sub is found such
+// that sub is wholly contained in s[start:end]. Return -1 on failure.
+func strFind(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	return strFindOrIndex(f, "find/index", args, strings.Index, false)
 }
 
 func strGE(f *Frame, v, w *Object) (*Object, *BaseException) {
@@ -335,6 +379,11 @@ func strHash(f *Frame, o *Object) (*Object, *BaseException) {
 	return h.ToObject(), nil
 }
 
+// strIndex is like strFind but raises ValueError when sub is not found.
+func strIndex(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	return strFindOrIndex(f, "find/index", args, strings.Index, true)
+}
+
 func strIsAlNum(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 	if raised := checkMethodArgs(f, "isalnum", args, StrType); raised != nil {
 		return nil, raised
@@ -388,15 +437,16 @@ func strIsLower(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 		return nil, raised
 	}
 	s := toStrUnsafe(args[0]).Value()
-	if len(s) == 0 {
-		return False.ToObject(), nil
-	}
+	// Uncased characters are ignored but there must be at least one
+	// cased character.
+	cased := false
 	for i := range s {
-		if !isLower(s[i]) {
+		if isUpper(s[i]) {
 			return False.ToObject(), nil
 		}
+		cased = cased || isLower(s[i])
 	}
-	return True.ToObject(), nil
+	return GetBool(cased).ToObject(), nil
 }
 
 func strIsSpace(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
@@ -458,15 +508,16 @@ func strIsUpper(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 		return nil, raised
 	}
 	s := toStrUnsafe(args[0]).Value()
-	if len(s) == 0 {
-		return False.ToObject(), nil
-	}
+	// Uncased characters are ignored but there must be at least one
+	// cased character.
+	cased := false
 	for i := range s {
-		if !isUpper(s[i]) {
+		if isLower(s[i]) {
 			return False.ToObject(), nil
 		}
+		cased = cased || isUpper(s[i])
 	}
-	return True.ToObject(), nil
+	return GetBool(cased).ToObject(), nil
 }
 
 func strJoin(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
@@ -527,6 +578,10 @@ func strLen(f *Frame, o *Object) (*Object, *BaseException) {
 	return NewInt(len(toStrUnsafe(o).Value())).ToObject(), nil
 }
 
+func strLJust(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	return strJustify(f, "ljust", args)
+}
+
 func strLower(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 	expectedTypes := []*Type{StrType}
 	if raised := checkMethodArgs(f, "lower", args, expectedTypes...); raised != nil {
@@ -706,6 +761,21 @@ func strRepr(_ *Frame, o *Object) (*Object, *BaseException) {
 	return NewStr(buf.String()).ToObject(), nil
 }
 
+// strRFind returns the highest index in s where the substring sub is found
+// such that sub is wholly contained in s[start:end]. Return -1 on failure.
+func strRFind(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	return strFindOrIndex(f, "rfind/rindex", args, strings.LastIndex, false)
+}
+
+// strRIndex is like strRFind but raises ValueError when sub is not found.
+func strRIndex(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	return strFindOrIndex(f, "rfind/rindex", args, strings.LastIndex, true)
+}
+
+func strRJust(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	return strJustify(f, "rjust", args)
+}
+
 func strSplit(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 	expectedTypes := []*Type{StrType, ObjectType, IntType}
 	argc := len(args)
@@ -907,10 +977,13 @@ func strSwapCase(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 func initStrType(dict map[string]*Object) {
 	dict["__getnewargs__"] = newBuiltinFunction("__getnewargs__", strGetNewArgs).ToObject()
 	dict["capitalize"] = newBuiltinFunction("capitalize", strCapitalize).ToObject()
+	dict["center"] = newBuiltinFunction("center", strCenter).ToObject()
 	dict["count"] = newBuiltinFunction("count", strCount).ToObject()
 	dict["decode"] = newBuiltinFunction("decode", strDecode).ToObject()
 	dict["endswith"] = newBuiltinFunction("endswith", strEndsWith).ToObject()
+	dict["expandtabs"] = newBuiltinFunction("expandtabs", strExpandTabs).ToObject()
 	dict["find"] = newBuiltinFunction("find", strFind).ToObject()
+	dict["index"] = newBuiltinFunction("index", strIndex).ToObject()
 	dict["isalnum"] = newBuiltinFunction("isalnum", strIsAlNum).ToObject()
 	dict["isalpha"] = newBuiltinFunction("isalpha", strIsAlpha).ToObject()
 	dict["isdigit"] = newBuiltinFunction("isdigit", strIsDigit).ToObject()
@@ -919,6 +992,7 @@ func initStrType(dict map[string]*Object) {
 	dict["istitle"] = newBuiltinFunction("istitle", strIsTitle).ToObject()
 	dict["isupper"] = newBuiltinFunction("isupper", strIsUpper).ToObject()
 	dict["join"] = newBuiltinFunction("join", strJoin).ToObject()
+	dict["ljust"] = newBuiltinFunction("ljust", strLJust).ToObject()
 	dict["lower"] = newBuiltinFunction("lower", strLower).ToObject()
 	dict["lstrip"] = newBuiltinFunction("lstrip", strLStrip).ToObject()
 	dict["split"] = newBuiltinFunction("split", strSplit).ToObject()
@@ -927,8 +1001,12 @@ func initStrType(dict map[string]*Object) {
 	dict["strip"] = newBuiltinFunction("strip", strStrip).ToObject()
 	dict["swapcase"] = newBuiltinFunction("swapcase", strSwapCase).ToObject()
 	dict["replace"] = newBuiltinFunction("replace", strReplace).ToObject()
+	dict["rfind"] = newBuiltinFunction("rfind", strRFind).ToObject()
+	dict["rindex"] = newBuiltinFunction("rindex", strRIndex).ToObject()
+	dict["rjust"] = newBuiltinFunction("rjust", strRJust).ToObject()
 	dict["rstrip"] = newBuiltinFunction("rstrip", strRStrip).ToObject()
 	dict["title"] = newBuiltinFunction("title", strTitle).ToObject()
+	dict["translate"] = newBuiltinFunction("translate", strTranslate).ToObject()
 	dict["upper"] = newBuiltinFunction("upper", strUpper).ToObject()
 	dict["zfill"] = newBuiltinFunction("zfill", strZFill).ToObject()
 	StrType.slots.Add = &binaryOpSlot{strAdd}
@@ -951,6 +1029,55 @@ func initStrType(dict map[string]*Object) {
 	StrType.slots.Str = &unaryOpSlot{strStr}
 }
 
+// strJustify implements center(), ljust() and rjust(). It pads s on the left
+// and right with the optional fill character so that its length is at least
+// width.
+func strJustify(f *Frame, method string, args Args) (*Object, *BaseException) {
+	expectedTypes := []*Type{StrType, ObjectType, ObjectType}
+	if len(args) == 2 {
+		expectedTypes = expectedTypes[:2]
+	}
+	if raised := checkMethodArgs(f, method, args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	width, raised := ToIntValue(f, args[1])
+	if raised != nil {
+		return nil, raised
+	}
+	fillchar := byte(' ')
+	if len(args) == 3 {
+		o := args[2]
+		if !o.isInstance(StrType) || len(toStrUnsafe(o).Value()) != 1 {
+			format := "%s() argument 2 must be char, not %s"
+			return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, method, o.typ.Name()))
+		}
+		fillchar = toStrUnsafe(o).Value()[0]
+	}
+	s := toStrUnsafe(args[0]).Value()
+	margin := width - len(s)
+	if margin <= 0 {
+		if args[0].typ == StrType {
+			return args[0], nil
+		}
+		return NewStr(s).ToObject(), nil
+	}
+	left := 0
+	switch method {
+	case "center":
+		// Matches CPython's placement of the odd fill character.
+		left = margin/2 + (margin & width & 1)
+	case "rjust":
+		left = margin
+	}
+	buf := bytes.Buffer{}
+	buf.Grow(width)
+	fill := []byte{fillchar}
+	buf.Write(bytes.Repeat(fill, left))
+	buf.WriteString(s)
+	buf.Write(bytes.Repeat(fill, margin-left))
+	return NewStr(buf.String()).ToObject(), nil
+}
+
 func strCompare(v, w *Object, ltResult, eqResult, gtResult *Int) *Object {
 	if v == w {
 		return eqResult.ToObject()
@@ -969,6 +1096,37 @@ func strCompare(v, w *Object, ltResult, eqResult, gtResult *Int) *Object {
 	return gtResult.ToObject()
 }
 
+// strFindOrIndex implements find(), index(), rfind() and rindex(). search
+// locates sub within s[start:end]. When sub is not found, -1 is returned or, if
+// raiseNotFound is set, a ValueError is raised.
+func strFindOrIndex(f *Frame, method string, args Args, search func(string, string) int, raiseNotFound bool) (*Object, *BaseException) {
+	// TODO: Support for unicode substring.
+	expectedTypes := []*Type{StrType, StrType, ObjectType, ObjectType}
+	argc := len(args)
+	if argc == 2 || argc == 3 {
+		expectedTypes = expectedTypes[:argc]
+	}
+	if raised := checkMethodArgs(f, method, args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	s := toStrUnsafe(args[0]).Value()
+	start, end, raised := strSearchBounds(f, args, len(s))
+	if raised != nil {
+		return nil, raised
+	}
+	index := -1
+	if start <= end {
+		index = search(s[start:end], toStrUnsafe(args[1]).Value())
+	}
+	if index == -1 {
+		if raiseNotFound {
+			return nil, f.RaiseType(ValueErrorType, "substring not found")
+		}
+		return NewInt(-1).ToObject(), nil
+	}
+	return NewInt(index + start).ToObject(), nil
+}
+
 func strInterpolate(f *Frame, format string, values *Tuple) (*Object, *BaseException) {
 	var buf bytes.Buffer
 	valueIndex := 0
@@ -995,9 +1153,20 @@ func strInterpolate(f *Frame, format string, values *Tuple) (*Object, *BaseExcep
 				return nil, f.RaiseType(TypeErrorType, fmt.Sprint(err))
 			}
 		}
-		if flags != "" && flags != "0" {
+		if flags != "" && flags != "0" && flags != "+" && flags != " " {
 			return nil, f.RaiseType(NotImplementedErrorType, "conversion flags not yet supported")
 		}
+		fillchar := " "
+		if flags == "0" {
+			fillchar = flags
+		}
+		// signed prefixes non-negative numbers with the sign flag, if any.
+		signed := func(val string) string {
+			if (flags == "+" || flags == " ") && !strings.HasPrefix(val, "-") {
+				return flags + val
+			}
+			return val
+		}
 		var val string
 		switch fieldType {
 		case "r", "s":
@@ -1021,12 +1190,8 @@ func strInterpolate(f *Frame, format string, values *Tuple) (*Object, *BaseExcep
 		case "f":
 			o := values.elems[valueIndex]
 			if v, ok := floatCoerce(o); ok {
-				val := strconv.FormatFloat(v, 'f', 6, 64)
+				val := signed(strconv.FormatFloat(v, 'f', 6, 64))
 				if fieldWidth > 0 {
-					fillchar := " "
-					if flags != "" {
-						fillchar = flags
-					}
 					val = strLeftPad(val, fieldWidth, fillchar)
 				}
 				buf.WriteString(val)
@@ -1062,15 +1227,49 @@ func strInterpolate(f *Frame, format string, values *Tuple) (*Object, *BaseExcep
 					val = strings.ToUpper(val)
 				}
 			}
+			val = signed(val)
 			if fieldWidth > 0 {
-				fillchar := " "
-				if flags != "" {
-					fillchar = flags
-				}
 				val = strLeftPad(val, fieldWidth, fillchar)
 			}
 			buf.WriteString(val)
 			valueIndex++
+		case "c":
+			o := values.elems[valueIndex]
+			if o.isInstance(StrType) {
+				val = toStrUnsafe(o).Value()
+				if len(val) != 1 {
+					return nil, f.RaiseType(TypeErrorType, "%c requires int or char")
+				}
+			} else if o.isInstance(FloatType) {
+				return nil, f.RaiseType(TypeErrorType, "integer argument expected, got float")
+			} else {
+				i, raised := ToInt(f, o)
+				if raised != nil {
+					return nil, raised
+				}
+				var v int64
+				if i.isInstance(LongType) {
+					l := toLongUnsafe(i).Value()
+					v = int64(l.Sign()) << 8
+					if l.IsInt64() {
+						v = l.Int64()
+					}
+				} else {
+					v = int64(toIntUnsafe(i).Value())
+				}
+				if v > 255 {
+					return nil, f.RaiseType(OverflowErrorType, "unsigned byte integer is greater than maximum")
+				}
+				if v < 0 {
+					return nil, f.RaiseType(OverflowErrorType, "unsigned byte integer is less than minimum")
+				}
+				val = string([]byte{byte(v)})
+			}
+			if fieldWidth > 0 {
+				val = strLeftPad(val, fieldWidth, " ")
+			}
+			buf.WriteString(val)
+			valueIndex++
 		case "%":
 			val = "%"
 			if fieldWidth > 0 {
@@ -1114,6 +1313,27 @@ func strRepeatCount(f *Frame, numChars int, mult *Object) (int, bool, *BaseExcep
 	return n, true, nil
 }
 
+// strSearchBounds returns the start and end indices of the region searched by
+// methods like find() and count() given their optional third and fourth
+// arguments. None bounds are treated as absent. The result is clamped to
+// [0, length] although start may exceed end, in which case nothing matches.
+func strSearchBounds(f *Frame, args Args, length int) (int, int, *BaseException) {
+	start, end := 0, length
+	var raised *BaseException
+	if len(args) > 2 && args[2] != None {
+		if start, raised = IndexInt(f, args[2]); raised != nil {
+			return 0, 0, raised
+		}
+	}
+	if len(args) > 3 && args[3] != None {
+		if end, raised = IndexInt(f, args[3]); raised != nil {
+			return 0, 0, raised
+		}
+	}
+	start, end = adjustIndex(start, end, length)
+	return start, end, nil
+}
+
 func adjustIndex(start, end, length int) (int, int) {
 	if end > length {
 		end = length
@@ -1133,7 +1353,7 @@ func adjustIndex(start, end, length int) (int, int) {
 }
 
 func strStartsEndsWith(f *Frame, method string, args Args) (*Object, *BaseException) {
-	expectedTypes := []*Type{StrType, ObjectType, IntType, IntType}
+	expectedTypes := []*Type{StrType, ObjectType, ObjectType, ObjectType}
 	argc := len(args)
 	if argc == 2 || argc == 3 {
 		expectedTypes = expectedTypes[:argc]
@@ -1149,7 +1369,7 @@ func strStartsEndsWith(f *Frame, method string, args Args) (*Object, *BaseExcept
 		matches = make([]string, len(elems))
 		for i, o := range elems {
 			if !o.isInstance(BaseStringType) {
-				return nil, f.RaiseType(TypeErrorType, "expected a str")
+				return nil, f.RaiseType(TypeErrorType, "expected a string or other character buffer object")
 			}
 			s, raised := ToStr(f, o)
 			if raised != nil {
@@ -1168,15 +1388,10 @@ func strStartsEndsWith(f *Frame, method string, args Args) (*Object, *BaseExcept
 		return nil, f.RaiseType(TypeErrorType, method+msg+matchesArg.typ.Name())
 	}
 	s := toStrUnsafe(args[0]).Value()
-	l := len(s)
-	start, end := 0, l
-	if argc >= 3 {
-		start = toIntUnsafe(args[2]).Value()
-	}
-	if argc == 4 {
-		end = toIntUnsafe(args[3]).Value()
+	start, end, raised := strSearchBounds(f, args, len(s))
+	if raised != nil {
+		return nil, raised
 	}
-	start, end = adjustIndex(start, end, l)
 	if start > end {
 		// start == end may still return true when matching ''.
 		return False.ToObject(), nil
@@ -1227,6 +1442,48 @@ func strTitle(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 	return NewStr(string(b)).ToObject(), nil
 }
 
+func strTranslate(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	expectedTypes := []*Type{StrType, ObjectType, StrType}
+	argc := len(args)
+	if argc == 2 {
+		expectedTypes = expectedTypes[:argc]
+	}
+	if raised := checkMethodArgs(f, "translate", args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	table := args[1]
+	if table != None && !table.isInstance(StrType) {
+		return nil, f.RaiseType(TypeErrorType, fmt.Sprintf("expected a character buffer object, got %s", table.typ.Name()))
+	}
+	var tableValue string
+	if table != None {
+		tableValue = toStrUnsafe(table).Value()
+		if len(tableValue) != 256 {
+			return nil, f.RaiseType(ValueErrorType, "translation table must be 256 characters long")
+		}
+	}
+	var deleted [256]bool
+	if argc == 3 {
+		deleteChars := toStrUnsafe(args[2]).Value()
+		for i := 0; i < len(deleteChars); i++ {
+			deleted[deleteChars[i]] = true
+		}
+	}
+	s := toStrUnsafe(args[0]).Value()
+	b := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if deleted[c] {
+			continue
+		}
+		if table != None {
+			c = tableValue[c]
+		}
+		b = append(b, c)
+	}
+	return NewStr(string(b)).ToObject(), nil
+}
+
 func strUpper(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
 	expectedTypes := []*Type{StrType}
 	if raised := checkMethodArgs(f, "upper", args, expectedTypes...); raised != nil {
@@ -19,6 +19,7 @@ import (
 	"math/big"
 	"reflect"
 	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -89,9 +90,22 @@ func TestStrBinaryOps(t *testing.T) {
 		{args: wrapArgs(Mod, "%s %s", true), wantExc: mustCreateException(TypeErrorType, "not enough arguments for format string")},
 		{args: wrapArgs(Mod, "%Z", None), wantExc: mustCreateException(ValueErrorType, "invalid format spec")},
 		{args: wrapArgs(Mod, "%s", NewDict()), wantExc: mustCreateException(NotImplementedErrorType, "mappings not yet supported")},
-		{args: wrapArgs(Mod, "% d", 23), wantExc: mustCreateException(NotImplementedErrorType, "conversion flags not yet supported")},
+		{args: wrapArgs(Mod, "% d", 23), want: NewStr(" 23").ToObject()},
+		{args: wrapArgs(Mod, "%+d", 23), want: NewStr("+23").ToObject()},
+		{args: wrapArgs(Mod, "%+d", -23), want: NewStr("-23").ToObject()},
+		{args: wrapArgs(Mod, "%+5d", 0), want: NewStr("   +0").ToObject()},
+		{args: wrapArgs(Mod, "% f", 1.5), want: NewStr(" 1.500000").ToObject()},
+		{args: wrapArgs(Mod, "%+x", 255), want: NewStr("+ff").ToObject()},
+		{args: wrapArgs(Mod, "%-d", 23), wantExc: mustCreateException(NotImplementedErrorType, "conversion flags not yet supported")},
 		{args: wrapArgs(Mod, "%.3f", 102.1), wantExc: mustCreateException(NotImplementedErrorType, "field width not yet supported")},
 		{args: wrapArgs(Mod, "%x", 0x1f), want: NewStr("1f").ToObject()},
+		{args: wrapArgs(Mod, "%c", 65), want: NewStr("A").ToObject()},
+		{args: wrapArgs(Mod, "%c%c", newTestTuple("a", 255)), want: NewStr("a\xff").ToObject()},
+		{args: wrapArgs(Mod, "%3c", NewLong(big.NewInt(65))), want: NewStr("  A").ToObject()},
+		{args: wrapArgs(Mod, "%c", 256), wantExc: mustCreateException(OverflowErrorType, "unsigned byte integer is greater than maximum")},
+		{args: wrapArgs(Mod, "%c", -1), wantExc: mustCreateException(OverflowErrorType, "unsigned byte integer is less than minimum")},
+		{args: wrapArgs(Mod, "%c", "ab"), wantExc: mustCreateException(TypeErrorType, "%c requires int or char")},
+		{args: wrapArgs(Mod, "%c", 1.5), wantExc: mustCreateException(TypeErrorType, "integer argument expected, got float")},
 		{args: wrapArgs(Mod, "%X", 0xffff), want: NewStr("FFFF").ToObject()},
 		{args: wrapArgs(Mod, "%x", 1.2), want: NewStr("1").ToObject()},
 		{args: wrapArgs(Mod, "abc %x", NewLong(big.NewInt(123))), want: NewStr("abc 7b").ToObject()},
@@ -142,6 +156,11 @@ func TestStrContains(t *testing.T) {
 		{args: wrapArgs("foobar", "foo"), want: True.ToObject()},
 		{args: wrapArgs("abcdef", "bar"), want: False.ToObject()},
 		{args: wrapArgs("", ""), want: True.ToObject()},
+		{args: wrapArgs("abc", ""), want: True.ToObject()},
+		{args: wrapArgs("", "a"), want: False.ToObject()},
+		{args: wrapArgs("aaab", "aab"), want: True.ToObject()},
+		{args: wrapArgs("abc", "abcd"), want: False.ToObject()},
+		{args: wrapArgs(strings.Repeat("ab", 10000)+"needle", "needle"), want: True.ToObject()},
 		{args: wrapArgs("foobar", NewUnicode("bar")), want: True.ToObject()},
 		{args: wrapArgs("", 102.1), wantExc: mustCreateException(TypeErrorType, "'in <string>' requires string as left operand, not float")},
 	}
@@ -293,6 +312,15 @@ func TestStrMethods(t *testing.T) {
 			return NewLong(big.NewInt(2)).ToObject(), nil
 		}).ToObject(),
 	}))
+	// rot13Table maps lowercase letters to their ROT13 counterparts.
+	rot13 := make([]byte, 256)
+	for i := range rot13 {
+		rot13[i] = byte(i)
+		if i >= 'a' && i <= 'z' {
+			rot13[i] = 'a' + byte(i-'a'+13)%26
+		}
+	}
+	rot13Table := string(rot13)
 	cases := []struct {
 		methodName string
 		args       Args
@@ -302,17 +330,48 @@ func TestStrMethods(t *testing.T) {
 		{"capitalize", wrapArgs(""), NewStr("").ToObject(), nil},
 		{"capitalize", wrapArgs("foobar"), NewStr("Foobar").ToObject(), nil},
 		{"capitalize", wrapArgs("FOOBAR"), NewStr("Foobar").ToObject(), nil},
+		{"capitalize", wrapArgs("hELLO wORLD"), NewStr("Hello world").ToObject(), nil},
+		{"capitalize", wrapArgs("1ABC"), NewStr("1abc").ToObject(), nil},
+		{"capitalize", wrapArgs("they're"), NewStr("They're").ToObject(), nil},
 		{"capitalize", wrapArgs("ùBAR"), NewStr("ùbar").ToObject(), nil},
 		{"capitalize", wrapArgs("вол"), NewStr("вол").ToObject(), nil},
 		{"capitalize", wrapArgs("foobar", 123), nil, mustCreateException(TypeErrorType, "'capitalize' of 'str' requires 1 arguments")},
+		{"center", wrapArgs("x", 5), NewStr("  x  ").ToObject(), nil},
+		{"center", wrapArgs("x", 4, "*"), NewStr("*x**").ToObject(), nil},
+		{"center", wrapArgs("ab", 5, "*"), NewStr("**ab*").ToObject(), nil},
+		{"center", wrapArgs("abc", 6, "*"), NewStr("*abc**").ToObject(), nil},
+		{"center", wrapArgs("abc", 4, "*"), NewStr("abc*").ToObject(), nil},
+		{"center", wrapArgs("abc", 2), NewStr("abc").ToObject(), nil},
+		{"center", wrapArgs("abc", -1), NewStr("abc").ToObject(), nil},
+		{"center", wrapArgs("x", 3, "**"), nil, mustCreateException(TypeErrorType, "center() argument 2 must be char, not str")},
+		{"center", wrapArgs("x", 3, ""), nil, mustCreateException(TypeErrorType, "center() argument 2 must be char, not str")},
+		{"center", wrapArgs("x", 3, 1), nil, mustCreateException(TypeErrorType, "center() argument 2 must be char, not int")},
+		{"center", wrapArgs("x", "3"), nil, mustCreateException(TypeErrorType, "an integer is required")},
+		{"center", wrapArgs("x"), nil, mustCreateException(TypeErrorType, "'center' of 'str' requires 3 arguments")},
 		{"capitalize", wrapArgs("ВОЛ"), NewStr("ВОЛ").ToObject(), nil},
 		{"count", wrapArgs("", "a"), NewInt(0).ToObject(), nil},
 		{"count", wrapArgs("five", ""), NewInt(5).ToObject(), nil},
 		{"count", wrapArgs("abba", "bb"), NewInt(1).ToObject(), nil},
 		{"count", wrapArgs("abbba", "bb"), NewInt(1).ToObject(), nil},
 		{"count", wrapArgs("abbbba", "bb"), NewInt(2).ToObject(), nil},
+		{"count", wrapArgs("abba", "a", NewLong(new(big.Int).Lsh(minIntBig, 2))), NewInt(2).ToObject(), nil},
+		{"find", wrapArgs("abc", "a", NewLong(new(big.Int).Lsh(minIntBig, 2))), NewInt(0).ToObject(), nil},
+		{"find", wrapArgs("abc", "a", 0, NewLong(new(big.Int).Lsh(minIntBig, 2))), NewInt(-1).ToObject(), nil},
 		{"count", wrapArgs("abcdeffdeabcb", "b"), NewInt(3).ToObject(), nil},
-		{"count", wrapArgs(""), nil, mustCreateException(TypeErrorType, "'count' of 'str' requires 2 arguments")},
+		{"count", wrapArgs("aaaa", "aa"), NewInt(2).ToObject(), nil},
+		{"count", wrapArgs("abcabc", "a", 1), NewInt(1).ToObject(), nil},
+		{"count", wrapArgs("abcabc", "a", 1, 3), NewInt(0).ToObject(), nil},
+		{"count", wrapArgs("abcabc", "a", -3), NewInt(1).ToObject(), nil},
+		{"count", wrapArgs("abcabc", "a", None, -3), NewInt(1).ToObject(), nil},
+		{"count", wrapArgs("abcabc", "a", 0, 100), NewInt(2).ToObject(), nil},
+		{"count", wrapArgs("abcabc", "", 2), NewInt(5).ToObject(), nil},
+		{"count", wrapArgs("abcabc", "", 2, 4), NewInt(3).ToObject(), nil},
+		{"count", wrapArgs("abcabc", "", 10), NewInt(0).ToObject(), nil},
+		{"count", wrapArgs("abcabc", "a", 4, 2), NewInt(0).ToObject(), nil},
+		{"count", wrapArgs("abcabc", "a", newObject(intIndexType)), NewInt(1).ToObject(), nil},
+		{"count", wrapArgs("abcabc", "a", "b"), nil, mustCreateException(TypeErrorType, "slice indices must be integers or None or have an __index__ method")},
+		{"count", wrapArgs("abcabc", 1), nil, mustCreateException(TypeErrorType, "'count' requires a 'str' object but received a 'int'")},
+		{"count", wrapArgs(""), nil, mustCreateException(TypeErrorType, "'count' of 'str' requires 4 arguments")},
 		{"endswith", wrapArgs("", ""), True.ToObject(), nil},
 		{"endswith", wrapArgs("", "", 1), False.ToObject(), nil},
 		{"endswith", wrapArgs("foobar", "bar"), True.ToObject(), nil},
@@ -323,7 +382,19 @@ func TestStrMethods(t *testing.T) {
 		{"endswith", wrapArgs("bar", "foobar"), False.ToObject(), nil},
 		{"endswith", wrapArgs("foo", newTestTuple("barfoo", "oo").ToObject()), True.ToObject(), nil},
 		{"endswith", wrapArgs("foo", 123), nil, mustCreateException(TypeErrorType, "endswith first arg must be str, unicode, or tuple, not int")},
-		{"endswith", wrapArgs("foo", newTestTuple(123).ToObject()), nil, mustCreateException(TypeErrorType, "expected a str")},
+		{"endswith", wrapArgs("foo", newTestTuple(123).ToObject()), nil, mustCreateException(TypeErrorType, "expected a string or other character buffer object")},
+		{"endswith", wrapArgs("foobar", newTestTuple("baz", "ba").ToObject(), 0, -1), True.ToObject(), nil},
+		{"endswith", wrapArgs("foobar", newTestTuple("baz", "bar").ToObject(), None, 5), False.ToObject(), nil},
+		{"endswith", wrapArgs("foobar", "ob", -100, -2), True.ToObject(), nil},
+		{"endswith", wrapArgs("foobar", "bar", newObject(intIndexType)), True.ToObject(), nil},
+		{"endswith", wrapArgs("foobar", newTestList("bar")), nil, mustCreateException(TypeErrorType, "endswith first arg must be str, unicode, or tuple, not list")},
+		{"expandtabs", wrapArgs(""), NewStr("").ToObject(), nil},
+		{"expandtabs", wrapArgs("a\tb"), NewStr("a       b").ToObject(), nil},
+		{"expandtabs", wrapArgs("ab\tc\n\td", 4), NewStr("ab  c\n    d").ToObject(), nil},
+		{"expandtabs", wrapArgs("a\r\tb", 2), NewStr("a\r  b").ToObject(), nil},
+		{"expandtabs", wrapArgs("a\tb", 0), NewStr("ab").ToObject(), nil},
+		{"expandtabs", wrapArgs("a\tb", -1), NewStr("ab").ToObject(), nil},
+		{"expandtabs", wrapArgs("a\tb", "4"), nil, mustCreateException(TypeErrorType, "an integer is required")},
 		{"find", wrapArgs("", ""), NewInt(0).ToObject(), nil},
 		{"find", wrapArgs("", "", 1), NewInt(-1).ToObject(), nil},
 		{"find", wrapArgs("", "", -1), NewInt(0).ToObject(), nil},
@@ -356,6 +427,30 @@ func TestStrMethods(t *testing.T) {
 		{"find", wrapArgs("bar", "a", 0, -1), NewInt(1).ToObject(), nil},
 		{"find", wrapArgs("foo", newTestTuple("barfoo", "oo").ToObject()), nil, mustCreateException(TypeErrorType, "'find/index' requires a 'str' object but received a 'tuple'")},
 		{"find", wrapArgs("foo", 123), nil, mustCreateException(TypeErrorType, "'find/index' requires a 'str' object but received a 'int'")},
+		{"index", wrapArgs("abcabc", "b"), NewInt(1).ToObject(), nil},
+		{"index", wrapArgs("abcabc", "b", 2), NewInt(4).ToObject(), nil},
+		{"index", wrapArgs("abcabc", "b", -3, -1), NewInt(4).ToObject(), nil},
+		{"index", wrapArgs("abcabc", "c", -100, 100), NewInt(2).ToObject(), nil},
+		{"index", wrapArgs("abcabc", "", 6), NewInt(6).ToObject(), nil},
+		{"index", wrapArgs("abcabc", "b", 0, -5), nil, mustCreateException(ValueErrorType, "substring not found")},
+		{"index", wrapArgs("abcabc", "z"), nil, mustCreateException(ValueErrorType, "substring not found")},
+		{"index", wrapArgs("abcabc", "", 7), nil, mustCreateException(ValueErrorType, "substring not found")},
+		{"index", wrapArgs("abcabc", 1), nil, mustCreateException(TypeErrorType, "'find/index' requires a 'str' object but received a 'int'")},
+		{"rfind", wrapArgs("abcabc", "b"), NewInt(4).ToObject(), nil},
+		{"rfind", wrapArgs("abcabc", "b", None, 4), NewInt(1).ToObject(), nil},
+		{"rfind", wrapArgs("abcabc", "b", -6, -2), NewInt(1).ToObject(), nil},
+		{"rfind", wrapArgs("abcabc", "c", -100, 100), NewInt(5).ToObject(), nil},
+		{"rfind", wrapArgs("abcabc", ""), NewInt(6).ToObject(), nil},
+		{"rfind", wrapArgs("abcabc", "", 2, 4), NewInt(4).ToObject(), nil},
+		{"rfind", wrapArgs("abcabc", "b", 0, -5), NewInt(-1).ToObject(), nil},
+		{"rfind", wrapArgs("abcabc", "z"), NewInt(-1).ToObject(), nil},
+		{"rfind", wrapArgs("abcabc", "b", 100), NewInt(-1).ToObject(), nil},
+		{"rfind", wrapArgs("abcabc", "b", "c"), nil, mustCreateException(TypeErrorType, "slice indices must be integers or None or have an __index__ method")},
+		{"rfind", wrapArgs("abcabc"), nil, mustCreateException(TypeErrorType, "'rfind/rindex' of 'str' requires 4 arguments")},
+		{"rindex", wrapArgs("abcabc", "b"), NewInt(4).ToObject(), nil},
+		{"rindex", wrapArgs("abcabc", "b", -6, -2), NewInt(1).ToObject(), nil},
+		{"rindex", wrapArgs("abcabc", "b", 0, -5), nil, mustCreateException(ValueErrorType, "substring not found")},
+		{"rindex", wrapArgs("abcabc", "z"), nil, mustCreateException(ValueErrorType, "substring not found")},
 		{"isalnum", wrapArgs("123abc"), True.ToObject(), nil},
 		{"isalnum", wrapArgs(""), False.ToObject(), nil},
 		{"isalnum", wrapArgs("#$%"), False.ToObject(), nil},
@@ -365,21 +460,31 @@ func TestStrMethods(t *testing.T) {
 		{"isalpha", wrapArgs(""), False.ToObject(), nil},
 		{"isalpha", wrapArgs("#$%"), False.ToObject(), nil},
 		{"isalpha", wrapArgs("abc#123"), False.ToObject(), nil},
+		{"isalpha", wrapArgs("AbC"), True.ToObject(), nil},
+		{"isalpha", wrapArgs("a\xe9"), False.ToObject(), nil},
 		{"isalpha", wrapArgs("absd", "efg"), nil, mustCreateException(TypeErrorType, "'isalpha' of 'str' requires 1 arguments")},
 		{"isdigit", wrapArgs("abc"), False.ToObject(), nil},
 		{"isdigit", wrapArgs("123"), True.ToObject(), nil},
 		{"isdigit", wrapArgs(""), False.ToObject(), nil},
 		{"isdigit", wrapArgs("abc#123"), False.ToObject(), nil},
+		{"isdigit", wrapArgs("1.5"), False.ToObject(), nil},
 		{"isdigit", wrapArgs("123", "456"), nil, mustCreateException(TypeErrorType, "'isdigit' of 'str' requires 1 arguments")},
 		{"islower", wrapArgs("abc"), True.ToObject(), nil},
 		{"islower", wrapArgs("ABC"), False.ToObject(), nil},
 		{"islower", wrapArgs(""), False.ToObject(), nil},
-		{"islower", wrapArgs("abc#123"), False.ToObject(), nil},
+		{"islower", wrapArgs("abc#123"), True.ToObject(), nil},
+		{"islower", wrapArgs("aBc"), False.ToObject(), nil},
+		{"islower", wrapArgs("123"), False.ToObject(), nil},
+		{"islower", wrapArgs("a\xe9"), True.ToObject(), nil},
 		{"islower", wrapArgs("123", "456"), nil, mustCreateException(TypeErrorType, "'islower' of 'str' requires 1 arguments")},
 		{"isupper", wrapArgs("abc"), False.ToObject(), nil},
 		{"isupper", wrapArgs("ABC"), True.ToObject(), nil},
 		{"isupper", wrapArgs(""), False.ToObject(), nil},
 		{"isupper", wrapArgs("abc#123"), False.ToObject(), nil},
+		{"isupper", wrapArgs("ABC#123"), True.ToObject(), nil},
+		{"isupper", wrapArgs("AbC"), False.ToObject(), nil},
+		{"isupper", wrapArgs("!!"), False.ToObject(), nil},
+		{"isupper", wrapArgs("A\xc9"), True.ToObject(), nil},
 		{"isupper", wrapArgs("123", "456"), nil, mustCreateException(TypeErrorType, "'isupper' of 'str' requires 1 arguments")},
 		{"isspace", wrapArgs(""), False.ToObject(), nil},
 		{"isspace", wrapArgs(" "), True.ToObject(), nil},
@@ -393,6 +498,14 @@ func TestStrMethods(t *testing.T) {
 		{"istitle", wrapArgs("ABc&D"), False.ToObject(), nil},
 		{"istitle", wrapArgs(""), False.ToObject(), nil},
 		{"istitle", wrapArgs("abc#123"), False.ToObject(), nil},
+		{"istitle", wrapArgs("A"), True.ToObject(), nil},
+		{"istitle", wrapArgs("1A"), True.ToObject(), nil},
+		{"istitle", wrapArgs("A1b"), False.ToObject(), nil},
+		{"istitle", wrapArgs("Abc Def"), True.ToObject(), nil},
+		{"istitle", wrapArgs("Abc def"), False.ToObject(), nil},
+		{"istitle", wrapArgs("Isn'T"), True.ToObject(), nil},
+		{"istitle", wrapArgs("Isn't"), False.ToObject(), nil},
+		{"istitle", wrapArgs("123"), False.ToObject(), nil},
 		{"istitle", wrapArgs("ABc&D", "456"), nil, mustCreateException(TypeErrorType, "'istitle' of 'str' requires 1 arguments")},
 		{"join", wrapArgs(",", newTestList("foo", "bar")), NewStr("foo,bar").ToObject(), nil},
 		{"join", wrapArgs(":", newTestList("foo", "bar", NewUnicode("baz"))), NewUnicode("foo:bar:baz").ToObject(), nil},
@@ -400,6 +513,10 @@ func TestStrMethods(t *testing.T) {
 		{"join", wrapArgs("nope", newTestTuple("foo")), NewStr("foo").ToObject(), nil},
 		{"join", wrapArgs(",", newTestList("foo", "bar", 3.14)), nil, mustCreateException(TypeErrorType, "sequence item 2: expected string, float found")},
 		{"join", wrapArgs("\xff", newTestList(NewUnicode("foo"), NewUnicode("bar"))), nil, mustCreateException(UnicodeDecodeErrorType, "'utf8' codec can't decode byte 0xff in position 0")},
+		{"ljust", wrapArgs("x", 5), NewStr("x    ").ToObject(), nil},
+		{"ljust", wrapArgs("x", 4, "*"), NewStr("x***").ToObject(), nil},
+		{"ljust", wrapArgs("abc", 1, "*"), NewStr("abc").ToObject(), nil},
+		{"ljust", wrapArgs("x", 3, NewUnicode("*")), nil, mustCreateException(TypeErrorType, "ljust() argument 2 must be char, not unicode")},
 		{"lower", wrapArgs(""), NewStr("").ToObject(), nil},
 		{"lower", wrapArgs("a"), NewStr("a").ToObject(), nil},
 		{"lower", wrapArgs("A"), NewStr("a").ToObject(), nil},
@@ -425,6 +542,7 @@ func TestStrMethods(t *testing.T) {
 		{"split", wrapArgs("foo,bar", ","), newTestList("foo", "bar").ToObject(), nil},
 		{"split", wrapArgs("1,2,3", ",", 1), newTestList("1", "2,3").ToObject(), nil},
 		{"split", wrapArgs("a \tb\nc"), newTestList("a", "b", "c").ToObject(), nil},
+		{"split", wrapArgs("\n\ta \t\r b \v "), newTestList("a", "b").ToObject(), nil},
 		{"split", wrapArgs("a \tb\nc", None), newTestList("a", "b", "c").ToObject(), nil},
 		{"split", wrapArgs("a \tb\nc", None, -1), newTestList("a", "b", "c").ToObject(), nil},
 		{"split", wrapArgs("a \tb\nc", None, 1), newTestList("a", "b\nc").ToObject(), nil},
@@ -460,8 +578,18 @@ func TestStrMethods(t *testing.T) {
 		{"startswith", wrapArgs("foo", "foobar"), False.ToObject(), nil},
 		{"startswith", wrapArgs("foo", newTestTuple("foobar", "fo").ToObject()), True.ToObject(), nil},
 		{"startswith", wrapArgs("foo", 123), nil, mustCreateException(TypeErrorType, "startswith first arg must be str, unicode, or tuple, not int")},
-		{"startswith", wrapArgs("foo", "f", "123"), nil, mustCreateException(TypeErrorType, "'startswith' requires a 'int' object but received a 'str'")},
-		{"startswith", wrapArgs("foo", newTestTuple(123).ToObject()), nil, mustCreateException(TypeErrorType, "expected a str")},
+		{"startswith", wrapArgs("foo", "f", "123"), nil, mustCreateException(TypeErrorType, "slice indices must be integers or None or have an __index__ method")},
+		{"startswith", wrapArgs("foo", newTestTuple(123).ToObject()), nil, mustCreateException(TypeErrorType, "expected a string or other character buffer object")},
+		{"startswith", wrapArgs("foo", newTestTuple("x", NewUnicode("fo")).ToObject()), True.ToObject(), nil},
+		{"startswith", wrapArgs("foobar", newTestTuple("x", "ob").ToObject(), 2), True.ToObject(), nil},
+		{"startswith", wrapArgs("foobar", newTestTuple("x", "y").ToObject()), False.ToObject(), nil},
+		{"startswith", wrapArgs("foobar", newTestTuple().ToObject()), False.ToObject(), nil},
+		{"startswith", wrapArgs("foobar", "bar", -3), True.ToObject(), nil},
+		{"startswith", wrapArgs("foobar", "foo", None, 2), False.ToObject(), nil},
+		{"startswith", wrapArgs("foobar", "bar", NewLong(big.NewInt(3)), None), True.ToObject(), nil},
+		{"startswith", wrapArgs("foobar", "", 6), True.ToObject(), nil},
+		{"startswith", wrapArgs("foobar", "", 7), False.ToObject(), nil},
+		{"startswith", wrapArgs("foobar", "foo", NewLong(new(big.Int).Lsh(minIntBig, 2))), True.ToObject(), nil},
 		{"strip", wrapArgs("foo "), NewStr("foo").ToObject(), nil},
 		{"strip", wrapArgs(" foo bar "), NewStr("foo bar").ToObject(), nil},
 		{"strip", wrapArgs("foo foo", "o"), NewStr("foo f").ToObject(), nil},
@@ -506,6 +634,10 @@ func TestStrMethods(t *testing.T) {
 		{"replace", wrapArgs("foobar", "bar", "baz", None), nil, mustCreateException(TypeErrorType, "an integer is required")},
 		{"replace", wrapArgs("foobar", "bar", "baz", newObject(intIndexType)), nil, mustCreateException(TypeErrorType, "an integer is required")},
 		{"replace", wrapArgs("foobar", "bar", "baz", newObject(longIndexType)), nil, mustCreateException(TypeErrorType, "an integer is required")},
+		{"rjust", wrapArgs("x", 5), NewStr("    x").ToObject(), nil},
+		{"rjust", wrapArgs("-1", 4, "0"), NewStr("00-1").ToObject(), nil},
+		{"rjust", wrapArgs("abc", 3, "*"), NewStr("abc").ToObject(), nil},
+		{"rjust", wrapArgs("x", 3, "ab"), nil, mustCreateException(TypeErrorType, "rjust() argument 2 must be char, not str")},
 		{"rstrip", wrapArgs("foo "), NewStr("foo").ToObject(), nil},
 		{"rstrip", wrapArgs(" foo bar "), NewStr(" foo bar").ToObject(), nil},
 		{"rstrip", wrapArgs("foo foo", "o"), NewStr("foo f").ToObject(), nil},
@@ -522,10 +654,21 @@ func TestStrMethods(t *testing.T) {
 		{"title", wrapArgs("abc def"), NewStr("Abc Def").ToObject(), nil},
 		{"title", wrapArgs("ABC DEF"), NewStr("Abc Def").ToObject(), nil},
 		{"title", wrapArgs("aBC dEF"), NewStr("Abc Def").ToObject(), nil},
+		{"title", wrapArgs("they're bill's"), NewStr("They'Re Bill'S").ToObject(), nil},
+		{"title", wrapArgs("o'neil-smith"), NewStr("O'Neil-Smith").ToObject(), nil},
+		{"title", wrapArgs("a1b2 c_d"), NewStr("A1B2 C_D").ToObject(), nil},
+		{"title", wrapArgs("1abc  \tdEF"), NewStr("1Abc  \tDef").ToObject(), nil},
 		{"title", wrapArgs("abc def", 123), nil, mustCreateException(TypeErrorType, "'title' of 'str' requires 1 arguments")},
 		{"title", wrapArgs(123), nil, mustCreateException(TypeErrorType, "unbound method title() must be called with str instance as first argument (got int instance instead)")},
 		{"title", wrapArgs("вол"), NewStr("вол").ToObject(), nil},
 		{"title", wrapArgs("ВОЛ"), NewStr("ВОЛ").ToObject(), nil},
+		{"translate", wrapArgs("foo", rot13Table), NewStr("sbb").ToObject(), nil},
+		{"translate", wrapArgs("foo bar", rot13Table, "o "), NewStr("sone").ToObject(), nil},
+		{"translate", wrapArgs("foo bar", None, "ab"), NewStr("foo r").ToObject(), nil},
+		{"translate", wrapArgs("", None), NewStr("").ToObject(), nil},
+		{"translate", wrapArgs("foo", "abc"), nil, mustCreateException(ValueErrorType, "translation table must be 256 characters long")},
+		{"translate", wrapArgs("foo", 123), nil, mustCreateException(TypeErrorType, "expected a character buffer object, got int")},
+		{"translate", wrapArgs("foo"), nil, mustCreateException(TypeErrorType, "'translate' of 'str' requires 3 arguments")},
 		{"upper", wrapArgs(""), NewStr("").ToObject(), nil},
 		{"upper", wrapArgs("a"), NewStr("A").ToObject(), nil},
 		{"upper", wrapArgs("A"), NewStr("A").ToObject(), nil},
@@ -566,6 +709,9 @@ func TestStrMethods(t *testing.T) {
 		{"swapcase", wrapArgs("abc"), NewStr("ABC").ToObject(), nil},
 		{"swapcase", wrapArgs("ABC"), NewStr("abc").ToObject(), nil},
 		{"swapcase", wrapArgs("aBC"), NewStr("Abc").ToObject(), nil},
+		{"swapcase", wrapArgs("Hello World"), NewStr("hELLO wORLD").ToObject(), nil},
+		{"swapcase", wrapArgs("a1!B2?c \t"), NewStr("A1!b2?C \t").ToObject(), nil},
+		{"swapcase", wrapArgs("\xe9\xc9"), NewStr("\xe9\xc9").ToObject(), nil},
 		{"swapcase", wrapArgs("abc def", 123), nil, mustCreateException(TypeErrorType, "'swapcase' of 'str' requires 1 arguments")},
 		{"swapcase", wrapArgs(123), nil, mustCreateException(TypeErrorType, "unbound method swapcase() must be called with str instance as first argument (got int instance instead)")},
 		{"swapcase", wrapArgs("вол"), NewStr("вол").ToObject(), nil},
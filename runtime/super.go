@@ -68,7 +68,9 @@ func superGetAttribute(f *Frame, o *Object, name *Str) (*Object, *BaseException)
 			i++
 		}
 		i++
-		var inst *Object
+		// Like a lookup on the type itself, descriptors see None as the
+		// instance when super is bound to a type.
+		inst := None
 		if sup.obj != sup.objType.ToObject() {
 			inst = sup.obj
 		}
@@ -49,16 +49,11 @@ func TestSuperGetAttribute(t *testing.T) {
 		"attr": NewStr("left").ToObject(),
 	}))
 	left := newObject(leftType)
+	rightAttr := newProperty(newBuiltinFunction("attr", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+		return NewStr("right").ToObject(), nil
+	}).ToObject(), nil, nil).ToObject()
 	rightType := newTestClass("Right", []*Type{topType}, newStringDict(map[string]*Object{
-		"attr": newProperty(newBuiltinFunction("attr", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
-			s := "right"
-			if args[0] == nil {
-				// When the "instance" argument is nil, the
-				// descriptor is unbound.
-				s = "rightType"
-			}
-			return NewStr(s).ToObject(), nil
-		}).ToObject(), nil, nil).ToObject(),
+		"attr": rightAttr,
 	}))
 	right := newObject(rightType)
 	bottomType := newTestClass("Bottom", []*Type{leftType, rightType}, newStringDict(map[string]*Object{
@@ -69,7 +64,9 @@ func TestSuperGetAttribute(t *testing.T) {
 		{args: wrapArgs(bottomType, bottom), want: NewStr("left").ToObject()},
 		{args: wrapArgs(bottomType, bottomType), want: NewStr("left").ToObject()},
 		{args: wrapArgs(leftType, bottom), want: NewStr("right").ToObject()},
-		{args: wrapArgs(leftType, bottomType), want: NewStr("rightType").ToObject()},
+		// When bound to a type, the descriptor is unbound like it is when
+		// accessed on the type directly.
+		{args: wrapArgs(leftType, bottomType), want: rightAttr},
 		{args: wrapArgs(rightType, bottom), want: NewStr("top").ToObject()},
 		{args: wrapArgs(rightType, bottomType), want: NewStr("top").ToObject()},
 		{args: wrapArgs(topType, bottom), wantExc: mustCreateException(AttributeErrorType, "'super' object has no attribute 'attr'")},
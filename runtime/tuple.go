@@ -212,6 +212,63 @@ func tupleGT(f *Frame, v, w *Object) (*Object, *BaseException) {
 	return tupleCompare(f, toTupleUnsafe(v), w, GT)
 }
 
+func tupleHash(f *Frame, o *Object) (*Object, *BaseException) {
+	elems := toTupleUnsafe(o).elems
+	// Borrowed from CPython's tuplehash() in tupleobject.c.
+	x, mult := 0x345678, 1000003
+	n := len(elems)
+	for _, elem := range elems {
+		h, raised := Hash(f, elem)
+		if raised != nil {
+			return nil, raised
+		}
+		x = (x ^ h.Value()) * mult
+		n--
+		mult += 82520 + n + n
+	}
+	x += 97531
+	if x == -1 {
+		x = -2
+	}
+	return NewInt(x).ToObject(), nil
+}
+
+func tupleIndex(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	expectedTypes := []*Type{TupleType, ObjectType, ObjectType, ObjectType}
+	argc := len(args)
+	var raised *BaseException
+	if argc == 2 || argc == 3 {
+		expectedTypes = expectedTypes[:argc]
+	}
+	if raised = checkMethodArgs(f, "index", args, expectedTypes...); raised != nil {
+		return nil, raised
+	}
+	elems := toTupleUnsafe(args[0]).elems
+	numElems := len(elems)
+	start, stop := 0, numElems
+	if argc > 2 {
+		if start, raised = IndexInt(f, args[2]); raised != nil {
+			return nil, raised
+		}
+	}
+	if argc > 3 {
+		if stop, raised = IndexInt(f, args[3]); raised != nil {
+			return nil, raised
+		}
+	}
+	start, stop = adjustIndex(start, stop, numElems)
+	index := -1
+	if start < numElems && start < stop {
+		if index, raised = seqFindElem(f, elems[start:stop], args[1]); raised != nil {
+			return nil, raised
+		}
+	}
+	if index == -1 {
+		return nil, f.RaiseType(ValueErrorType, "tuple.index(x): x not in tuple")
+	}
+	return NewInt(index + start).ToObject(), nil
+}
+
 func tupleIter(f *Frame, o *Object) (*Object, *BaseException) {
 	return newSliceIterator(reflect.ValueOf(toTupleUnsafe(o).elems)), nil
 }
@@ -232,7 +289,12 @@ func tupleMul(f *Frame, v, w *Object) (*Object, *BaseException) {
 	if !w.isInstance(IntType) {
 		return NotImplemented, nil
 	}
-	elems, raised := seqMul(f, toTupleUnsafe(v).elems, toIntUnsafe(w).Value())
+	n := toIntUnsafe(w).Value()
+	if n == 1 && v.typ == TupleType {
+		// Tuples are immutable so just return the tuple provided.
+		return v, nil
+	}
+	elems, raised := seqMul(f, toTupleUnsafe(v).elems, n)
 	if raised != nil {
 		return nil, raised
 	}
@@ -288,6 +350,7 @@ func tupleRMul(f *Frame, v, w *Object) (*Object, *BaseException) {
 
 func initTupleType(dict map[string]*Object) {
 	dict["count"] = newBuiltinFunction("count", tupleCount).ToObject()
+	dict["index"] = newBuiltinFunction("index", tupleIndex).ToObject()
 	dict["__getnewargs__"] = newBuiltinFunction("__getnewargs__", tupleGetNewArgs).ToObject()
 	TupleType.slots.Add = &binaryOpSlot{tupleAdd}
 	TupleType.slots.Contains = &binaryOpSlot{tupleContains}
@@ -295,6 +358,7 @@ func initTupleType(dict map[string]*Object) {
 	TupleType.slots.GE = &binaryOpSlot{tupleGE}
 	TupleType.slots.GetItem = &binaryOpSlot{tupleGetItem}
 	TupleType.slots.GT = &binaryOpSlot{tupleGT}
+	TupleType.slots.Hash = &unaryOpSlot{tupleHash}
 	TupleType.slots.Iter = &unaryOpSlot{tupleIter}
 	TupleType.slots.LE = &binaryOpSlot{tupleLE}
 	TupleType.slots.Len = &unaryOpSlot{tupleLen}
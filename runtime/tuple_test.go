@@ -15,6 +15,7 @@
 package grumpy
 
 import (
+	"math/big"
 	"reflect"
 	"testing"
 )
@@ -58,6 +59,17 @@ func TestTupleBinaryOps(t *testing.T) {
 	}
 }
 
+func TestTupleMulOne(t *testing.T) {
+	tup := newTestTuple(1, 2).ToObject()
+	if got := mustNotRaise(Mul(NewRootFrame(), tup, NewInt(1).ToObject())); got != tup {
+		t.Errorf("%v * 1 = %v, want the same tuple", tup, got)
+	}
+	subTup := newObject(newTestClass("Foo", []*Type{TupleType}, NewDict()))
+	if got := mustNotRaise(Mul(NewRootFrame(), subTup, NewInt(1).ToObject())); got == subTup || got.typ != TupleType {
+		t.Errorf("Foo() * 1 = %v, want a new tuple", got)
+	}
+}
+
 func TestTupleCompare(t *testing.T) {
 	o := newObject(ObjectType)
 	cases := []invokeTestCase{
@@ -115,6 +127,27 @@ func TestTupleCount(t *testing.T) {
 	}
 }
 
+func TestTupleIndex(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(newTestTuple(10, 20, 30), 20), want: NewInt(1).ToObject()},
+		{args: wrapArgs(newTestTuple(1, 2, 3, 2, 1), 2, 2), want: NewInt(3).ToObject()},
+		{args: wrapArgs(newTestTuple(1, 2, 3, 2, 1), 1, -1), want: NewInt(4).ToObject()},
+		{args: wrapArgs(newTestTuple(1, 2, 3, 2, 1), 2, 0, 2), want: NewInt(1).ToObject()},
+		{args: wrapArgs(newTestTuple(1, 2, 3, 2, 1), 2, -100, 100), want: NewInt(1).ToObject()},
+		{args: wrapArgs(newTestTuple(1, 2), 1, NewLong(new(big.Int).Lsh(minIntBig, 2))), want: NewInt(0).ToObject()},
+		{args: wrapArgs(newTestTuple(1, 2, 3), 2, 0, 1), wantExc: mustCreateException(ValueErrorType, "tuple.index(x): x not in tuple")},
+		{args: wrapArgs(newTestTuple(1, 2, 3), 2, 5, 0), wantExc: mustCreateException(ValueErrorType, "tuple.index(x): x not in tuple")},
+		{args: wrapArgs(NewTuple(), "foo"), wantExc: mustCreateException(ValueErrorType, "tuple.index(x): x not in tuple")},
+		{args: wrapArgs(newTestTuple(1, 2, 3), 2, "foo"), wantExc: mustCreateException(TypeErrorType, "slice indices must be integers or None or have an __index__ method")},
+		{args: wrapArgs(NewTuple()), wantExc: mustCreateException(TypeErrorType, "'index' of 'tuple' requires 4 arguments")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(TupleType, "index", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func BenchmarkTupleContains(b *testing.B) {
 	b.Run("false-3", func(b *testing.B) {
 		t := newTestTuple("foo", 42, "bar").ToObject()
@@ -185,6 +218,20 @@ func TestTupleGetItem(t *testing.T) {
 	}
 }
 
+func TestTupleHash(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(NewTuple()), want: NewInt(3527539).ToObject()},
+		{args: wrapArgs(newTestTuple(1, 2)), want: NewInt(3713081631934410656).ToObject()},
+		{args: wrapArgs(newTestTuple(3, newTestTuple(1, 2))), want: NewInt(-1401337650046085912).ToObject()},
+		{args: wrapArgs(newTestTuple(1, NewList())), wantExc: mustCreateException(TypeErrorType, "unhashable type: 'list'")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(TupleType, "__hash__", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestTupleLen(t *testing.T) {
 	tuple := newTestTuple("foo", 42, "bar")
 	if got := tuple.Len(); got != 3 {
@@ -207,6 +254,9 @@ func TestTupleNew(t *testing.T) {
 }
 
 func TestTupleStrRepr(t *testing.T) {
+	l := NewList()
+	recursiveTuple := newTestTuple("foo", l).ToObject()
+	l.Append(recursiveTuple)
 	fun := wrapFuncForTest(func(f *Frame, o *Object) (*Tuple, *BaseException) {
 		str, raised := ToStr(f, o)
 		if raised != nil {
@@ -222,6 +272,7 @@ func TestTupleStrRepr(t *testing.T) {
 		{args: wrapArgs(NewTuple()), want: newTestTuple("()", "()").ToObject()},
 		{args: wrapArgs(newTestTuple("foo")), want: newTestTuple("('foo',)", "('foo',)").ToObject()},
 		{args: wrapArgs(newTestTuple(TupleType, ExceptionType)), want: newTestTuple("(<type 'tuple'>, <type 'Exception'>)", "(<type 'tuple'>, <type 'Exception'>)").ToObject()},
+		{args: wrapArgs(recursiveTuple), want: newTestTuple("('foo', [(...)])", "('foo', [(...)])").ToObject()},
 	}
 	for _, cas := range cases {
 		if err := runInvokeTestCase(fun, &cas); err != "" {
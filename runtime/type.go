@@ -17,6 +17,7 @@ package grumpy
 import (
 	"fmt"
 	"reflect"
+	"sync"
 )
 
 type typeFlag int
@@ -30,6 +31,10 @@ const (
 	// Set when the type can be used as a base class. This is the default.
 	// Corresponds to the Py_TPFLAGS_BASETYPE flag in CPython.
 	typeFlagBasetype typeFlag = 1 << iota
+	// Set when the type has a non-empty __abstractmethods__ attribute,
+	// which prevents it from being instantiated. Corresponds to the
+	// Py_TPFLAGS_IS_ABSTRACT flag in CPython.
+	typeFlagAbstract typeFlag = 1 << iota
 	typeFlagDefault           = typeFlagInstantiable | typeFlagBasetype
 )
 
@@ -42,8 +47,13 @@ type Type struct {
 	mro   []*Type
 	flags typeFlag
 	slots typeSlots
+	// subclasses holds weak references to the types that directly inherit
+	// from this one. It is guarded by subclassesMutex.
+	subclasses []*WeakRef
 }
 
+var subclassesMutex sync.Mutex
+
 var basisTypes = map[reflect.Type]*Type{
 	objectBasis: ObjectType,
 	typeBasis:   TypeType,
@@ -189,20 +199,38 @@ func prepareType(typ *Type) string {
 			typ.flags &^= typeFlagBasetype
 		}
 	}
-	// Inherit slots from typ's mro.
+	// Inherit slots from typ's mro. Like CPython, a slot that a base merely
+	// inherited from its own primary base is skipped so that it does not
+	// shadow a definition further along the mro.
 	slotsValue := reflect.ValueOf(&typ.slots).Elem()
 	for i := 0; i < numSlots; i++ {
 		slotField := slotsValue.Field(i)
 		if slotField.IsNil() {
+			var inherited reflect.Value
 			for _, base := range typ.mro {
 				baseSlotFunc := reflect.ValueOf(base.slots).Field(i)
-				if !baseSlotFunc.IsNil() {
-					slotField.Set(baseSlotFunc)
+				if baseSlotFunc.IsNil() {
+					continue
+				}
+				if !inherited.IsValid() {
+					inherited = baseSlotFunc
+				}
+				if len(base.bases) == 0 || reflect.ValueOf(base.bases[0].slots).Field(i).Pointer() != baseSlotFunc.Pointer() {
+					inherited = baseSlotFunc
 					break
 				}
 			}
+			if inherited.IsValid() {
+				slotField.Set(inherited)
+			}
 		}
 	}
+	ref := getWeakRef(typ.ToObject())
+	subclassesMutex.Lock()
+	for _, base := range typ.bases {
+		base.subclasses = append(base.subclasses, ref)
+	}
+	subclassesMutex.Unlock()
 	return ""
 }
 
@@ -345,6 +373,11 @@ func typeCall(f *Frame, callable *Object, args Args, kwargs KWArgs) (*Object, *B
 	if raised != nil {
 		return nil, raised
 	}
+	// Like CPython, don't initialize objects of other types returned by
+	// __new__.
+	if !o.isInstance(t) {
+		return o, nil
+	}
 	if init := o.Type().slots.Init; init != nil {
 		if _, raised := init.Fn(f, o, args, kwargs); raised != nil {
 			return nil, raised
@@ -394,16 +427,32 @@ func typeGetAttribute(f *Frame, o *Object, name *Str) (*Object, *BaseException)
 	return nil, f.RaiseType(AttributeErrorType, msg)
 }
 
+func typeInit(f *Frame, _ *Object, args Args, kwargs KWArgs) (*Object, *BaseException) {
+	if len(kwargs) > 0 {
+		return nil, f.RaiseType(TypeErrorType, "type.__init__() takes no keyword arguments")
+	}
+	if argc := len(args); argc != 1 && argc != 3 {
+		return nil, f.RaiseType(TypeErrorType, "type.__init__() takes 1 or 3 arguments")
+	}
+	return None, nil
+}
+
 func typeNew(f *Frame, t *Type, args Args, kwargs KWArgs) (*Object, *BaseException) {
 	switch len(args) {
-	case 0:
-		return nil, f.RaiseType(TypeErrorType, "type() takes 1 or 3 arguments")
 	case 1:
 		return args[0].typ.ToObject(), nil
+	case 3:
+	default:
+		return nil, f.RaiseType(TypeErrorType, "type() takes 1 or 3 arguments")
 	}
-	// case 3+
-	if raised := checkMethodArgs(f, "__new__", args, StrType, TupleType, DictType); raised != nil {
-		return nil, raised
+	for i, want := range []struct {
+		t    *Type
+		name string
+	}{{StrType, "string"}, {TupleType, "tuple"}, {DictType, "dict"}} {
+		if !args[i].isInstance(want.t) {
+			format := "type() argument %d must be %s, not %s"
+			return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, i+1, want.name, args[i].typ.Name()))
+		}
 	}
 	name := toStrUnsafe(args[0]).Value()
 	bases := toTupleUnsafe(args[1]).elems
@@ -424,11 +473,49 @@ func typeNew(f *Frame, t *Type, args Args, kwargs KWArgs) (*Object, *BaseExcepti
 			meta = o.typ
 		} else if !meta.isSubclass(o.typ) {
 			msg := "metaclass conflict: the metaclass of a derived class must " +
-				"a be a (non-strict) subclass of the metaclasses of all its bases"
+				"be a (non-strict) subclass of the metaclasses of all its bases"
 			return nil, f.RaiseType(TypeErrorType, msg)
 		}
 		baseTypes[i] = toTypeUnsafe(o)
 	}
+	if len(baseTypes) == 0 {
+		// Like CPython, a type created without bases derives from
+		// object.
+		baseTypes = []*Type{ObjectType}
+	}
+	if globals := f.Globals(); globals != nil {
+		// Like CPython, default __module__ to the module of the caller.
+		mod, raised := dict.GetItemString(f, "__module__")
+		if raised != nil {
+			return nil, raised
+		}
+		if mod == nil {
+			if mod, raised = globals.GetItemString(f, "__name__"); raised != nil {
+				return nil, raised
+			}
+			if mod != nil {
+				if raised := dict.SetItemString(f, "__module__", mod); raised != nil {
+					return nil, raised
+				}
+			}
+		}
+	}
+	// Like CPython, default __doc__ to None so that it's not inherited.
+	doc, raised := dict.GetItemString(f, "__doc__")
+	if raised != nil {
+		return nil, raised
+	}
+	if doc == nil {
+		if raised := dict.SetItemString(f, "__doc__", None); raised != nil {
+			return nil, raised
+		}
+	}
+	if meta != t && meta.slots.New != t.slots.New {
+		// The most derived metaclass overrides __new__ so let it
+		// create the type, e.g. when inheriting from a class with a
+		// custom metaclass.
+		return meta.slots.New.Fn(f, meta, args, kwargs)
+	}
 	ret, raised := newClass(f, meta, name, baseTypes, dict)
 	if raised != nil {
 		return nil, raised
@@ -436,6 +523,77 @@ func typeNew(f *Frame, t *Type, args Args, kwargs KWArgs) (*Object, *BaseExcepti
 	return ret.ToObject(), nil
 }
 
+func typeAbstractMethodsDelete(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkFunctionArgs(f, "__abstractmethods__", args, TypeType); raised != nil {
+		return nil, raised
+	}
+	t := toTypeUnsafe(args[0])
+	deleted, raised := t.dict.DelItemString(f, "__abstractmethods__")
+	if raised != nil {
+		return nil, raised
+	}
+	if !deleted {
+		return nil, f.RaiseType(AttributeErrorType, "__abstractmethods__")
+	}
+	t.flags &^= typeFlagAbstract
+	return None, nil
+}
+
+func typeAbstractMethodsGet(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkFunctionArgs(f, "__abstractmethods__", args, TypeType); raised != nil {
+		return nil, raised
+	}
+	t := toTypeUnsafe(args[0])
+	// Like CPython, the attribute is only looked up in the type's own dict
+	// and type itself never has it, despite this property being in its
+	// dict.
+	var methods *Object
+	if t != TypeType {
+		var raised *BaseException
+		if methods, raised = t.dict.GetItemString(f, "__abstractmethods__"); raised != nil {
+			return nil, raised
+		}
+	}
+	if methods == nil {
+		return nil, f.RaiseType(AttributeErrorType, "__abstractmethods__")
+	}
+	return methods, nil
+}
+
+func typeAbstractMethodsSet(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkFunctionArgs(f, "__abstractmethods__", args, TypeType, ObjectType); raised != nil {
+		return nil, raised
+	}
+	t := toTypeUnsafe(args[0])
+	isAbstract, raised := IsTrue(f, args[1])
+	if raised != nil {
+		return nil, raised
+	}
+	if raised := t.dict.SetItemString(f, "__abstractmethods__", args[1]); raised != nil {
+		return nil, raised
+	}
+	if isAbstract {
+		t.flags |= typeFlagAbstract
+	} else {
+		t.flags &^= typeFlagAbstract
+	}
+	return None, nil
+}
+
+func typeBasesGet(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkFunctionArgs(f, "__bases__", args, TypeType); raised != nil {
+		return nil, raised
+	}
+	return typesToTuple(toTypeUnsafe(args[0]).bases), nil
+}
+
+func typeMROGet(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkFunctionArgs(f, "__mro__", args, TypeType); raised != nil {
+		return nil, raised
+	}
+	return typesToTuple(toTypeUnsafe(args[0]).mro), nil
+}
+
 func typeRepr(f *Frame, o *Object) (*Object, *BaseException) {
 	s, raised := toTypeUnsafe(o).FullName(f)
 	if raised != nil {
@@ -444,14 +602,53 @@ func typeRepr(f *Frame, o *Object) (*Object, *BaseException) {
 	return NewStr(fmt.Sprintf("<type '%s'>", s)).ToObject(), nil
 }
 
-func initTypeType(map[string]*Object) {
+func typeSubclasses(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, "__subclasses__", args, TypeType); raised != nil {
+		return nil, raised
+	}
+	t := toTypeUnsafe(args[0])
+	var subclasses []*Object
+	subclassesMutex.Lock()
+	// Drop references to types that have been garbage collected.
+	live := t.subclasses[:0]
+	for _, ref := range t.subclasses {
+		ref.mutex.Lock()
+		o := ref.get()
+		ref.mutex.Unlock()
+		if o != nil {
+			live = append(live, ref)
+			subclasses = append(subclasses, o)
+		}
+	}
+	t.subclasses = live
+	subclassesMutex.Unlock()
+	return NewList(subclasses...).ToObject(), nil
+}
+
+func initTypeType(dict map[string]*Object) {
+	dict["__bases__"] = newProperty(newBuiltinFunction("_get_bases", typeBasesGet).ToObject(), nil, nil).ToObject()
+	dict["__mro__"] = newProperty(newBuiltinFunction("_get_mro", typeMROGet).ToObject(), nil, nil).ToObject()
+	dict["__abstractmethods__"] = newProperty(
+		newBuiltinFunction("_get_abstractmethods", typeAbstractMethodsGet).ToObject(),
+		newBuiltinFunction("_set_abstractmethods", typeAbstractMethodsSet).ToObject(),
+		newBuiltinFunction("_del_abstractmethods", typeAbstractMethodsDelete).ToObject()).ToObject()
+	dict["__subclasses__"] = newBuiltinFunction("__subclasses__", typeSubclasses).ToObject()
 	TypeType.typ = TypeType
 	TypeType.slots.Call = &callSlot{typeCall}
 	TypeType.slots.GetAttribute = &getAttributeSlot{typeGetAttribute}
+	TypeType.slots.Init = &initSlot{typeInit}
 	TypeType.slots.New = &newSlot{typeNew}
 	TypeType.slots.Repr = &unaryOpSlot{typeRepr}
 }
 
+func typesToTuple(types []*Type) *Object {
+	elems := make([]*Object, len(types))
+	for i, t := range types {
+		elems[i] = t.ToObject()
+	}
+	return NewTuple(elems...).ToObject()
+}
+
 // basisParent returns the immediate ancestor of basis, which is its first
 // field. Returns nil when basis is objectBasis (the root of basis hierarchy.)
 func basisParent(basis reflect.Type) reflect.Type {
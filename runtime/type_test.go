@@ -160,6 +160,21 @@ func TestPrepareType(t *testing.T) {
 	}
 }
 
+func TestPrepareTypeInheritsSlotsFromMro(t *testing.T) {
+	mixinType := newTestClass("Mixin", []*Type{ObjectType}, NewDict())
+	initType := newTestClass("Init", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__init__": newBuiltinFunction("__init__", func(*Frame, Args, KWArgs) (*Object, *BaseException) {
+			return None, nil
+		}).ToObject(),
+	}))
+	fooType := newTestClass("Foo", []*Type{mixinType, initType}, NewDict())
+	// Mixin's __init__ slot is inherited from object so it must not shadow
+	// the one defined by Init.
+	if fooType.slots.Init != initType.slots.Init {
+		t.Errorf("Foo.slots.Init = %v, want %v", fooType.slots.Init, initType.slots.Init)
+	}
+}
+
 func makeTestType(name string, bases ...*Type) *Type {
 	return newType(TypeType, name, nil, bases, NewDict())
 }
@@ -219,12 +234,22 @@ func TestTypeCall(t *testing.T) {
 	prepareType(fooType)
 	emptyExc := toBaseExceptionUnsafe(newObject(ExceptionType))
 	emptyExc.args = NewTuple()
+	// otherType's __new__ returns an int so its __init__ must not run.
+	otherType := newTestClass("Other", []*Type{ObjectType}, newStringDict(map[string]*Object{
+		"__new__": newBuiltinFunction("__new__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return NewInt(123).ToObject(), nil
+		}).ToObject(),
+		"__init__": newBuiltinFunction("__init__", func(f *Frame, _ Args, _ KWArgs) (*Object, *BaseException) {
+			return nil, f.RaiseType(RuntimeErrorType, "foo")
+		}).ToObject(),
+	}))
 	cases := []invokeTestCase{
 		{wantExc: mustCreateException(TypeErrorType, "unbound method __call__() must be called with type instance as first argument (got nothing instead)")},
 		{args: wrapArgs(42), wantExc: mustCreateException(TypeErrorType, "unbound method __call__() must be called with type instance as first argument (got int instance instead)")},
 		{args: wrapArgs(fooType), wantExc: mustCreateException(TypeErrorType, "type Foo has no __new__")},
 		{args: wrapArgs(IntType), want: NewInt(0).ToObject()},
 		{args: wrapArgs(ExceptionType, "blah"), want: mustCreateException(ExceptionType, "blah").ToObject()},
+		{args: wrapArgs(otherType, "blah"), want: NewInt(123).ToObject()},
 	}
 	for _, cas := range cases {
 		if err := runInvokeMethodTestCase(TypeType, "__call__", &cas); err != "" {
@@ -367,6 +392,20 @@ func TestTypeName(t *testing.T) {
 	}
 }
 
+func TestTypeInit(t *testing.T) {
+	cases := []invokeTestCase{
+		{args: wrapArgs(IntType, 123), want: None},
+		{args: wrapArgs(IntType, "Foo", NewTuple(), NewDict()), want: None},
+		{args: wrapArgs(IntType, "Foo", NewTuple()), wantExc: mustCreateException(TypeErrorType, "type.__init__() takes 1 or 3 arguments")},
+		{args: wrapArgs(IntType, 123), kwargs: wrapKWArgs("foo", 123), wantExc: mustCreateException(TypeErrorType, "type.__init__() takes no keyword arguments")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(TypeType, "__init__", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestTypeNew(t *testing.T) {
 	fooMetaType := newTestClass("FooMeta", []*Type{TypeType}, NewDict())
 	fooType, raised := newClass(NewRootFrame(), fooMetaType, "Foo", []*Type{ObjectType}, NewDict())
@@ -392,17 +431,35 @@ func TestTypeNew(t *testing.T) {
 	if raised != nil {
 		panic(raised)
 	}
+	// quxMetaType overrides __new__ to return its class name so that
+	// delegation from type.__new__ to the most derived metaclass can be
+	// observed.
+	quxMetaType := newTestClass("QuxMeta", []*Type{TypeType}, newStringDict(map[string]*Object{
+		"__new__": newBuiltinFunction("__new__", func(f *Frame, args Args, kwargs KWArgs) (*Object, *BaseException) {
+			return NewStr(toTypeUnsafe(args[0]).Name()).ToObject(), nil
+		}).ToObject(),
+	}))
+	quxType, raised := newClass(NewRootFrame(), quxMetaType, "Qux", []*Type{ObjectType}, NewDict())
+	if raised != nil {
+		panic(raised)
+	}
 	cases := []invokeTestCase{
 		{wantExc: mustCreateException(TypeErrorType, "'__new__' requires 1 arguments")},
 		{args: wrapArgs(TypeType), wantExc: mustCreateException(TypeErrorType, "type() takes 1 or 3 arguments")},
 		{args: wrapArgs(TypeType, "foo", newTestTuple(false), NewDict()), wantExc: mustCreateException(TypeErrorType, "not a valid base class: False")},
 		{args: wrapArgs(TypeType, None), want: NoneType.ToObject()},
-		{args: wrapArgs(fooMetaType, "Qux", newTestTuple(fooType, barType), NewDict()), wantExc: mustCreateException(TypeErrorType, "metaclass conflict: the metaclass of a derived class must a be a (non-strict) subclass of the metaclasses of all its bases")},
+		{args: wrapArgs(TypeType, "foo", NewTuple()), wantExc: mustCreateException(TypeErrorType, "type() takes 1 or 3 arguments")},
+		{args: wrapArgs(TypeType, "foo", NewTuple(), NewDict(), None), wantExc: mustCreateException(TypeErrorType, "type() takes 1 or 3 arguments")},
+		{args: wrapArgs(TypeType, 123, NewTuple(), NewDict()), wantExc: mustCreateException(TypeErrorType, "type() argument 1 must be string, not int")},
+		{args: wrapArgs(TypeType, "foo", NewList(), NewDict()), wantExc: mustCreateException(TypeErrorType, "type() argument 2 must be tuple, not list")},
+		{args: wrapArgs(TypeType, "foo", NewTuple(), NewList()), wantExc: mustCreateException(TypeErrorType, "type() argument 3 must be dict, not list")},
+		{args: wrapArgs(fooMetaType, "Qux", newTestTuple(fooType, barType), NewDict()), wantExc: mustCreateException(TypeErrorType, "metaclass conflict: the metaclass of a derived class must be a (non-strict) subclass of the metaclasses of all its bases")},
 		// Test that the metaclass of the result is the most derived
 		// metaclass of the bases. In this case that should be
 		// bazMetaType so pass bazMetaType to be compared by the __eq__
 		// operator defined above.
 		{args: wrapArgs(barMetaType, "Qux", newTestTuple(barType, bazType), NewDict()), want: bazMetaType.ToObject()},
+		{args: wrapArgs(TypeType, "Quux", newTestTuple(quxType), NewDict()), want: NewStr("QuxMeta").ToObject()},
 	}
 	for _, cas := range cases {
 		if err := runInvokeMethodTestCase(TypeType, "__new__", &cas); err != "" {
@@ -437,6 +494,22 @@ func TestTypeNewResult(t *testing.T) {
 	}
 }
 
+func TestTypeNewNoBases(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame) (*Object, *BaseException) {
+		ret, raised := TypeType.Call(f, wrapArgs("Foo", NewTuple(), newStringDict(map[string]*Object{"bar": NewInt(42).ToObject()})), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		if typ := toTypeUnsafe(ret); !reflect.DeepEqual(typ.mro, []*Type{typ, ObjectType}) {
+			t.Errorf("type('Foo', (), {}).__mro__ = %v, want (Foo, object)", typ.mro)
+		}
+		return GetAttr(f, ret, NewStr("bar"), nil)
+	})
+	if err := runInvokeTestCase(fun, &invokeTestCase{want: NewInt(42).ToObject()}); err != "" {
+		t.Error(err)
+	}
+}
+
 func TestTypeStrRepr(t *testing.T) {
 	fun := wrapFuncForTest(func(f *Frame, o *Object) (*Tuple, *BaseException) {
 		str, raised := ToStr(f, o)
@@ -464,6 +537,27 @@ func TestTypeStrRepr(t *testing.T) {
 	}
 }
 
+func TestTypeDoc(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, bases *Tuple, dict *Dict) (*Object, *BaseException) {
+		cls, raised := TypeType.Call(f, wrapArgs("Foo", bases, dict), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return GetAttr(f, cls, NewStr("__doc__"), nil)
+	})
+	fooType := newTestClass("Foo", []*Type{ObjectType}, newTestDict("__doc__", "foo"))
+	cases := []invokeTestCase{
+		{args: wrapArgs(NewTuple(), NewDict()), want: None},
+		{args: wrapArgs(NewTuple(), newTestDict("__doc__", "bar")), want: NewStr("bar").ToObject()},
+		{args: wrapArgs(newTestTuple(fooType), NewDict()), want: None},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
 func TestTypeModule(t *testing.T) {
 	fn := newBuiltinFunction("__module__", func(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 		if raised := checkFunctionArgs(f, "__module__", args, TypeType); raised != nil {
@@ -548,3 +642,79 @@ func newTestClassStrictEq(name string, base *Type) *Type {
 	}))
 	return t
 }
+
+func TestTypeSubclasses(t *testing.T) {
+	fooType := newTestClass("Foo", []*Type{ObjectType}, NewDict())
+	barType := newTestClass("Bar", []*Type{fooType}, NewDict())
+	bazType := newTestClass("Baz", []*Type{fooType}, NewDict())
+	quxType := newTestClass("Qux", []*Type{barType, bazType}, NewDict())
+	cases := []invokeTestCase{
+		{args: wrapArgs(fooType), want: newTestList(barType, bazType).ToObject()},
+		{args: wrapArgs(barType), want: newTestList(quxType).ToObject()},
+		{args: wrapArgs(quxType), want: NewList().ToObject()},
+		{args: wrapArgs(123), wantExc: mustCreateException(TypeErrorType, "unbound method __subclasses__() must be called with type instance as first argument (got int instance instead)")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeMethodTestCase(TypeType, "__subclasses__", &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestTypeAbstractMethods(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, t *Type, value *Object) (*Tuple, *BaseException) {
+		o := t.ToObject()
+		name := NewStr("__abstractmethods__")
+		if value != None {
+			if raised := SetAttr(f, o, name, value); raised != nil {
+				return nil, raised
+			}
+		}
+		methods, raised := GetAttr(f, o, name, nil)
+		if raised != nil {
+			return nil, raised
+		}
+		isAbstract := GetBool(t.flags&typeFlagAbstract != 0).ToObject()
+		if raised := DelAttr(f, o, name); raised != nil {
+			return nil, raised
+		}
+		return newTestTuple(methods, isAbstract, t.flags&typeFlagAbstract != 0), nil
+	})
+	cases := []invokeTestCase{
+		{args: wrapArgs(newTestClass("Foo", []*Type{ObjectType}, NewDict()), newTestTuple("foo")), want: newTestTuple(newTestTuple("foo"), true, false).ToObject()},
+		{args: wrapArgs(newTestClass("Foo", []*Type{ObjectType}, NewDict()), NewTuple()), want: newTestTuple(NewTuple(), false, false).ToObject()},
+		{args: wrapArgs(newTestClass("Foo", []*Type{ObjectType}, NewDict()), None), wantExc: mustCreateException(AttributeErrorType, "__abstractmethods__")},
+		{args: wrapArgs(TypeType, None), wantExc: mustCreateException(AttributeErrorType, "__abstractmethods__")},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
+
+func TestTypeBasesMRO(t *testing.T) {
+	fun := wrapFuncForTest(func(f *Frame, o *Object) (*Tuple, *BaseException) {
+		bases, raised := GetAttr(f, o, NewStr("__bases__"), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		mro, raised := GetAttr(f, o, NewStr("__mro__"), nil)
+		if raised != nil {
+			return nil, raised
+		}
+		return newTestTuple(bases, mro), nil
+	})
+	fooType := newTestClass("Foo", []*Type{ObjectType}, NewDict())
+	barType := newTestClass("Bar", []*Type{fooType, StrType}, NewDict())
+	cases := []invokeTestCase{
+		{args: wrapArgs(ObjectType), want: newTestTuple(NewTuple(), newTestTuple(ObjectType)).ToObject()},
+		{args: wrapArgs(TypeType), want: newTestTuple(newTestTuple(ObjectType), newTestTuple(TypeType, ObjectType)).ToObject()},
+		{args: wrapArgs(barType), want: newTestTuple(newTestTuple(fooType, StrType), newTestTuple(barType, fooType, StrType, BaseStringType, ObjectType)).ToObject()},
+	}
+	for _, cas := range cases {
+		if err := runInvokeTestCase(fun, &cas); err != "" {
+			t.Error(err)
+		}
+	}
+}
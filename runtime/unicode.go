@@ -203,17 +203,32 @@ func unicodeHash(f *Frame, o *Object) (*Object, *BaseException) {
 	if l == 0 {
 		return NewInt(0).ToObject(), nil
 	}
-	h := int(s[0]) << 7
+	h := hashSecret.prefix ^ int(s[0])<<7
 	for _, r := range s {
 		h = (1000003 * h) ^ int(r)
 	}
 	h ^= l
+	h ^= hashSecret.suffix
 	if h == -1 {
 		h = -2
 	}
 	return NewInt(h).ToObject(), nil
 }
 
+func unicodeIsAlNum(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	return unicodeAllRunes(f, "isalnum", args, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsNumber(r)
+	})
+}
+
+func unicodeIsDigit(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	return unicodeAllRunes(f, "isdigit", args, unicode.IsDigit)
+}
+
+func unicodeIsSpace(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
+	return unicodeAllRunes(f, "isspace", args, unicode.IsSpace)
+}
+
 func unicodeJoin(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 	if raised := checkMethodArgs(f, "join", args, UnicodeType, ObjectType); raised != nil {
 		return nil, raised
@@ -413,6 +428,9 @@ func unicodeStrip(f *Frame, args Args, _ KWArgs) (*Object, *BaseException) {
 func initUnicodeType(dict map[string]*Object) {
 	dict["__getnewargs__"] = newBuiltinFunction("__getnewargs__", unicodeGetNewArgs).ToObject()
 	dict["encode"] = newBuiltinFunction("encode", unicodeEncode).ToObject()
+	dict["isalnum"] = newBuiltinFunction("isalnum", unicodeIsAlNum).ToObject()
+	dict["isdigit"] = newBuiltinFunction("isdigit", unicodeIsDigit).ToObject()
+	dict["isspace"] = newBuiltinFunction("isspace", unicodeIsSpace).ToObject()
 	dict["join"] = newBuiltinFunction("join", unicodeJoin).ToObject()
 	dict["strip"] = newBuiltinFunction("strip", unicodeStrip).ToObject()
 	UnicodeType.slots.Add = &binaryOpSlot{unicodeAdd}
@@ -508,6 +526,24 @@ func unicodeCompareEq(f *Frame, v *Unicode, w *Object, eq bool) (*Object, *BaseE
 	return GetBool((i == lhsLen) == eq).ToObject(), nil
 }
 
+// unicodeAllRunes returns True if args[0] is a non-empty unicode object whose
+// runes all satisfy pred.
+func unicodeAllRunes(f *Frame, method string, args Args, pred func(rune) bool) (*Object, *BaseException) {
+	if raised := checkMethodArgs(f, method, args, UnicodeType); raised != nil {
+		return nil, raised
+	}
+	runes := toUnicodeUnsafe(args[0]).Value()
+	if len(runes) == 0 {
+		return False.ToObject(), nil
+	}
+	for _, r := range runes {
+		if !pred(r) {
+			return False.ToObject(), nil
+		}
+	}
+	return True.ToObject(), nil
+}
+
 func unicodeCoerce(f *Frame, o *Object) (*Unicode, *BaseException) {
 	switch {
 	case o.isInstance(StrType):
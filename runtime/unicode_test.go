@@ -181,6 +181,19 @@ func TestUnicodeMethods(t *testing.T) {
 		want       *Object
 		wantExc    *BaseException
 	}{
+		{"isalnum", wrapArgs(NewUnicode("abc123")), True.ToObject(), nil},
+		{"isalnum", wrapArgs(NewUnicode("Empf\u00e4nger")), True.ToObject(), nil},
+		{"isalnum", wrapArgs(NewUnicode("foo-bar")), False.ToObject(), nil},
+		{"isalnum", wrapArgs(NewUnicode("")), False.ToObject(), nil},
+		{"isdigit", wrapArgs(NewUnicode("0123")), True.ToObject(), nil},
+		{"isdigit", wrapArgs(NewUnicode("\u0663")), True.ToObject(), nil},
+		{"isdigit", wrapArgs(NewUnicode("12a")), False.ToObject(), nil},
+		{"isdigit", wrapArgs(NewUnicode("")), False.ToObject(), nil},
+		{"isspace", wrapArgs(NewUnicode(" \t\n")), True.ToObject(), nil},
+		{"isspace", wrapArgs(NewUnicode("\u00a0\u2003")), True.ToObject(), nil},
+		{"isspace", wrapArgs(NewUnicode(" x ")), False.ToObject(), nil},
+		{"isspace", wrapArgs(NewUnicode("")), False.ToObject(), nil},
+		{"isspace", wrapArgs(NewUnicode(" "), "foo"), nil, mustCreateException(TypeErrorType, "'isspace' of 'unicode' requires 1 arguments")},
 		{"join", wrapArgs(NewUnicode(","), newTestList("foo", "bar")), NewUnicode("foo,bar").ToObject(), nil},
 		{"join", wrapArgs(NewUnicode(":"), newTestList(NewUnicode("foo"), "bar", NewUnicode("baz"))), NewUnicode("foo:bar:baz").ToObject(), nil},
 		{"join", wrapArgs(NewUnicode("nope"), NewTuple()), NewUnicode("").ToObject(), nil},
@@ -103,6 +103,24 @@ func weakRefHash(f *Frame, o *Object) (result *Object, raised *BaseException) {
 	return result, raised
 }
 
+// getWeakRef returns the weak reference to o, creating it if necessary.
+func getWeakRef(o *Object) *WeakRef {
+	nilPtr := unsafe.Pointer(nil)
+	addr := (*unsafe.Pointer)(unsafe.Pointer(&o.ref))
+	// Atomically fetch or initialize o.ref.
+	for {
+		p := atomic.LoadPointer(addr)
+		if p != nilPtr {
+			return (*WeakRef)(p)
+		}
+		r := &WeakRef{Object: Object{typ: WeakRefType}, ptr: uintptr(o.toPointer())}
+		if atomic.CompareAndSwapPointer(addr, nilPtr, r.toPointer()) {
+			runtime.SetFinalizer(o, weakRefFinalizeReferent)
+			return r
+		}
+	}
+}
+
 func weakRefNew(f *Frame, t *Type, args Args, _ KWArgs) (*Object, *BaseException) {
 	if raised := checkFunctionVarArgs(f, "__new__", args, ObjectType); raised != nil {
 		return nil, raised
@@ -112,24 +130,7 @@ func weakRefNew(f *Frame, t *Type, args Args, _ KWArgs) (*Object, *BaseException
 		format := "__new__ expected at most 2 arguments, got %d"
 		return nil, f.RaiseType(TypeErrorType, fmt.Sprintf(format, argc))
 	}
-	o := args[0]
-	nilPtr := unsafe.Pointer(nil)
-	addr := (*unsafe.Pointer)(unsafe.Pointer(&o.ref))
-	var r *WeakRef
-	// Atomically fetch or initialize o.ref.
-	for {
-		p := atomic.LoadPointer(addr)
-		if p != nilPtr {
-			r = (*WeakRef)(p)
-			break
-		} else {
-			r = &WeakRef{Object: Object{typ: WeakRefType}, ptr: uintptr(o.toPointer())}
-			if atomic.CompareAndSwapPointer(addr, nilPtr, r.toPointer()) {
-				runtime.SetFinalizer(o, weakRefFinalizeReferent)
-				break
-			}
-		}
-	}
+	r := getWeakRef(args[0])
 	if argc > 1 {
 		r.mutex.Lock()
 		r.callbacks = append(r.callbacks, args[1])
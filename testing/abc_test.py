@@ -0,0 +1,140 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# pylint: disable=abstract-method,abstract-class-instantiated
+
+import abc
+
+
+class Shape(object):
+  __metaclass__ = abc.ABCMeta
+
+  @abc.abstractmethod
+  def area(self):
+    pass
+
+  @abc.abstractmethod
+  def perimeter(self):
+    pass
+
+
+class Square(Shape):
+
+  def __init__(self, side):
+    self.side = side
+
+  def area(self):
+    return self.side ** 2
+
+  def perimeter(self):
+    return self.side * 4
+
+
+class HalfSquare(Shape):
+
+  def area(self):
+    return 0
+
+
+assert Shape.__abstractmethods__ == frozenset(['area', 'perimeter'])
+assert HalfSquare.__abstractmethods__ == frozenset(['perimeter'])
+assert Square.__abstractmethods__ == frozenset()
+
+try:
+  Shape()
+except TypeError as e:
+  assert str(e) == ("Can't instantiate abstract class Shape with abstract "
+                    "methods area, perimeter")
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+try:
+  HalfSquare()
+except TypeError as e:
+  assert str(e) == ("Can't instantiate abstract class HalfSquare with "
+                    "abstract methods perimeter")
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+assert Square(3).area() == 9
+assert isinstance(Square(3), Shape)
+assert issubclass(Square, Shape)
+
+
+# Test virtual subclass registration.
+
+class Circle(object):
+  pass
+
+
+class Ellipse(Circle):
+  pass
+
+
+assert not isinstance(Circle(), Shape)
+assert not issubclass(Circle, Shape)
+Shape.register(Circle)
+assert isinstance(Circle(), Shape)
+assert issubclass(Circle, Shape)
+assert issubclass(Ellipse, Shape)
+assert Shape not in Circle.__mro__
+
+Shape.register(int)
+assert isinstance(3, Shape)
+
+try:
+  Shape.register(3)
+except TypeError as e:
+  assert str(e) == 'Can only register classes'
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+
+# Test that subclasses of a registered ABC are consulted.
+
+class Polygon(Shape):
+  pass
+
+
+class Triangle(object):
+  pass
+
+
+Polygon.register(Triangle)
+assert issubclass(Triangle, Polygon)
+assert issubclass(Triangle, Shape)
+
+
+# Test abstract properties.
+
+class Named(object):
+  __metaclass__ = abc.ABCMeta
+
+  @abc.abstractproperty
+  def name(self):
+    pass
+
+
+class Person(Named):
+  name = 'Alice'
+
+
+try:
+  Named()
+except TypeError:
+  pass
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+assert Person().name == 'Alice'
@@ -49,6 +49,49 @@ except ValueError as e:
 else:
   raise AssertionError('this was supposed to raise an exception')
 
+try:
+  bar, baz = [1]
+except ValueError as e:
+  assert str(e) == 'need more than 1 value to unpack'
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+try:
+  bar, baz = iter(())
+except ValueError as e:
+  assert str(e) == 'need more than 0 values to unpack'
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+try:
+  bar, baz = 1
+except TypeError as e:
+  assert str(e) == "'int' object is not iterable"
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+[foo, (bar, [baz, (qux, quux)])] = 1, [2, (3, 'ab')]
+assert (foo, bar, baz, qux, quux) == (1, 2, 3, 'a', 'b')
+
+try:
+  foo, (bar, (baz, qux)) = 1, (2, (3, 4, 5))
+except ValueError as e:
+  assert str(e) == 'too many values to unpack'
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+try:
+  foo, (bar, (baz, qux)) = 1, (2, (3,))
+except ValueError as e:
+  assert str(e) == 'need more than 1 value to unpack'
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+foo = []
+for bar, (baz, qux) in [(1, (2, 3)), (4, (5, 6))]:
+  foo.append(bar + baz + qux)
+assert foo == [6, 15]
+
 foo = Foo()
 
 foo.bar = 1
@@ -94,6 +137,50 @@ assert foo == ["bar", "baz", "bar", "baz"]
 assert bar is foo
 
 
+class Accumulator(object):
+
+  def __init__(self):
+    self.calls = []
+
+  def __add__(self, other):
+    self.calls.append(('__add__', other))
+    return 'add'
+
+  def __iadd__(self, other):
+    self.calls.append(('__iadd__', other))
+    return self
+
+  def __isub__(self, other):
+    self.calls.append(('__isub__', other))
+    return NotImplemented
+
+  def __rsub__(self, other):
+    return 'rsub'
+
+  def __sub__(self, other):
+    self.calls.append(('__sub__', other))
+    return 'sub'
+
+
+# Augmented assignment prefers the in-place method over the binary one.
+foo = bar = Accumulator()
+foo += 1
+foo += 2
+assert foo is bar
+assert foo.calls == [('__iadd__', 1), ('__iadd__', 2)]
+
+# Returning NotImplemented from the in-place method falls back to the binary
+# method.
+foo -= 3
+assert foo == 'sub'
+assert bar.calls[-2:] == [('__isub__', 3), ('__sub__', 3)]
+
+# Types without in-place methods fall back to the reflected binary method.
+foo = 4
+foo -= Accumulator()
+assert foo == 'rsub'
+
+
 # Multiple target assignment should only evaluate rhs once.
 def foo():  # pylint: disable=function-redefined
   foo_ran[0] += 1
@@ -28,6 +28,26 @@ assert abs(3.4) == 3.4
 assert abs(-3.4) == 3.4
 assert isinstance(abs(-3.4), float)
 
+assert abs(3 - 4j) == 5.0
+assert abs(complex(0, -2)) == 2.0
+assert isinstance(abs(1j), float)
+
+assert abs(True) == 1 and abs(False) == 0
+
+
+class Abs(object):
+  def __abs__(self):
+    return 'abs'
+
+
+class AbsInt(int):
+  def __abs__(self):
+    return 'absint'
+
+
+assert abs(Abs()) == 'abs'
+assert abs(AbsInt(-3)) == 'absint'
+
 try:
   abs('a')
 except TypeError as e:
@@ -56,6 +76,15 @@ else:
   raise AssertionError('this was supposed to raise an exception')
 
 
+def all_gen():
+  yield 1
+  yield 0
+  raise AssertionError('all() did not short-circuit')
+
+assert not all(all_gen())
+assert all(x for x in ())
+
+
 # any(iterable)
 
 assert any([1, 2, 3])
@@ -78,6 +107,152 @@ else:
   raise AssertionError('this was supposed to raise an exception')
 
 
+def any_gen():
+  yield 0
+  yield 1
+  raise AssertionError('any() did not short-circuit')
+
+assert any(any_gen())
+assert not any(x for x in ())
+
+
+class Truthiness(object):
+
+  def __init__(self, n):
+    self.n = n
+
+  def __len__(self):
+    return self.n
+
+
+class NonZero(Truthiness):
+
+  def __nonzero__(self):
+    return self.n > 1
+
+# Truthiness comes from __nonzero__, falling back to __len__.
+assert not all([Truthiness(1), Truthiness(0)])
+assert any([Truthiness(0), Truthiness(2)])
+assert not any([NonZero(1)])
+assert all([NonZero(2), NonZero(0)]) is False
+assert all([NonZero(2), NonZero(3)]) is True
+
+
+# apply(function, args, kwargs)
+
+def apply_foo(*args, **kwargs):
+  return args, kwargs
+
+assert apply(apply_foo) == ((), {})
+assert apply(apply_foo, (1, 2)) == ((1, 2), {})
+assert apply(apply_foo, [3], {'a': 4}) == ((3,), {'a': 4})
+
+try:
+  apply(apply_foo, 123)
+except TypeError as e:
+  assert str(e) == 'apply() arg 2 expected sequence, found int'
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+try:
+  apply(apply_foo, (), [])
+except TypeError as e:
+  assert str(e) == 'apply() arg 3 expected dictionary, found list'
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+
+# buffer(object[, offset[, size]])
+
+b = buffer('hello world', 6)
+assert len(b) == 5
+assert str(b) == 'world'
+assert b[0] == 'w'
+assert b[1:3] == 'or'
+assert b + '!' == 'world!'
+assert b * 2 == 'worldworld'
+assert str(buffer('hello world', 0, 5)) == 'hello'
+assert str(buffer('abc', 1, 100)) == 'bc'
+assert str(buffer(buffer('abcdef', 1), 1, 2)) == 'cd'
+assert buffer('hello world', 0, 5) == buffer('hello')
+assert buffer('hello') != 'hello'
+
+try:
+  b[0] = 'x'
+except TypeError as e:
+  assert str(e) == 'buffer is read-only'
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+try:
+  buffer('abc', -1)
+except ValueError as e:
+  assert str(e) == 'offset must be zero or positive'
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+try:
+  buffer([1, 2])
+except TypeError as e:
+  assert str(e) == 'buffer object expected'
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+
+# bytearray([source[, encoding[, errors]]])
+
+b = bytearray('h\xc3\xa9llo')
+assert len(b) == 6
+assert b[0] == ord('h')
+assert isinstance(b[1:3], bytearray)
+assert b.decode('utf-8') == u'h\xe9llo'
+assert isinstance(b.decode('utf-8'), unicode)
+assert bytearray('\xff').decode('utf-8', 'replace') == u'\ufffd'
+assert bytearray(u'h\xe9llo', 'utf-8') == b
+
+# str() round-trips the raw bytes.
+assert str(b) == 'h\xc3\xa9llo'
+assert bytearray(str(b)) == b
+assert str(bytearray('\x00\xff')) == '\x00\xff'
+assert repr(bytearray('ab')) == "bytearray(b'ab')"
+
+# Concatenation with str produces a bytearray from either side.
+assert bytearray('ab') + 'c' == bytearray('abc')
+assert isinstance(bytearray('ab') + 'c', bytearray)
+assert isinstance('c' + bytearray('ab'), bytearray)
+assert 'c' + bytearray('ab') == 'cab'
+assert bytearray('ab') == 'ab'
+
+b = bytearray(3)
+assert b == '\x00\x00\x00'
+b[0] = 65
+b[-1] = 'Z'
+b.append(33)
+assert str(b) == 'A\x00Z!'
+assert list(bytearray([1, 2])) == [1, 2]
+assert 98 in bytearray('abc') and 'bc' in bytearray('abc')
+
+try:
+  bytearray(u'abc')
+except TypeError as e:
+  assert str(e) == 'unicode argument without an encoding'
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+try:
+  bytearray([256])
+except ValueError as e:
+  assert str(e) == 'byte must be in range(0, 256)'
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+try:
+  hash(bytearray())
+except TypeError:
+  pass
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
 # callable(x)
 
 assert not callable(1)
@@ -103,6 +278,33 @@ class bar(object):
 
 assert callable(bar)
 assert callable(bar())
+assert callable(bar().__call__)
+assert callable(int)
+assert not callable(object())
+
+# chr(i) and ord(c)
+
+assert chr(0) == '\x00'
+assert chr(255) == '\xff'
+assert chr(65L) == 'A'
+assert ord('\x00') == 0
+assert ord('\xff') == 255
+assert ord(u'\u1234') == 0x1234
+assert all(ord(chr(i)) == i for i in range(256))
+
+for i in (256, -1):
+  try:
+    chr(i)
+    raise AssertionError
+  except ValueError:
+    pass
+
+for c in ('', 'ab', u'ab', 1):
+  try:
+    ord(c)
+    raise AssertionError
+  except TypeError:
+    pass
 
 # cmp(x)
 
@@ -247,6 +449,44 @@ a, b = NoCmp(1), Cmp(2)
 assert cmp(a, b) == -1
 assert b.cmp_called
 
+# coerce(x, y)
+
+x, y = coerce(1, 2.5)
+assert type(x) is float and type(y) is float
+assert (x, y) == (1.0, 2.5)
+x, y = coerce(2.5, 1)
+assert type(x) is float and type(y) is float
+assert coerce(1, 2L) == (1L, 2L)
+assert type(coerce(1, 2L)[0]) is long
+x, y = coerce(1, 2j)
+assert type(x) is complex and type(y) is complex
+assert (x, y) == (1 + 0j, 2j)
+assert coerce(None, None) == (None, None)
+
+
+class Coerce(object):
+
+  def __init__(self, value):
+    self.value = value
+
+  def __coerce__(self, other):
+    if isinstance(other, Coerce):
+      return self, other
+    return self, Coerce(other)
+
+
+x, y = coerce(Coerce(1), 2)
+assert (x.value, y.value) == (1, 2)
+x, y = coerce(2, Coerce(1))
+assert (x.value, y.value) == (2, 1)
+
+try:
+  coerce(1, 'a')
+except TypeError as e:
+  assert str(e) == 'number coercion failed'
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
 # Test delattr
 
 class Foo(object):
@@ -293,6 +533,273 @@ try:
 except TypeError:
   pass
 
+# dir([object])
+
+class DirBase(object):
+
+  base_attr = 1
+
+  def BaseMethod(self):
+    pass
+
+
+class DirDerived(DirBase):
+
+  derived_attr = 2
+
+
+def PublicNames(names):
+  return [n for n in names if not n.startswith('__')]
+
+
+d = DirDerived()
+d.instance_attr = 3
+assert PublicNames(dir(d)) == ['BaseMethod', 'base_attr', 'derived_attr',
+                               'instance_attr']
+assert PublicNames(dir(DirDerived)) == ['BaseMethod', 'base_attr',
+                                        'derived_attr']
+assert '__class__' in dir(d) and '__init__' in dir(d)
+# A class's dir() does not include the attributes of its metaclass.
+assert 'mro' not in dir(DirDerived)
+
+
+class CustomDir(object):
+
+  def __dir__(self):
+    return ['b', 'c', 'a']
+
+
+assert dir(CustomDir()) == ['a', 'b', 'c']
+
+
+class BadDir(object):
+
+  def __dir__(self):
+    return ('a', 'b')
+
+
+try:
+  dir(BadDir())
+  raise AssertionError
+except TypeError:
+  pass
+
+
+def DirInFunction(a, b=2):
+  c = 3
+  return dir()
+
+
+assert DirInFunction(1) == ['a', 'b', 'c']
+assert 'DirInFunction' in dir() and 'BaseMethod' not in dir()
+
+# globals() and locals()
+
+# globals() is the live module namespace.
+globals()['_injected'] = 42
+assert _injected == 42  # pylint: disable=undefined-variable
+_rebound = 1
+globals()['_rebound'] = 2
+assert _rebound == 2
+del globals()['_injected']
+assert '_injected' not in globals()
+
+# At module level locals() is globals().
+assert locals() is globals()
+
+def LocalsSnapshot(a, b=2):
+  first = dict(locals())
+  c = a + b
+  return first, locals()
+
+first, second = LocalsSnapshot(1)
+assert first == {'a': 1, 'b': 2}
+assert sorted(second) == ['a', 'b', 'c', 'first']
+assert second['c'] == 3
+
+# Each call to locals() updates and returns the same dict.
+def LocalsUnbound():
+  d = locals()
+  x = 1
+  assert d is locals() and d['x'] == 1
+  del x
+  locals()
+  return d
+
+assert sorted(LocalsUnbound()) == ['d']
+
+def LocalsInComprehension(n):
+  return [k for k in locals()]
+
+assert LocalsInComprehension(1) == ['n']
+
+class LocalsClass(object):
+  attr = 1
+  names = sorted(locals())
+
+assert LocalsClass.names == ['__module__', 'attr']
+
+# id(object)
+
+class IdClass(object):
+  pass
+
+# Names bound to the same object share an id, distinct live objects never do.
+objs = [None, True, 1, 2 ** 100, 1.5, 'foo', u'foo', (1, 2), [], [], {}, {},
+        set(), IdClass, IdClass(), IdClass(), len, IdClass.__init__]
+for a in objs:
+  b = a
+  assert id(a) == id(b)
+  assert isinstance(id(a), (int, long))
+for a in objs:
+  for b in objs:
+    assert (id(a) == id(b)) == (a is b), (a, b)
+
+# An object's id is stable for its lifetime, even across collections.
+import gc  # pylint: disable=g-import-not-at-top
+obj = IdClass()
+obj_id = id(obj)
+garbage = [[i] for i in range(10000)]
+del garbage
+gc.collect()
+assert id(obj) == obj_id
+
+# intern(s)
+
+assert intern('a' + 'b') is intern('ab')
+
+# Strings built at runtime are distinct objects until they are interned, after
+# which the first interned instance is returned for every equal string.
+s1 = ''.join(['not ', 'interned'])
+s2 = ''.join(['not interned'])
+assert s1 == s2 and s1 is not s2
+assert intern(s1) is s1
+assert intern(s2) is s1
+assert intern(s2) is intern(s1)
+
+try:
+  intern(1)
+  raise AssertionError
+except TypeError:
+  pass
+
+class StrSub(str):
+  pass
+
+try:
+  intern(StrSub('foo'))
+  raise AssertionError
+except TypeError:
+  pass
+
+# isinstance(object, classinfo) and issubclass(class, classinfo)
+
+assert isinstance(1, int)
+assert isinstance(1, (str, int))
+assert isinstance(1, (str, (float, (int,))))
+assert not isinstance(1, (str, (float, ())))
+assert issubclass(bool, (str, (float, int)))
+assert not issubclass(bool, (str, (float,)))
+
+class Duck(type):
+
+  def __instancecheck__(cls, instance):
+    return hasattr(instance, 'quack')
+
+  def __subclasscheck__(cls, subclass):
+    return hasattr(subclass, 'quack')
+
+
+class DuckLike(object):
+  __metaclass__ = Duck
+
+
+class Mallard(object):
+
+  def quack(self):
+    pass
+
+assert isinstance(Mallard(), DuckLike)
+assert isinstance(Mallard(), (int, DuckLike))
+assert not isinstance(1, DuckLike)
+assert issubclass(Mallard, DuckLike)
+assert not issubclass(int, DuckLike)
+
+try:
+  isinstance(1, (str, 2))
+except TypeError:
+  pass
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+try:
+  issubclass(bool, 'int')
+except TypeError:
+  pass
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+# Classic classes.
+class ClassicBase:
+  pass
+
+
+class ClassicDerived(ClassicBase):
+  pass
+
+
+class ClassicOther:
+  pass
+
+
+class ClassicMulti(ClassicOther, ClassicDerived):
+  pass
+
+classic = ClassicMulti()
+assert isinstance(classic, ClassicMulti)
+assert isinstance(classic, ClassicBase)
+assert isinstance(classic, (int, ClassicOther))
+assert not isinstance(ClassicDerived(), ClassicOther)
+assert classic.__class__ is ClassicMulti
+assert ClassicMulti.__bases__ == (ClassicOther, ClassicDerived)
+assert issubclass(ClassicMulti, ClassicBase)
+assert issubclass(ClassicDerived, ClassicDerived)
+assert not issubclass(ClassicBase, ClassicDerived)
+assert issubclass(ClassicDerived, (ClassicOther, ClassicBase))
+
+# Objects can masquerade as instances of another class via __class__.
+class ClassProxy(object):
+  __class__ = ClassicDerived
+
+assert isinstance(ClassProxy(), ClassicBase)
+assert isinstance(ClassProxy(), ClassProxy)
+assert not isinstance(ClassProxy(), ClassicOther)
+
+# Any object with a tuple __bases__ attribute can stand in for a class.
+class AbstractClass(object):
+
+  def __init__(self, *bases):
+    self.__bases__ = bases
+
+abstract_base = AbstractClass()
+abstract_derived = AbstractClass(AbstractClass(), abstract_base)
+assert issubclass(abstract_derived, abstract_base)
+assert not issubclass(abstract_base, abstract_derived)
+
+
+class AbstractInstance(object):
+  __class__ = abstract_derived
+
+assert isinstance(AbstractInstance(), abstract_base)
+assert not isinstance(1, abstract_base)
+
+try:
+  issubclass(1, abstract_base)
+except TypeError:
+  pass
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
 # Test sorted
 
 assert sorted([3, 2, 4, 1]) == [1, 2, 3, 4]
@@ -300,6 +807,100 @@ assert sorted([]) == []
 assert sorted(["a", "e", "c", "b"]) == ["a", "b", "c", "e"]
 assert sorted((3, 1, 5, 2, 4)) == [1, 2, 3, 4, 5]
 assert sorted({"foo": 1, "bar": 2}) == ["bar", "foo"]
+assert sorted([3, -4, 1], key=abs) == [1, 3, -4]
+assert sorted([3, 2, 4, 1], reverse=True) == [4, 3, 2, 1]
+assert sorted([3, 2, 4, 1], cmp=lambda x, y: y - x) == [4, 3, 2, 1]
+assert sorted([(1, 'b'), (0, 'a'), (1, 'a')], key=lambda t: t[0],
+              reverse=True) == [(1, 'b'), (1, 'a'), (0, 'a')]
+
+
+# Exceptions raised while comparing propagate out of sort and the list is
+# left intact.
+class Unorderable(object):
+  def __lt__(self, other):
+    raise ValueError('foo')
+
+l = [3, 1j, 2, 2j]
+try:
+  l.sort()
+except TypeError as e:
+  assert str(e) == 'no ordering relation is defined for complex numbers'
+else:
+  raise AssertionError
+assert l == [3, 1j, 2, 2j]
+
+l = [Unorderable(), Unorderable()]
+try:
+  sorted(l)
+except ValueError as e:
+  assert str(e) == 'foo'
+else:
+  raise AssertionError
+assert len(l) == 2 and all(isinstance(x, Unorderable) for x in l)
+
+def RaisingKey(x):
+  if x == 2:
+    raise KeyError(x)
+  return x
+
+l = [3, 2, 1]
+try:
+  l.sort(key=RaisingKey)
+except KeyError:
+  pass
+else:
+  raise AssertionError
+assert l == [3, 2, 1]
+
+# Test range
+
+assert range(3) == [0, 1, 2]
+assert range(5, 0, -2) == [5, 3, 1]
+assert range(-1, -4, -1) == [-1, -2, -3]
+assert range(2L, 4L) == [2, 3]
+
+try:
+  range(1.0)
+except TypeError:
+  pass
+else:
+  raise AssertionError
+
+try:
+  range(1, 3, 0)
+except ValueError:
+  pass
+else:
+  raise AssertionError
+
+try:
+  range(0, 2 ** 64)
+except OverflowError:
+  pass
+else:
+  raise AssertionError
+
+# Test reversed
+
+assert list(reversed([1, 2, 3])) == [3, 2, 1]
+assert list(reversed('abc')) == ['c', 'b', 'a']
+assert list(reversed(xrange(3))) == [2, 1, 0]
+assert list(reversed(())) == []
+
+
+class Reversible(object):
+
+  def __reversed__(self):
+    return iter('foo')
+
+assert list(reversed(Reversible())) == ['f', 'o', 'o']
+
+try:
+  reversed({})
+except TypeError:
+  pass
+else:
+  raise AssertionError('this was supposed to raise an exception')
 
 # Test zip
 
@@ -329,6 +930,14 @@ try:
 except TypeError:
   pass
 
+# zip is eager: it returns a list and consumes its iterators up front, pulling
+# from each in turn until one is exhausted.
+assert isinstance(zip('ab', 'cd'), list)
+it = iter([1, 2, 3])
+z = zip(it, 'a')
+assert z == [(1, 'a')]
+assert list(it) == [3]
+
 # Test map
 
 assert map(str, []) == []
@@ -349,6 +958,89 @@ assert map(None, a) == a
 assert map(None, a) is not a
 assert map(None, (1, 2, 3)) == [1, 2, 3]
 
+# With several iterables, shorter ones are padded with None up to the length of
+# the longest.
+assert map(None, [1, 2, 3], 'ab') == [(1, 'a'), (2, 'b'), (3, None)]
+assert map(None, 'ab', [1], (5, 6, 7)) == [
+    ('a', 1, 5), ('b', None, 6), (None, None, 7)]
+assert map(lambda *args: args, [1], [2, 3], ()) == [
+    (1, 2, None), (None, 3, None)]
+assert map(None, [], ()) == []
+
+# Test filter
+
+assert filter(None, [0, 1, '', 'a', None, []]) == [1, 'a']
+assert filter(lambda x: x % 2, [1, 2, 3, 4]) == [1, 3]
+assert filter(lambda x: x % 2, xrange(5)) == [1, 3]
+assert filter(None, {'a': 1, '': 2}) == ['a']
+# str, unicode and tuple inputs produce results of the same type.
+assert filter(lambda c: c != 'b', 'abcb') == 'ac'
+assert filter(str.isdigit, 'a1b2') == '12'
+assert filter(None, '') == ''
+assert filter(lambda c: c != u'b', u'abc') == u'ac'
+assert isinstance(filter(None, u'abc'), unicode)
+assert filter(lambda x: x > 1, (1, 2, 3)) == (2, 3)
+assert filter(None, ()) == ()
+try:
+  filter(None, 1)
+except TypeError:
+  pass
+else:
+  raise AssertionError
+
+# Test max and min
+
+assert max(3, 1, 2) == 3
+assert min(3, 1, 2) == 1
+assert max([3, 1, 2]) == 3
+assert min('bca') == 'a'
+assert max(['a', 'bbb', 'cc'], key=len) == 'bbb'
+assert min('a', 'bbb', 'cc', key=len) == 'a'
+# Ties go to the first element seen.
+assert max([(1, 'a'), (1, 'b')], key=lambda t: t[0]) == (1, 'a')
+assert min([(1, 'a'), (1, 'b')], key=lambda t: t[0]) == (1, 'a')
+for fn in (max, min):
+  try:
+    fn([])
+  except ValueError as e:
+    assert str(e) == '%s() arg is an empty sequence' % fn.__name__
+  else:
+    raise AssertionError
+
+# Test sum
+
+assert sum([1, 2, 3]) == 6
+assert sum([1, 2, 3], 10) == 16
+assert sum(xrange(4), -6) == 0
+assert sum([0.5, 0.25]) == 0.75
+assert sum([1, 0.5], 0.25) == 1.75
+assert sum([[2], [3]], [1]) == [1, 2, 3]
+assert sum([], 5) == 5
+for start in ('', u'', 'a'):
+  try:
+    sum(['b'], start)
+  except TypeError as e:
+    assert str(e) == "sum() can't sum strings [use ''.join(seq) instead]"
+  else:
+    raise AssertionError
+
+# bit_length
+
+assert (0).bit_length() == 0
+assert (-37).bit_length() == 6
+assert (1 << 100).bit_length() == 101
+
+# Test hash: numbers that compare equal hash equal.
+
+for v in (0, 1, -1, 2, -2, 42, 1 << 62, -(1 << 62)):
+  assert hash(v) == hash(long(v)) == hash(float(v)) == hash(complex(v, 0))
+for v in (1 << 64, -(1 << 64), 1 << 100, -(1 << 100), 5 ** 20 << 40):
+  assert hash(v) == hash(float(v)) == hash(complex(v, 0))
+for v in (0.5, -0.5, 2.25, -1e-3):
+  assert hash(v) == hash(complex(v, 0))
+assert hash(-1) == -2
+assert hash(True) == hash(1) == hash(1.0)
+
 # divmod(v, w)
 
 import sys
@@ -379,6 +1071,23 @@ assert isinstance(divmod(3.25, 1.0), tuple)
 assert isinstance(divmod(3.25, 1.0)[0], float)
 assert isinstance(divmod(3.25, 1.0)[1], float)
 
+assert divmod(7.5, 2.0) == (3.0, 1.5) and 7.5 % 2.0 == 1.5
+assert divmod(-7.5, 2.0) == (-4.0, 0.5) and -7.5 % 2.0 == 0.5
+assert divmod(7.5, -2.0) == (-4.0, -0.5) and 7.5 % -2.0 == -0.5
+assert divmod(7, 2.5) == (2.0, 2.0) and 7 // 2.5 == 2.0
+# The quotient is floored exactly rather than rounded up to 10.
+assert divmod(1.0, 0.1)[0] == 1.0 // 0.1 == 9.0
+
+import math
+
+inf = float('inf')
+q, r = divmod(inf, 1.0)
+assert math.isnan(q) and math.isnan(r)
+assert math.isnan(inf % 1)
+assert divmod(1.0, inf) == (0.0, 1.0)
+assert divmod(-1.0, inf) == (-1.0, inf)
+assert divmod(1.0, -inf) == (-1.0, -inf)
+
 try:
   divmod('a', 'b')
 except TypeError as e:
@@ -386,6 +1095,65 @@ except TypeError as e:
 else:
   assert AssertionError
 
+# pow(x, y[, z])
+
+assert pow(2, 10) == 1024
+assert pow(2, -1) == 0.5
+assert pow(2, 10, 1000) == 24
+assert pow(2, 3, -5) == -2
+assert pow(-2, 3, 5) == 2
+assert isinstance(pow(2, 10, 1000), int)
+assert isinstance(pow(2L, 10, 1000), long)
+
+# The modulus is applied as the power is computed, so huge exponents are cheap.
+# 2 ** 127 - 1 is prime, so Fermat's little theorem applies.
+assert pow(3, 2 ** 127 - 2, 2 ** 127 - 1) == 1
+assert pow(7, 10 ** 30, 13) == 9
+
+for args, exc, msg in [
+    ((2, -1, 5), TypeError,
+     'pow() 2nd argument cannot be negative when 3rd argument specified'),
+    ((2, 3, 0), ValueError, 'pow() 3rd argument cannot be 0'),
+    ((2.0, 3, 5), TypeError,
+     'pow() 3rd argument not allowed unless all arguments are integers')]:
+  try:
+    pow(*args)
+  except exc as e:
+    assert str(e) == msg, str(e)
+  else:
+    raise AssertionError
+
+
+class Pow(object):
+
+  def __pow__(self, other, mod=None):
+    return other, mod
+
+assert pow(Pow(), 2) == (2, None)
+assert pow(Pow(), 2, 3) == (2, 3)
+
+# reload(module)
+
+import keyword  # pylint: disable=g-import-not-at-top
+
+# Reloading re-runs the module's code in its existing namespace: rebound
+# globals are restored while unrelated attributes survive.
+keyword_iskeyword = keyword.iskeyword
+keyword.kwlist = None
+keyword.extra = 'extra'
+assert reload(keyword) is keyword
+assert 'print' in keyword.kwlist
+assert keyword.extra == 'extra'
+assert keyword.iskeyword is not keyword_iskeyword
+assert keyword.iskeyword('print')
+
+try:
+  reload(1)
+except TypeError:
+  pass
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
 # Check for a bug where zip() and map() were not properly cleaning their
 # internal exception state. See:
 # https://github.com/google/grumpy/issues/305
@@ -48,3 +48,393 @@ except AttributeError:
   pass
 else:
   raise AssertionError
+
+
+class Adder(object):
+
+  def __init__(self, n):
+    self.n = n
+
+  def __call__(self, x, y=0):
+    return self.n + x + y
+
+
+class SubAdder(Adder):
+  pass
+
+
+add = Adder(1)
+assert add(2) == 3
+assert add(2, y=3) == 6
+assert SubAdder(10)(5) == 15
+
+# Instance attributes are not consulted when calling an object.
+foo.__call__ = lambda: 'instance'
+try:
+  foo()
+except TypeError as e:
+  assert str(e) == "'Foo' object is not callable"
+else:
+  raise AssertionError
+
+
+class Prop(object):
+
+  @property
+  def value(self):
+    return 42
+
+# Properties are returned as-is when looked up on the class.
+assert isinstance(Prop.value, property)
+assert Prop.value.fget(None) == 42
+assert Prop().value == 42
+
+# type() with three arguments creates a new class.
+
+
+def Greet(self):
+  return 'hello ' + self.name
+
+
+Greeter = type('Greeter', (), {'name': 'world', 'greet': Greet})
+assert Greeter.__name__ == 'Greeter'
+assert Greeter.__bases__ == (object,)
+assert Greeter.__module__ == __name__
+assert Greeter().greet() == 'hello world'
+assert isinstance(Greeter(), Greeter)
+
+SubGreeter = type('SubGreeter', (Greeter,), {'name': 'there'})
+assert SubGreeter.__bases__ == (Greeter,)
+assert issubclass(SubGreeter, Greeter)
+assert SubGreeter().greet() == 'hello there'
+assert SubGreeter.__mro__ == (SubGreeter, Greeter, object)
+
+
+class Meta(type):
+  pass
+
+
+WithMeta = Meta('WithMeta', (object,), {})
+# The metaclass of the bases is used for classes created via type().
+assert type(type('Derived', (WithMeta,), {})) is Meta
+
+try:
+  type('Foo', ())
+except TypeError as e:
+  assert str(e) == 'type() takes 1 or 3 arguments'
+else:
+  raise AssertionError
+
+try:
+  type('Foo', [], {})
+except TypeError as e:
+  assert str(e) == 'type() argument 2 must be tuple, not list'
+else:
+  raise AssertionError
+
+# Metaclasses declared with __metaclass__ are inherited by subclasses.
+
+
+class Tagging(type):
+
+  def __new__(mcs, name, bases, dict):
+    dict['tag'] = name.lower()
+    return super(Tagging, mcs).__new__(mcs, name, bases, dict)
+
+  def __init__(cls, name, bases, dict):
+    super(Tagging, cls).__init__(name, bases, dict)
+    cls.initialized = True
+
+
+class Tagged(object):
+  __metaclass__ = Tagging
+
+
+class SubTagged(Tagged):
+  pass
+
+
+assert type(Tagged) is Tagging
+assert Tagged.tag == 'tagged' and Tagged.initialized
+assert type(SubTagged) is Tagging
+assert SubTagged.tag == 'subtagged' and SubTagged.initialized
+assert SubTagged().tag == 'subtagged'
+
+
+class FromFunction(object):
+  __metaclass__ = lambda name, bases, dict: (name, bases, sorted(dict))
+
+
+assert FromFunction == ('FromFunction', (object,), ['__metaclass__', '__module__'])
+
+
+class OtherMeta(type):
+  pass
+
+
+class Other(object):
+  __metaclass__ = OtherMeta
+
+
+try:
+  class Conflict(Tagged, Other):
+    pass
+except TypeError as e:
+  assert 'metaclass conflict' in str(e)
+else:
+  raise AssertionError
+
+
+class Plain(object):
+
+  def __init__(self):
+    super(Plain, self).__init__()
+
+
+assert isinstance(Plain(), Plain)
+
+try:
+  object(1)
+except TypeError as e:
+  assert str(e) == 'object() takes no parameters'
+else:
+  raise AssertionError
+
+
+class Mixin(object):
+  pass
+
+
+class WithInit(object):
+
+  def __init__(self, value):
+    self.value = value
+
+
+class Mixed(Mixin, WithInit):
+  pass
+
+
+# __init__ inherited by Mixin from object doesn't shadow WithInit's.
+assert Mixed(42).value == 42
+
+# __getattr__ is only called when normal attribute lookup fails.
+
+
+class Fallback(object):
+
+  existing = 'existing'
+
+  def __getattr__(self, name):
+    if name.startswith('dyn_'):
+      return name[4:]
+    raise AttributeError(name)
+
+
+fallback = Fallback()
+assert fallback.existing == 'existing'
+assert fallback.dyn_foo == 'foo'
+assert getattr(fallback, 'dyn_bar') == 'bar'
+assert getattr(fallback, 'missing', None) is None
+assert hasattr(fallback, 'dyn_baz')
+assert not hasattr(fallback, 'missing')
+fallback.dyn_foo = 'set'
+assert fallback.dyn_foo == 'set'
+
+# __getattribute__ intercepts every attribute access.
+
+
+class Logging(object):
+
+  value = 42
+
+  def __init__(self):
+    self.log = []
+
+  def __getattribute__(self, name):
+    object.__getattribute__(self, 'log').append(name)
+    return object.__getattribute__(self, name)
+
+  def __getattr__(self, name):
+    return 'default'
+
+
+logging = Logging()
+assert logging.value == 42
+assert logging.missing == 'default'
+assert object.__getattribute__(logging, 'log') == ['value', 'missing']
+
+# __setattr__ and __delattr__ intercept assignment and deletion.
+
+
+class Validated(object):
+
+  def __setattr__(self, name, value):
+    if name.startswith('_'):
+      raise AttributeError('cannot set private attribute ' + name)
+    object.__setattr__(self, name, value)
+
+
+validated = Validated()
+validated.foo = 'bar'
+assert validated.foo == 'bar'
+setattr(validated, 'baz', 123)
+assert validated.baz == 123
+try:
+  validated._private = 1
+except AttributeError as e:
+  assert str(e) == 'cannot set private attribute _private'
+else:
+  raise AssertionError
+assert not hasattr(validated, '_private')
+
+
+class Undeletable(object):
+
+  def __init__(self):
+    self.deleted = []
+    self.foo = 'foo'
+    self.bar = 'bar'
+
+  def __delattr__(self, name):
+    if name == 'foo':
+      raise AttributeError("can't delete foo")
+    self.deleted.append(name)
+    object.__delattr__(self, name)
+
+
+undeletable = Undeletable()
+del undeletable.bar
+assert undeletable.deleted == ['bar']
+assert not hasattr(undeletable, 'bar')
+try:
+  del undeletable.foo
+except AttributeError as e:
+  assert str(e) == "can't delete foo"
+else:
+  raise AssertionError
+assert undeletable.foo == 'foo'
+
+try:
+  object().foo = 1
+except AttributeError as e:
+  assert str(e) == "'object' object has no attribute 'foo'"
+else:
+  raise AssertionError
+
+# Data descriptors take precedence over the instance dict, which takes
+# precedence over non-data descriptors.
+
+
+class DataDescriptor(object):
+
+  def __get__(self, instance, owner):
+    if instance is None:
+      return self
+    return 'data'
+
+  def __set__(self, instance, value):
+    instance.__dict__['stored'] = value
+
+
+class NonDataDescriptor(object):
+
+  def __get__(self, instance, owner):
+    if instance is None:
+      return self
+    return 'non-data'
+
+
+class Described(object):
+  data = DataDescriptor()
+  non_data = NonDataDescriptor()
+
+
+described = Described()
+assert isinstance(Described.data, DataDescriptor)
+assert described.data == 'data'
+assert described.non_data == 'non-data'
+described.__dict__['data'] = 'instance'
+described.__dict__['non_data'] = 'instance'
+# The data descriptor shadows the instance attribute...
+assert described.data == 'data'
+# ...but the non-data descriptor is shadowed by it.
+assert described.non_data == 'instance'
+described.data = 'value'
+assert described.stored == 'value'
+assert described.__dict__['data'] == 'instance'
+described.non_data = 'assigned'
+assert described.non_data == 'assigned'
+del described.non_data
+assert described.non_data == 'non-data'
+try:
+  del described.data
+except AttributeError as e:
+  assert str(e) == '__delete__'
+else:
+  raise AssertionError
+
+
+# classmethod and staticmethod expose the wrapped function as __func__.
+def make_name(cls):
+  return cls.__name__
+
+
+class Wrapped(object):
+  name = classmethod(make_name)
+
+  @staticmethod
+  def answer():
+    return 42
+
+
+assert Wrapped.__dict__['name'].__func__ is make_name
+assert Wrapped.__dict__['answer'].__func__ is Wrapped.answer
+assert Wrapped.name() == 'Wrapped'
+assert Wrapped.__dict__['answer'].__func__() == 42
+
+
+# super finds any attribute, not just methods, after the given class in the
+# MRO, invoking descriptors with the bound instance.
+class SuperBase(object):
+  attr = 'base'
+  only_base = 'only base'
+
+  @property
+  def prop(self):
+    return ['base', self.name]
+
+
+class SuperMiddle(SuperBase):
+  attr = 'middle'
+
+  @property
+  def prop(self):
+    return ['middle'] + super(SuperMiddle, self).prop
+
+
+class SuperLeaf(SuperMiddle):
+  attr = 'leaf'
+  name = 'leaf'
+
+  @property
+  def prop(self):
+    return ['leaf'] + super(SuperLeaf, self).prop
+
+
+leaf = SuperLeaf()
+assert super(SuperLeaf, leaf).attr == 'middle'
+assert super(SuperMiddle, leaf).attr == 'base'
+assert super(SuperLeaf, leaf).only_base == 'only base'
+assert super(SuperLeaf, SuperLeaf).attr == 'middle'
+assert leaf.prop == ['leaf', 'middle', 'base', 'leaf']
+assert super(SuperLeaf, leaf).prop == ['middle', 'base', 'leaf']
+# Bound to a type, super returns descriptors unbound.
+assert super(SuperLeaf, SuperLeaf).prop is SuperMiddle.__dict__['prop']
+
+try:
+  super(SuperBase, leaf).attr
+except AttributeError:
+  pass
+else:
+  raise AssertionError
@@ -0,0 +1,168 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import StringIO
+import collections
+import sys
+
+# Counter
+
+c = collections.Counter('abracadabra')
+assert isinstance(c, dict)
+assert sorted(c.items()) == [('a', 5), ('b', 2), ('c', 1), ('d', 1), ('r', 2)]
+assert c['z'] == 0
+assert 'z' not in c
+assert sorted(c.elements()) == sorted('abracadabra')
+
+# most_common orders by count; the order among ties is unspecified.
+assert c.most_common(1) == [('a', 5)]
+common = c.most_common(3)
+assert common[0] == ('a', 5)
+assert set(common[1:]) == set([('b', 2), ('r', 2)])
+assert len(c.most_common()) == 5
+assert c.most_common()[-2:] in ([('c', 1), ('d', 1)], [('d', 1), ('c', 1)])
+
+# Arithmetic drops non-positive counts.
+a = collections.Counter(a=3, b=1, c=0)
+b = collections.Counter(a=1, b=2, d=-1)
+assert a + b == {'a': 4, 'b': 3}
+assert a - b == {'a': 2, 'd': 1}
+assert a | b == {'a': 3, 'b': 2}
+assert a & b == {'a': 1, 'b': 1}
+
+# update and subtract accept iterables and mappings.
+a.update('aab')
+a.update({'x': 2})
+assert a == {'a': 5, 'b': 2, 'c': 0, 'x': 2}
+a.subtract('aaaaa')
+a.subtract({'x': 3})
+assert a == {'a': 0, 'b': 2, 'c': 0, 'x': -1}
+del a['x']
+del a['missing']
+assert 'x' not in a
+
+assert repr(collections.Counter('aab')) == "Counter({'a': 2, 'b': 1})"
+
+# namedtuple
+
+Point = collections.namedtuple('Point', 'x y')
+p = Point(1, y=2)
+assert isinstance(p, tuple)
+assert p == (1, 2)
+assert p.x == 1 and p.y == 2
+assert p[0] == 1
+x, y = p
+assert (x, y) == (1, 2)
+assert Point._fields == ('x', 'y')
+assert Point.__doc__ == 'Point(x, y)'
+assert repr(p) == 'Point(x=1, y=2)'
+assert Point._make([3, 4]) == Point(3, 4)
+
+# _replace returns a new instance, leaving the original untouched.
+q = p._replace(x=5)
+assert q == Point(5, 2)
+assert type(q) is Point
+assert p == Point(1, 2)
+
+# _asdict preserves field order.
+d = Point(3, 4)._asdict()
+assert isinstance(d, collections.OrderedDict)
+assert d.items() == [('x', 3), ('y', 4)]
+assert Point(**d) == Point(3, 4)
+
+assert collections.namedtuple('P', ['a', 'b', 'c'])(1, 2, 3).c == 3
+assert collections.namedtuple('P', 'a, b')._fields == ('a', 'b')
+assert (collections.namedtuple('P', 'def x x _y', rename=True)._fields ==
+        ('_0', 'x', '_2', '_3'))
+
+# verbose prints the class definition that namedtuple is equivalent to.
+old_stdout = sys.stdout
+sys.stdout = StringIO.StringIO()
+try:
+  collections.namedtuple('P', 'x y', verbose=True)
+  source = sys.stdout.getvalue()
+finally:
+  sys.stdout = old_stdout
+assert 'class P(tuple):' in source
+assert "_fields = ('x', 'y')" in source
+assert "return 'P(x=%r, y=%r)' % self" in source
+assert "if len(result) != 2:" in source
+assert "y = _property(_itemgetter(1), doc='Alias for field number 1')" in source
+
+for bad in ['def x', 'x x', '_x y', '1x', 'x-y']:
+  try:
+    collections.namedtuple('P', bad)
+  except ValueError:
+    pass
+  else:
+    raise AssertionError('namedtuple accepted %r' % bad)
+
+for args, kwargs in [((1,), {}), ((1, 2, 3), {}), ((1,), {'x': 2}),
+                     ((1, 2), {'z': 3})]:
+  try:
+    Point(*args, **kwargs)
+  except TypeError:
+    pass
+  else:
+    raise AssertionError
+
+# deque
+
+d = collections.deque('bcd')
+d.append('e')
+d.appendleft('a')
+assert list(d) == ['a', 'b', 'c', 'd', 'e']
+assert len(d) == 5
+assert d[0] == 'a' and d[-1] == 'e'
+assert d.popleft() == 'a'
+assert d.pop() == 'e'
+assert list(d) == ['b', 'c', 'd']
+d[1] = 'x'
+assert list(d) == ['b', 'x', 'd']
+d.extend('ef')
+d.extendleft('za')
+assert list(d) == ['a', 'z', 'b', 'x', 'd', 'e', 'f']
+assert list(reversed(d)) == ['f', 'e', 'd', 'x', 'b', 'z', 'a']
+d.remove('x')
+assert list(d) == ['a', 'z', 'b', 'd', 'e', 'f']
+assert repr(collections.deque([1, 2])) == 'deque([1, 2])'
+
+# rotate moves items right for positive counts and left for negative ones.
+d = collections.deque(range(5))
+d.rotate(2)
+assert list(d) == [3, 4, 0, 1, 2]
+d.rotate(-3)
+assert list(d) == [1, 2, 3, 4, 0]
+d.rotate(7)
+assert list(d) == [4, 0, 1, 2, 3]
+
+# Appending to a full deque evicts from the opposite end.
+d = collections.deque([1, 2, 3], maxlen=3)
+assert d.maxlen == 3
+d.append(4)
+assert list(d) == [2, 3, 4]
+d.appendleft(1)
+assert list(d) == [1, 2, 3]
+d.extend([5, 6])
+assert list(d) == [3, 5, 6]
+assert repr(d) == 'deque([3, 5, 6], maxlen=3)'
+assert list(collections.deque('abcde', 2)) == ['d', 'e']
+
+for pop in (collections.deque().pop, collections.deque().popleft):
+  try:
+    pop()
+  except IndexError:
+    pass
+  else:
+    raise AssertionError
@@ -105,3 +105,26 @@ assert a.cmp_called
 a, b = Cmp(5), Cmp(4)
 assert a >= b
 assert a.cmp_called
+
+# Test set comparisons, which are subset and superset tests.
+
+a, b, c = set([1, 2]), set([1, 2, 3]), frozenset([1, 2])
+assert a <= b and a < b and not b <= a and not b < a
+assert b >= a and b > a and not a >= b and not a > b
+assert a <= c and a >= c and a == c and not a < c and not a > c
+assert not set([1]) < set([2]) and not set([1]) > set([2])
+assert not set([1]) <= set([2]) and not set([1]) >= set([2])
+assert a.__lt__([1, 2, 3]) is NotImplemented
+assert c.__ge__(None) is NotImplemented
+assert a != [1, 2] and not a == (1, 2)
+
+try:
+  cmp(a, b)
+except TypeError as e:
+  assert str(e) == 'cannot compare sets using cmp()'
+else:
+  raise AssertionError
+
+assert cmp(a, [1, 2]) == 1
+assert cmp([1, 2], a) == -1
+assert cmp(a, c) == 0
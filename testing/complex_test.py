@@ -14,3 +14,57 @@
 
 assert repr(1j) == "1j"
 assert repr(complex()) == "0j"
+
+assert complex(1, 2) == 1 + 2j
+assert complex(1.5) == 1.5 + 0j
+assert complex(3L, -1) == 3 - 1j
+assert complex(1 + 2j, 3 + 4j) == -3 + 5j
+assert complex(imag=2) == 2j
+assert complex('1+2j') == 1 + 2j
+assert complex(' (-j) ') == 0 - 1j
+
+try:
+  complex('1+2i')
+  raise AssertionError
+except ValueError:
+  pass
+
+try:
+  complex('1', 2)
+  raise AssertionError
+except TypeError:
+  pass
+
+
+class Complex(object):
+  def __complex__(self):
+    return 1 + 2j
+
+
+class IndexAndComplex(object):
+  def __index__(self):
+    return 3
+
+  def __complex__(self):
+    return 4j
+
+
+assert complex(Complex()) == 1 + 2j
+assert complex(Complex(), 1) == 1 + 3j
+assert complex(IndexAndComplex()) == 4j
+
+class FloatComplex(object):
+  def __complex__(self):
+    return 1.5
+
+assert complex(FloatComplex()) == 1.5 + 0j
+
+class BadComplex(object):
+  def __complex__(self):
+    return 'foo'
+
+try:
+  complex(BadComplex())
+  raise AssertionError
+except TypeError:
+  pass
@@ -0,0 +1,87 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import decimal
+from decimal import Decimal
+
+# Arithmetic is exact.
+assert Decimal('0.1') + Decimal('0.2') == Decimal('0.3')
+assert Decimal('1.30') + Decimal('1.20') == Decimal('2.50')
+assert str(Decimal('1.30') + Decimal('1.20')) == '2.50'
+assert Decimal('1.3') * Decimal('1.2') == Decimal('1.56')
+assert Decimal(3) - Decimal('0.5') == Decimal('2.5')
+assert Decimal(1) + 2 == 3
+assert Decimal('-7') // Decimal('2') == -3
+assert Decimal('-7') % Decimal('2') == -1
+assert Decimal(2) ** 10 == 1024
+assert sum([Decimal('0.1')] * 10) == 1
+
+# Comparison and hashing agree with ints.
+assert Decimal('1.1') < Decimal('1.2')
+assert Decimal('1.0') == Decimal('1') == 1
+assert hash(Decimal('1.0')) == hash(1)
+assert sorted([Decimal(3), Decimal('1.5'), Decimal(2)]) == [1.5, 2, 3]
+
+# String round-trips preserve the exponent.
+for s in ('0.25', '2.50', '-0', '1E+3', '1.23E-7', '0.000001', 'Infinity',
+          '-Infinity', 'NaN', '123456789012345678901234567890'):
+  assert str(Decimal(s)) == s
+assert repr(Decimal('1.50')) == "Decimal('1.50')"
+assert str(Decimal(' 12 ')) == '12'
+assert str(Decimal((0, (3, 1, 4, 0), -3))) == '3.140'
+assert Decimal('3.140').as_tuple() == (0, (3, 1, 4, 0), -3)
+assert str(Decimal(0.5)) == '0.5'
+assert int(Decimal('12.7')) == 12
+assert float(Decimal('0.25')) == 0.25
+
+# The context precision rounds results.
+ctx = decimal.getcontext()
+assert ctx.prec == 28
+assert str(Decimal(1) / Decimal(7)) == '0.1428571428571428571428571429'
+ctx.prec = 6
+assert str(Decimal(1) / Decimal(7)) == '0.142857'
+assert str(Decimal('123456789') + 0) == '1.23457E+8'
+ctx.prec = 28
+with decimal.localcontext() as c:
+  c.prec = 3
+  assert str(Decimal(2) / Decimal(3)) == '0.667'
+assert str(Decimal(2) / Decimal(3)) == '0.6666666666666666666666666667'
+
+# quantize honours the rounding modes.
+cent = Decimal('0.01')
+for mode, up, down in [(decimal.ROUND_UP, '2.68', '-2.67'),
+                       (decimal.ROUND_DOWN, '2.67', '-2.66'),
+                       (decimal.ROUND_CEILING, '2.68', '-2.66'),
+                       (decimal.ROUND_FLOOR, '2.67', '-2.67'),
+                       (decimal.ROUND_HALF_UP, '2.68', '-2.67'),
+                       (decimal.ROUND_HALF_DOWN, '2.67', '-2.66'),
+                       (decimal.ROUND_HALF_EVEN, '2.68', '-2.66'),
+                       (decimal.ROUND_05UP, '2.67', '-2.66')]:
+  assert str(Decimal('2.675').quantize(cent, rounding=mode)) == up
+  assert str(Decimal('-2.665').quantize(cent, rounding=mode)) == down
+assert str(Decimal('7.325').quantize(cent)) == '7.32'
+
+try:
+  Decimal(1) / Decimal(0)
+except decimal.DivisionByZero:
+  pass
+else:
+  raise AssertionError
+
+try:
+  Decimal('abc')
+except decimal.InvalidOperation:
+  pass
+else:
+  raise AssertionError
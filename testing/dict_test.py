@@ -61,3 +61,42 @@ try:
   assert AssertionError
 except TypeError:
   pass
+
+# Test copy
+d = {'foo': 1, 'bar': [2]}
+c = d.copy()
+assert c == d and c is not d
+c['baz'] = 3
+assert 'baz' not in d
+assert c['bar'] is d['bar']
+
+
+class SubDict(dict):
+  pass
+
+assert type(SubDict(a=1).copy()) is dict
+
+# Test fromkeys
+assert dict.fromkeys('ab') == {'a': None, 'b': None}
+assert dict.fromkeys([1, 2], 0) == {1: 0, 2: 0}
+assert type(SubDict.fromkeys('a')) is SubDict
+
+# Test repr of self-referential dicts
+d = {}
+d['d'] = d
+assert repr(d) == "{'d': {...}}"
+assert str(d) == "{'d': {...}}"
+d = {1: [], 2: ()}
+d[1].append(d)
+assert repr(d) == '{1: [{...}], 2: ()}'
+
+# Test frozenset keys built in different insertion orders
+a = frozenset(['foo', 'bar', 'baz', 1, 2.5])
+b = frozenset([2.5, 'baz', 1, 'bar', 'foo'])
+assert hash(a) == hash(b)
+assert hash(frozenset(range(50))) == hash(frozenset(reversed(range(50))))
+d = {a: 'qux'}
+assert d[b] == 'qux'
+d[b] = 'quux'
+assert len(d) == 1 and d[a] == 'quux'
+assert {frozenset([a, 'x']): 1}[frozenset(['x', b])] == 1
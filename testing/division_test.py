@@ -0,0 +1,102 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""Tests for / under "from __future__ import division"."""
+
+from __future__ import division
+
+import weetest
+
+
+def TestIntTrueDivision():
+  assert 5 / 2 == 2.5
+  assert type(5 / 2) is float
+  assert type(6 / 3) is float
+  assert 6 / 3 == 2.0
+  assert -7 / 2 == -3.5
+  assert True / 2 == 0.5
+  assert 5 // 2 == 2
+
+
+def TestLongTrueDivision():
+  assert 5L / 2 == 2.5
+  assert 5 / 2L == 2.5
+  assert (1 << 1100) / (1 << 1099) == 2.0
+  assert 1 / (1 << 2000) == 0.0
+  try:
+    (1 << 1100) / 3
+  except OverflowError as e:
+    assert str(e) == 'integer division result too large for a float'
+  else:
+    raise AssertionError
+
+
+def TestFloatTrueDivision():
+  assert 5.0 / 2 == 2.5
+  assert 5 / 2.0 == 2.5
+
+
+def TestComplexTrueDivision():
+  assert (1+2j) / 2 == (0.5+1j)
+  assert 5 / (1+2j) == (1-2j)
+  assert (3+4j) / (1+2j) == (2.2-0.4j)
+  try:
+    (1+2j) / 0
+  except ZeroDivisionError as e:
+    assert str(e) == 'complex division by zero'
+  else:
+    raise AssertionError
+
+
+def TestInplaceTrueDivision():
+  x = 5
+  x /= 2
+  assert x == 2.5
+
+
+def TestTrueDivisionByZero():
+  for n in (1, 1L):
+    try:
+      n / 0
+    except ZeroDivisionError as e:
+      assert str(e) == 'division by zero'
+    else:
+      raise AssertionError
+
+
+def TestTrueDivOverloads():
+
+  class Div(object):
+
+    def __div__(self, other):
+      return 'div'
+
+    def __truediv__(self, other):
+      return 'truediv'
+
+    def __rtruediv__(self, other):
+      return 'rtruediv'
+
+    def __itruediv__(self, other):
+      return 'itruediv'
+
+  d = Div()
+  assert d / 1 == 'truediv'
+  assert 1 / d == 'rtruediv'
+  d /= 1
+  assert d == 'itruediv'
+
+
+if __name__ == '__main__':
+  weetest.RunTests()
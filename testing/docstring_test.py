@@ -0,0 +1,65 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""Docstring tests."""
+
+import sys
+
+
+def Documented():
+  """Function docstring."""
+
+
+def Undocumented():
+  pass
+
+
+def NotADocstring():
+  pass
+  'foo'  # pylint: disable=pointless-string-statement
+
+
+class DocumentedClass(object):
+  """Class docstring."""
+
+  def Method(self):
+    """Method docstring."""
+
+
+class UndocumentedClass(DocumentedClass):
+  pass
+
+
+assert __doc__ == 'Docstring tests.'
+assert sys.modules[__name__].__doc__ == 'Docstring tests.'
+
+assert Documented.__doc__ == 'Function docstring.'
+assert Documented.func_doc == 'Function docstring.'
+assert Undocumented.__doc__ is None
+assert NotADocstring.__doc__ is None
+assert (lambda: 'foo').__doc__ is None
+assert '__doc__' not in Documented.__dict__
+
+assert DocumentedClass.__doc__ == 'Class docstring.'
+assert DocumentedClass().__doc__ == 'Class docstring.'
+assert DocumentedClass.Method.__doc__ == 'Method docstring.'
+assert DocumentedClass().Method.__doc__ == 'Method docstring.'
+assert UndocumentedClass.__doc__ is None
+assert type('Foo', (object,), {}).__doc__ is None
+
+Undocumented.__doc__ = 'foo'
+assert Undocumented.__doc__ == 'foo'
+assert Undocumented.func_doc == 'foo'
+del Undocumented.__doc__
+assert Undocumented.__doc__ is None
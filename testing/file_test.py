@@ -0,0 +1,45 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+import os
+import tempfile
+
+
+fd, path = tempfile.mkstemp()
+f = os.fdopen(fd, 'w')
+f.write('foo\r\nbar\rbaz\nqux\r\rquux\r')
+f.close()
+
+try:
+  # Without universal newlines the data is returned untouched.
+  with open(path) as f:
+    assert f.read() == 'foo\r\nbar\rbaz\nqux\r\rquux\r'
+
+  # Universal newline mode translates \r\n and \r to \n.
+  with open(path, 'rU') as f:
+    lines = list(f)
+  assert lines == ['foo\n', 'bar\n', 'baz\n', 'qux\n', '\n', 'quux\n'], lines
+  assert all(line.endswith('\n') for line in lines)
+
+  with open(path, 'rU') as f:
+    assert f.readline() == 'foo\n'
+    assert f.read(4) == 'bar\n'
+    assert f.readlines() == ['baz\n', 'qux\n', '\n', 'quux\n']
+
+  with open(path, 'U') as f:
+    assert f.read() == 'foo\nbar\nbaz\nqux\n\nquux\n'
+    assert f.read() == ''
+finally:
+  os.remove(path)
@@ -20,3 +20,44 @@ assert 1E6 == 1e6
 assert -1E6 == -1e6
 assert 1E+6 == 1e6
 assert 1E-6 == 0.000001
+
+# is_integer
+
+assert (2.0).is_integer()
+assert (-1e300).is_integer()
+assert not (2.5).is_integer()
+assert not float('inf').is_integer()
+assert not float('nan').is_integer()
+
+# as_integer_ratio
+
+assert (0.25).as_integer_ratio() == (1, 4)
+assert (-1.5).as_integer_ratio() == (-3, 2)
+assert (0.0).as_integer_ratio() == (0, 1)
+assert (0.1).as_integer_ratio() == (3602879701896397, 36028797018963968)
+
+# hex and fromhex
+
+assert (3.5).hex() == '0x1.c000000000000p+1'
+assert (-0.0).hex() == '-0x0.0p+0'
+assert (5e-324).hex() == '0x0.0000000000001p-1022'
+assert float('-inf').hex() == '-inf'
+assert float('nan').hex() == 'nan'
+assert float.fromhex('0x1.8p1') == 3.0
+assert float.fromhex('-a.b') == -10.6875
+for x in (0.1, -123.456, 1e-310, 2.2250738585072014e-308, 1.7976931348623157e308,
+          float('inf')):
+  assert float.fromhex(x.hex()) == x
+
+
+class SubFloat(float):
+  pass
+
+assert type(SubFloat.fromhex('1')) is SubFloat
+
+try:
+  float.fromhex('1p')
+except ValueError:
+  pass
+else:
+  raise AssertionError
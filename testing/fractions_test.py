@@ -0,0 +1,103 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import fractions
+from fractions import Fraction
+
+# Construction reduces to lowest terms with a positive denominator.
+f = Fraction(6, 4)
+assert (f.numerator, f.denominator) == (3, 2)
+assert (Fraction(3, -6).numerator, Fraction(3, -6).denominator) == (-1, 2)
+assert Fraction(0, 5).denominator == 1
+assert Fraction(3) == 3 and Fraction() == 0
+assert Fraction(2 ** 70, 2 ** 68) == 4
+assert Fraction(Fraction(1, 2), Fraction(1, 3)) == Fraction(3, 2)
+
+# Strings and floats.
+assert Fraction('3/4') == Fraction(3, 4)
+assert Fraction(' -35/4 ') == Fraction(-35, 4)
+assert Fraction('1.5') == Fraction(3, 2)
+assert Fraction('-2.5e-1') == Fraction(-1, 4)
+assert Fraction(0.5) == Fraction(1, 2)
+assert Fraction(1.1) == Fraction(2476979795053773, 2251799813685248)
+assert Fraction.from_float(0.25) == Fraction(1, 4)
+
+try:
+  Fraction('abc')
+  raise AssertionError
+except ValueError:
+  pass
+
+try:
+  Fraction(1, 0)
+  raise AssertionError
+except ZeroDivisionError:
+  pass
+
+# str and repr.
+assert str(Fraction(5, 10)) == '1/2'
+assert str(Fraction(4, 2)) == '2'
+assert repr(Fraction(-3, 9)) == 'Fraction(-1, 3)'
+
+# Arithmetic, including mixed arithmetic with ints and floats.
+assert Fraction(1, 2) + Fraction(1, 3) == Fraction(5, 6)
+assert Fraction(1, 2) - 1 == Fraction(-1, 2)
+assert 1 - Fraction(1, 2) == Fraction(1, 2)
+assert Fraction(1, 2) * 3 == Fraction(3, 2)
+assert Fraction(1, 2) / 3 == Fraction(1, 6)
+assert 3 / Fraction(1, 2) == 6
+assert isinstance(3 / Fraction(1, 2), Fraction)
+assert Fraction(7, 2) // 2 == 1
+assert Fraction(7, 2) % 2 == Fraction(3, 2)
+assert Fraction(1, 2) ** 2 == Fraction(1, 4)
+assert Fraction(1, 2) ** -2 == 4
+assert -Fraction(1, 2) == Fraction(-1, 2)
+assert abs(Fraction(-1, 2)) == Fraction(1, 2)
+assert Fraction(1, 2) + 0.25 == 0.75
+assert isinstance(Fraction(1, 2) + 0.25, float)
+assert float(Fraction(1, 4)) == 0.25
+
+try:
+  Fraction(1, 2) / 0
+  raise AssertionError
+except ZeroDivisionError:
+  pass
+
+# Comparison and hashing.
+assert Fraction(1, 2) < Fraction(2, 3)
+assert Fraction(1, 2) > Fraction(1, 3)
+assert Fraction(2, 3) >= 0.5
+assert Fraction(1, 2) == 0.5
+assert Fraction(2, 1) == 2
+assert Fraction(1, 2) != 1
+assert not Fraction(1, 2) > 1
+assert sorted([Fraction(2, 3), 0, Fraction(1, 3)]) == [0, Fraction(1, 3),
+                                                       Fraction(2, 3)]
+assert hash(Fraction(2, 1)) == hash(2)
+assert hash(Fraction(1, 2)) == hash(0.5)
+
+# limit_denominator.
+pi = Fraction('3.1415926535897932')
+assert pi.limit_denominator(1000) == Fraction(355, 113)
+assert Fraction(1.1).limit_denominator() == Fraction(11, 10)
+assert Fraction(3, 4).limit_denominator(10) == Fraction(3, 4)
+assert Fraction(1, 3).limit_denominator(2) == Fraction(1, 2)
+
+try:
+  Fraction(1, 3).limit_denominator(0)
+  raise AssertionError
+except ValueError:
+  pass
+
+assert fractions.gcd(12, 18) == 6
@@ -86,3 +86,122 @@ assert (foo(**{'a': 'apple', 'b': 'bear'}) ==
         {'a': 'apple', 'kwargs': {'b': 'bear'}})
 assert (foo('bar', b='baz', c='qux') ==
         {'a': 'bar', 'kwargs': {'b': 'baz', 'c': 'qux'}})
+
+
+# Arguments are forwarded faithfully through *args and **kwargs.
+def foo(a, b=2, *args, **kwargs):
+  return a, b, args, kwargs
+
+
+def wrapper(*args, **kwargs):
+  return foo(*args, **kwargs)
+
+
+assert wrapper(1) == (1, 2, (), {})
+assert wrapper(1, 3, 4, c=5) == (1, 3, (4,), {'c': 5})
+assert wrapper(b=3, a=1) == (1, 3, (), {})
+assert foo(1, *[2, 3], c=4, **{'d': 5}) == (1, 2, (3,), {'c': 4, 'd': 5})
+assert foo(*(i for i in range(3))) == (0, 1, (2,), {})
+try:
+  foo(1, b=2, **{'b': 3})
+  raise AssertionError
+except TypeError as e:
+  assert str(e) == "foo() got multiple values for keyword argument 'b'"
+try:
+  foo(1, **{'a': 2})
+  raise AssertionError
+except TypeError as e:
+  assert str(e) == "foo() got multiple values for keyword argument 'a'"
+try:
+  foo(*1)
+  raise AssertionError
+except TypeError as e:
+  assert str(e) == 'foo() argument after * must be an iterable, not int'
+try:
+  foo(1, **[])
+  raise AssertionError
+except TypeError as e:
+  assert str(e) == 'foo() argument after ** must be a mapping, not list'
+
+
+class Mapping(object):
+
+  def keys(self):
+    return ['c']
+
+  def __getitem__(self, key):
+    return key * 2
+
+
+assert foo(1, **Mapping()) == (1, 2, (), {'c': 'cc'})
+
+
+def foo():
+  pass
+
+
+# Functions support arbitrary attributes stored in __dict__.
+assert foo.__dict__ == {}
+foo.bar = 'baz'
+assert foo.bar == 'baz'
+assert foo.__dict__ == {'bar': 'baz'}
+assert foo.func_dict is foo.__dict__
+foo.__dict__ = {'qux': 42}
+assert foo.qux == 42
+assert not hasattr(foo, 'bar')
+try:
+  foo.__dict__ = None
+  raise AssertionError
+except TypeError:
+  pass
+
+assert foo.__name__ == 'foo'
+assert foo.func_name == 'foo'
+assert foo.__module__ == __name__
+foo.func_name = 'bar'
+assert foo.__name__ == 'bar'
+
+
+# Default values are evaluated once, when the function is defined.
+def foo(a=[]):
+  a.append(len(a))
+  return a
+
+
+assert foo() == [0]
+assert foo() == [0, 1]
+assert foo([]) == [0]
+assert foo() is foo.func_defaults[0]
+assert foo.__defaults__ == ([0, 1, 2],)
+
+default_calls = []
+
+
+def make_default(value):
+  default_calls.append(value)
+  return value
+
+
+bar = 'def time'
+
+
+def foo(a=make_default(bar), b=make_default('b')):
+  return a, b
+
+
+bar = 'call time'
+assert default_calls == ['def time', 'b']
+assert foo() == ('def time', 'b')
+assert foo() == ('def time', 'b')
+assert default_calls == ['def time', 'b']
+
+
+def foo():
+  x = 'outer'
+  def bar(a=x):
+    return a
+  x = 'changed'
+  return bar()
+
+
+assert foo() == 'outer'
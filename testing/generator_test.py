@@ -74,3 +74,83 @@ def gen6():
 g = gen6()
 assert list(g) == [1]
 assert list(g) == []
+
+
+def gen7():
+  try:
+    yield 1
+  except ValueError as e:
+    yield str(e)
+  yield 3
+g = gen7()
+assert g.next() == 1
+assert g.throw(ValueError, 'foo') == 'foo'
+assert g.next() == 3
+try:
+  g.throw(ValueError('bar'))
+except ValueError as e:
+  assert str(e) == 'bar'
+else:
+  raise AssertionError
+assert list(g) == []
+
+
+def gen8():
+  try:
+    yield 1
+  finally:
+    log.append('finally')
+log = []
+g = gen8()
+assert g.next() == 1
+assert g.close() is None
+assert log == ['finally']
+assert g.close() is None
+assert list(g) == []
+assert gen8().close() is None
+assert log == ['finally']
+
+
+def gen9():
+  try:
+    yield 1
+  except GeneratorExit:
+    yield 2
+g = gen9()
+g.next()
+try:
+  g.close()
+except RuntimeError as e:
+  assert 'generator ignored GeneratorExit' in str(e), str(e)
+else:
+  raise AssertionError
+
+
+g = gen1()
+try:
+  g.throw(KeyError)
+except KeyError:
+  pass
+else:
+  raise AssertionError
+assert list(g) == []
+try:
+  g.throw(123)
+except TypeError as e:
+  assert 'exceptions must be classes, or instances, not int' in str(e), str(e)
+else:
+  raise AssertionError
+
+
+g = gen8()
+g.next()
+try:
+  raise KeyError('foo')
+except KeyError:
+  g.close()
+  try:
+    raise
+  except KeyError:
+    pass
+  else:
+    raise AssertionError
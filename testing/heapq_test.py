@@ -0,0 +1,66 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import heapq
+
+
+def is_heap(h):
+  return all(h[(i - 1) // 2] <= h[i] for i in range(1, len(h)))
+
+
+# Mixed pushes and pops keep the min-heap invariant.
+h = []
+for x in [5, 1, 8, 3, 9, 2]:
+  heapq.heappush(h, x)
+  assert is_heap(h)
+assert heapq.heappop(h) == 1
+assert heapq.heappop(h) == 2
+assert is_heap(h)
+heapq.heappush(h, 0)
+heapq.heappush(h, 7)
+assert [heapq.heappop(h) for _ in range(len(h))] == [0, 3, 5, 7, 8, 9]
+
+try:
+  heapq.heappop([])
+except IndexError:
+  pass
+else:
+  raise AssertionError
+
+# heapify reorders an unsorted list in place.
+l = [9, 4, 7, 1, 0, 6, 2]
+heapq.heapify(l)
+assert is_heap(l)
+assert l[0] == 0
+assert sorted(l) == [0, 1, 2, 4, 6, 7, 9]
+
+# heappushpop pushes first, heapreplace pops first.
+l = [1, 4, 7]
+assert heapq.heappushpop(l, 0) == 0
+assert l == [1, 4, 7]
+assert heapq.heapreplace(l, 0) == 1
+assert is_heap(l)
+assert l[0] == 0
+
+# nlargest and nsmallest, with and without a key.
+words = ['ccc', 'a', 'dddd', 'bb', 'eeeee']
+assert heapq.nlargest(2, [3, 1, 5, 2, 4]) == [5, 4]
+assert heapq.nsmallest(2, [3, 1, 5, 2, 4]) == [1, 2]
+assert heapq.nlargest(2, words, key=len) == ['eeeee', 'dddd']
+assert heapq.nsmallest(2, words, key=len) == ['a', 'bb']
+assert heapq.nlargest(1, words, key=len) == ['eeeee']
+assert heapq.nlargest(10, [2, 1]) == [2, 1]
+assert heapq.nsmallest(0, [2, 1]) == []
+
+assert list(heapq.merge([1, 3, 5], [2, 4], [0])) == [0, 1, 2, 3, 4, 5]
@@ -12,6 +12,31 @@
 # See the License for the specific language governing permissions and
 # limitations under the License.
 
+import os.path
 import sys
 
 print sys.maxint
+
+# __import__ returns the top level package unless a fromlist is given.
+assert __import__('os.path') is os
+assert __import__('os.path', fromlist=['join']) is os.path
+assert __import__('os', globals(), locals(), ['path']) is os
+
+# Relative imports are resolved against the package named by globals.
+os_globals = {'__name__': 'os.fake', '__package__': 'os'}
+assert __import__('path', os_globals, None, ['join'], 1) is os.path
+assert __import__('', os_globals, None, ['path'], 1) is os
+
+try:
+  __import__('path', {'__name__': 'toplevel'}, None, [], 1)
+except ValueError:
+  pass
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+try:
+  __import__('no_such_module')
+except ImportError:
+  pass
+else:
+  raise AssertionError('this was supposed to raise an exception')
@@ -0,0 +1,46 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import importlib
+import os.path
+
+# Unlike __import__, the module named is returned rather than its top level
+# package.
+assert importlib.import_module('os') is os
+assert importlib.import_module('os.path') is os.path
+
+# Relative names are resolved against the package argument.
+assert importlib.import_module('.path', 'os') is os.path
+assert importlib.import_module('..path', 'os.fake') is os.path
+
+try:
+  importlib.import_module('.path')
+except TypeError:
+  pass
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+try:
+  importlib.import_module('..path', 'os')
+except ValueError:
+  pass
+else:
+  raise AssertionError('this was supposed to raise an exception')
+
+try:
+  importlib.import_module('no_such_module')
+except ImportError:
+  pass
+else:
+  raise AssertionError('this was supposed to raise an exception')
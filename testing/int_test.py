@@ -0,0 +1,72 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Parsing strings in different bases.
+
+CASES = [
+    ('0', 0, 0),
+    ('42', 0, 42),
+    ('  -42  ', 0, -42),
+    ('0b1010', 0, 10),
+    ('0o17', 0, 15),
+    ('017', 0, 15),
+    ('0x1F', 0, 31),
+    ('-0x1f', 0, -31),
+    ('1010', 2, 10),
+    ('0b1010', 2, 10),
+    ('  10  ', 2, 2),
+    ('17', 8, 15),
+    ('0o17', 8, 15),
+    ('ff', 16, 255),
+    ('0xff', 16, 255),
+    ('0b', 16, 11),
+    ('z', 36, 35),
+    ('ZZ', 36, 1295),
+    ('99999999999999999999', 0, 99999999999999999999),
+]
+
+for s, base, want in CASES:
+  assert int(s, base) == want, (s, base)
+  assert long(s, base) == want, (s, base)
+  assert isinstance(long(s, base), long)
+
+assert long('10L', 0) == 10
+assert long('10l', 16) == 16
+assert long('10L', 36) == 1317
+
+
+def ValueErrorMessage(f, *args):
+  try:
+    f(*args)
+  except ValueError as e:
+    return str(e)
+  raise AssertionError
+
+
+for s, base in [('', 10), ('0b', 0), ('0x', 16), ('12', 2), ('09', 0),
+                ('8', 8), ('1e3', 10), ('1_0', 10), ('0x10', 10),
+                ('g', 16), ('10L', 0), ('1 0', 10), ('--1', 10)]:
+  assert (ValueErrorMessage(int, s, base) ==
+          'invalid literal for int() with base %d: %r' % (base, s)), (s, base)
+
+assert (ValueErrorMessage(int, '  x ', 10) ==
+        "invalid literal for int() with base 10: 'x '")
+assert (ValueErrorMessage(long, '  x ', 10) ==
+        "invalid literal for long() with base 10: '  x '")
+assert (ValueErrorMessage(long, '09', 0) ==
+        "invalid literal for long() with base 8: '09'")
+assert (ValueErrorMessage(int, '1', 37) ==
+        'int() base must be >= 2 and <= 36, or 0')
+assert (ValueErrorMessage(long, '1', 1) ==
+        'long() base must be >= 2 and <= 36, or 0')
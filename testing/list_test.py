@@ -40,6 +40,38 @@ b.sort()
 assert b == []
 c.sort()
 assert c == ["a", "b", "c", "e"]
+c.sort(reverse=True)
+assert c == ["e", "c", "b", "a"]
+c.sort(key=lambda x: x == "b")
+assert c == ["e", "c", "a", "b"]
+c.sort(lambda x, y: cmp(y, x))
+assert c == ["e", "c", "b", "a"]
+assert sorted(c, None, None, True) == ["e", "c", "b", "a"]
+
+try:
+  c.sort(foo=1)
+except TypeError:
+  pass
+else:
+  raise AssertionError
+
+seen = []
+
+
+def MutatingKey(x):
+  seen.append(len(c))
+  c.append(x)
+  return x
+
+
+try:
+  c.sort(key=MutatingKey)
+except ValueError:
+  pass
+else:
+  raise AssertionError
+assert seen == [0, 1, 2, 3]
+assert c == ["a", "b", "c", "e"]
 
 # Test pop
 a = [-1, 0, 1]
@@ -87,6 +119,32 @@ assert a == [3, 2, 4, 1, 6, 7]
 a.extend(range(3))
 assert a == [3, 2, 4, 1, 6, 7, 0, 1, 2]
 
+# Any iterable can be used to extend a list.
+a = []
+a.extend(x * 2 for x in range(3))
+assert a == [0, 2, 4]
+a.extend(set([5]))
+assert a == [0, 2, 4, 5]
+a.extend({'k': 'v'})
+assert a == [0, 2, 4, 5, 'k']
+a.extend('xy')
+assert a == [0, 2, 4, 5, 'k', 'x', 'y']
+a.extend(iter([8, 9]))
+assert a == [0, 2, 4, 5, 'k', 'x', 'y', 8, 9]
+
+
+class BadLen(object):
+
+  def __iter__(self):
+    return iter([1, 2])
+
+  def __len__(self):
+    raise TypeError
+
+a = []
+a.extend(BadLen())
+assert a == [1, 2]
+
 try:
   a.extend()
   assert AssertionError
@@ -110,3 +168,84 @@ try:
   assert AssertionError
 except TypeError:
   pass
+
+# Test index and remove
+
+
+class Value(object):
+
+  def __init__(self, value):
+    self.value = value
+
+  def __eq__(self, other):
+    return isinstance(other, Value) and self.value == other.value
+
+a = [Value(1), Value(2), Value(3), Value(2)]
+assert a.index(Value(2)) == 1
+assert a.index(Value(2), 2) == 3
+assert a.index(Value(2), -1) == 3
+assert a.index(Value(2), 0, 2) == 1
+
+for args in ((Value(4),), (Value(1), 1), (Value(3), 0, 2), (Value(2), 2, -1)):
+  try:
+    a.index(*args)
+  except ValueError:
+    pass
+  else:
+    raise AssertionError
+
+a.remove(Value(2))
+assert [x.value for x in a] == [1, 3, 2]
+a.remove(Value(2))
+assert [x.value for x in a] == [1, 3]
+
+try:
+  a.remove(Value(2))
+except ValueError as e:
+  assert str(e) == 'list.remove(x): x not in list'
+else:
+  raise AssertionError
+
+# Identical elements match even when they don't compare equal to themselves.
+nan = float('nan')
+a = [1, nan]
+assert a.index(nan) == 1
+a.remove(nan)
+assert a == [1]
+
+# Test repr of self-referential lists
+a = []
+a.append(a)
+assert repr(a) == '[[...]]'
+assert str(a) == '[[...]]'
+b = [1, {}]
+b[1]['b'] = b
+assert repr(b) == "[1, {'b': [...]}]"
+
+# Test in-place add and multiply
+a = b = [1]
+a += (x * 2 for x in range(1, 3))
+assert a is b
+assert a == [1, 2, 4]
+a += 'ab'
+assert b == [1, 2, 4, 'a', 'b']
+a = b = [1, 2]
+a += a
+assert a is b
+assert a == [1, 2, 1, 2]
+a *= 2
+assert a is b
+assert b == [1, 2, 1, 2, 1, 2, 1, 2]
+a *= 0
+assert a is b
+assert b == []
+try:
+  a += 1
+  raise AssertionError
+except TypeError:
+  pass
+try:
+  a *= 'foo'
+  raise AssertionError
+except TypeError:
+  pass
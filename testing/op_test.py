@@ -15,6 +15,7 @@
 """Arithmetic and boolean operator tests."""
 
 import math
+import sys
 
 import weetest
 
@@ -58,6 +59,72 @@ def TestBoolOpsLazyEval():
   assert ran == ['Yes']
 
 
+def TestClassicDivision():
+  assert 5 / 2 == 2
+  assert -5 / 2 == -3
+  assert 5L / 2 == 2L
+  assert 5.0 / 2 == 2.5
+  x = 5
+  x /= 2
+  assert x == 2
+
+
+def TestFloorDivision():
+  assert -7 // 2 == -4
+  assert 7 // -2 == -4
+  assert -7 // -2 == 3
+  assert -7L // 2 == -4L
+  assert 7L // -2 == -4L
+  assert -7.0 // 2 == -4.0
+  assert 7 // -2.0 == -4.0
+  assert 1 // 0.1 == 9.0
+  assert type(7 // 2.0) is float
+  x = -7
+  x //= 2
+  assert x == -4
+
+
+def TestIntShiftPromotion():
+  bits = sys.maxint.bit_length()
+  assert type(1 << 10) is int
+  assert type(1 << (bits - 1)) is int
+  assert type(-1 << bits) is int
+  assert -1 << bits == -sys.maxint - 1
+  assert type(1 << bits) is long
+  assert 1 << bits == sys.maxint + 1
+  assert type(1 << 100) is long
+  assert type(sys.maxint << 1) is long
+  assert -7 >> 1 == -4
+  assert -1 >> 100 == -1
+  assert type(-7 >> 1) is int
+
+
+def TestLongBitwise():
+  assert ~(-1L) == 0
+  assert ~(1L << 70) == -(1L << 70) - 1
+  assert -1L & 0xff == 0xff
+  assert -(3L << 100) & (0xffL << 96) == 0xd0L << 96
+  assert -5L | (1L << 70) == -5
+  assert -5L ^ (1L << 70) == -5 - (1L << 70)
+  assert (1L << 1000) >> 999 == 2
+  assert -(1L << 100) >> 99 == -2
+  assert -1L >> 1000 == -1
+  assert 1 << 100 == 1267650600228229401496703205376L
+
+
+def TestModulo():
+  # The result of % takes the sign of the divisor.
+  for t in (int, long, float):
+    assert t(7) % t(3) == 1
+    assert t(-7) % t(3) == 2
+    assert t(7) % t(-3) == -2
+    assert t(-7) % t(-3) == -1
+  assert -7 % 3.0 == 2.0
+  assert 7L % -3.0 == -2.0
+  assert math.copysign(1, -0.0 % 3) == 1
+  assert math.copysign(1, 0.0 % -3) == -1
+
+
 def TestNeg():
   x = 12
   assert -x == -12
@@ -0,0 +1,143 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import cPickle
+import pickle
+
+
+class Point(object):
+
+  def __init__(self, x, y):
+    self.x = x
+    self.y = y
+
+
+class Counter(object):
+
+  def __init__(self, name):
+    self.name = name
+    self.cache = {'expensive': True}
+
+  def __getstate__(self):
+    return {'name': self.name}
+
+  def __setstate__(self, state):
+    self.__dict__.update(state)
+    self.cache = {}
+
+
+class Reduced(object):
+
+  def __init__(self, value):
+    self.value = value
+
+  def __reduce__(self):
+    return (Reduced, (self.value * 2,))
+
+
+PROTOCOLS = (0, 1, 2)
+
+# Primitive values and nested containers.
+VALUES = [None, True, False, 0, 1, -1, 255, 65536, -70000, 1L << 70,
+          -(1L << 70), 3.25, -0.5, '', 'foo\n\'"\\\x00\xff', u'', u'caf\xe9',
+          u'\u1234\\\n', (), (1,), (1, (2, 3)), [], [1, [2, [3]]],
+          {'a': [1, 2], 'b': {'c': (3,)}}]
+
+for proto in PROTOCOLS:
+  for value in VALUES:
+    result = pickle.loads(pickle.dumps(value, proto))
+    assert result == value, (proto, value, result)
+    assert type(result) is type(value), (proto, value, result)
+
+# Shared sub-objects keep their identity.
+for proto in PROTOCOLS:
+  shared = [1, 2]
+  l = pickle.loads(pickle.dumps([shared, shared, (shared,)], proto))
+  assert l[0] == [1, 2]
+  assert l[0] is l[1]
+  assert l[0] is l[2][0]
+
+# Recursive structures are handled through the memo.
+for proto in PROTOCOLS:
+  l = []
+  l.append(l)
+  result = pickle.loads(pickle.dumps(l, proto))
+  assert result[0] is result
+  d = {}
+  d['self'] = d
+  result = pickle.loads(pickle.dumps(d, proto))
+  assert result['self'] is result
+
+# Instances of user defined classes.
+for proto in PROTOCOLS:
+  p = pickle.loads(pickle.dumps(Point(1, [2]), proto))
+  assert type(p) is Point
+  assert (p.x, p.y) == (1, [2])
+
+  c = pickle.loads(pickle.dumps(Counter('foo'), proto))
+  assert type(c) is Counter
+  assert c.name == 'foo'
+  assert c.cache == {}
+
+  r = pickle.loads(pickle.dumps(Reduced(21), proto))
+  assert type(r) is Reduced
+  assert r.value == 42
+
+# Classes and functions are pickled by reference.
+for proto in PROTOCOLS:
+  assert pickle.loads(pickle.dumps(Point, proto)) is Point
+  assert pickle.loads(pickle.dumps(len, proto)) is len
+
+# Protocol 0 output is printable and matches CPython's.
+assert pickle.dumps([1, 'a'], 0) == "(lp0\nI1\naS'a'\np1\na."
+assert pickle.dumps((1, 2), 2) == '\x80\x02K\x01K\x02\x86q\x00.'
+
+# The Pickler and Unpickler classes can be used directly.
+class Sink(object):
+
+  def __init__(self):
+    self.data = []
+
+  def write(self, s):
+    self.data.append(s)
+
+
+sink = Sink()
+pickler = pickle.Pickler(sink, 2)
+pickler.dump({'x': 1})
+assert pickle.loads(''.join(sink.data)) == {'x': 1}
+
+# Objects that cannot be found by name cannot be pickled.
+try:
+  pickle.dumps(lambda: None)
+except pickle.PicklingError:
+  pass
+else:
+  raise AssertionError
+
+try:
+  pickle.loads('garbage')
+except pickle.UnpicklingError:
+  pass
+except (KeyError, IndexError, ValueError):
+  pass
+else:
+  raise AssertionError
+
+# cPickle exposes the same interface.
+for proto in PROTOCOLS:
+  s = cPickle.dumps({'a': [1, (2, 3)]}, proto)
+  assert cPickle.loads(s) == {'a': [1, (2, 3)]}
+  assert pickle.loads(s) == cPickle.loads(s)
+assert cPickle.HIGHEST_PROTOCOL == 2
@@ -0,0 +1,80 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import copy
+
+
+def RoundTrip(obj, proto):
+  """Rebuilds obj from its __reduce_ex__ the way pickle does."""
+  reduced = obj.__reduce_ex__(proto)
+  assert isinstance(reduced, tuple) and 2 <= len(reduced) <= 5
+  result = reduced[0](*reduced[1])
+  state = reduced[2] if len(reduced) > 2 else None
+  if state is not None:
+    if hasattr(result, '__setstate__'):
+      result.__setstate__(state)
+    else:
+      result.__dict__.update(state)
+  return result
+
+
+class Point(object):
+
+  def __init__(self, x, y):
+    self.x = x
+    self.y = y
+
+
+class Counter(object):
+
+  def __init__(self, name):
+    self.name = name
+    self.cache = {'expensive': True}
+
+  def __getstate__(self):
+    # The cache is not part of the pickled state.
+    return {'name': self.name}
+
+  def __setstate__(self, state):
+    self.__dict__.update(state)
+    self.cache = {}
+
+
+for proto in (0, 1, 2):
+  p = RoundTrip(Point(1, 2), proto)
+  assert type(p) is Point
+  assert (p.x, p.y) == (1, 2)
+
+  c = RoundTrip(Counter('foo'), proto)
+  assert type(c) is Counter
+  assert c.name == 'foo'
+  assert c.cache == {}
+
+# The copy module uses the same protocol.
+p = copy.copy(Point(3, 4))
+assert (p.x, p.y) == (3, 4)
+c = copy.deepcopy(Counter('bar'))
+assert c.name == 'bar' and c.cache == {}
+
+# __reduce__ overrides are honored by __reduce_ex__.
+
+
+class Reduced(object):
+
+  def __reduce__(self):
+    return (Reduced, ())
+
+
+assert Reduced().__reduce_ex__(2) == (Reduced, ())
+assert isinstance(RoundTrip(Reduced(), 2), Reduced)
@@ -27,6 +27,54 @@ assert "foo".capitalize() == "Foo"
 assert "Foo".capitalize() == "Foo"
 assert "FOO".capitalize() == "Foo"
 
+# Test center, ljust and rjust
+assert "x".center(5) == "  x  "
+assert "x".center(4, "*") == "*x**"
+assert "ab".center(5, "*") == "**ab*"
+assert "ab".center(6, "*") == "**ab**"
+assert "abc".center(6, "*") == "*abc**"
+assert "abc".center(2) == "abc"
+assert "x".ljust(4, "*") == "x***"
+assert "x".rjust(4, "*") == "***x"
+assert "-1".rjust(4, "0") == "00-1"
+assert "abc".ljust(3) == "abc"
+assert "abc".rjust(-1) == "abc"
+
+
+class StrSubclass(str):
+  pass
+
+
+assert type(StrSubclass("abc").center(2)) is str
+
+for method in ("center", "ljust", "rjust"):
+  for fillchar in ("", "**", 1):
+    try:
+      getattr("x", method)(3, fillchar)
+      raise AssertionError
+    except TypeError:
+      pass
+
+# Test contains
+assert "b" in "abc"
+assert "bc" in "abc"
+assert "abc" in "abc"
+assert "d" not in "abc"
+assert "ac" not in "abc"
+assert "abcd" not in "abc"
+assert "" in "abc"
+assert "" in ""
+assert "a" not in ""
+assert "needle" in "hay" * 10000 + "needle" + "hay" * 10000
+assert "needles" not in "hay" * 10000 + "needle" + "hay" * 10000
+assert u"b" in "abc"
+try:
+  1 in "abc"
+except TypeError as e:
+  assert str(e) == "'in <string>' requires string as left operand, not int"
+else:
+  raise AssertionError
+
 # Test count
 assert "".count("a") == 0
 assert "abcd".count("e") == 0
@@ -36,6 +84,14 @@ assert "abbba".count("bb") == 1
 assert "abbbba".count("bb") == 2
 assert "five".count("") == 5
 assert ("a" * 20).count("a") == 20
+assert "aaaa".count("aa") == 2
+assert "abcabc".count("a", 1) == 1
+assert "abcabc".count("a", 1, 3) == 0
+assert "abcabc".count("a", -3) == 1
+assert "abcabc".count("a", None, -3) == 1
+assert "abcabc".count("", 2) == 5
+assert "abcabc".count("", 2, 4) == 3
+assert "abcabc".count("", 10) == 0
 
 try:
   "".count()
@@ -117,6 +173,29 @@ try:
 except TypeError:
   pass
 
+# Test index, rfind and rindex
+assert "abcabc".index("b") == 1
+assert "abcabc".index("b", 2) == 4
+assert "abcabc".index("b", -3, -1) == 4
+assert "abcabc".index("c", -100, 100) == 2
+assert "abcabc".rfind("b") == 4
+assert "abcabc".rfind("b", None, 4) == 1
+assert "abcabc".rfind("b", -6, -2) == 1
+assert "abcabc".rfind("") == 6
+assert "abcabc".rfind("", 2, 4) == 4
+assert "abcabc".rfind("b", 0, -5) == -1
+assert "abcabc".rfind("z") == -1
+assert "abcabc".rindex("b") == 4
+assert "abcabc".rindex("b", -6, -2) == 1
+
+for method in ("index", "rindex"):
+  for args in (("z",), ("b", 0, -5), ("", 7)):
+    try:
+      getattr("abcabc", method)(*args)
+      raise AssertionError
+    except ValueError:
+      pass
+
 # Test GetItem
 class IntIndexType(object):
   def __index__(self):
@@ -338,6 +417,25 @@ assert 'a b c d '.split() == ['a', 'b', 'c', 'd']
 assert ' a b c d '.split(None, 1) == ['a', 'b c d ']
 assert '   a b c d'.split(None, 0) == ['a b c d']
 
+# Test startswith and endswith
+assert "foobar".startswith(("x", "fo"))
+assert not "foobar".startswith(("x", "y"))
+assert not "foobar".startswith(())
+assert "foobar".startswith("bar", 3)
+assert "foobar".startswith("bar", -3)
+assert not "foobar".startswith("foo", None, 2)
+assert "foobar".startswith(("x", "ob"), 2, 4)
+assert "foobar".endswith(("baz", "ba"), 0, -1)
+assert not "foobar".endswith(("baz", "bar"), None, 5)
+assert "foobar".endswith("ob", -100, -2)
+
+for arg in (1, ["foo"], ("x", 1)):
+  try:
+    "foobar".startswith(arg)
+    raise AssertionError
+  except TypeError:
+    pass
+
 # Test zfill
 assert '123'.zfill(2) == '123'
 assert '123'.zfill(3) == '123'
@@ -369,3 +467,62 @@ assert '3'.zfill(LongIntType()) == '03'
 assert '%o' % 8 == '10'
 assert '%o' % -8 == '-10'
 assert '%o %o' % (8, -8) == '10 -10'
+
+# In Python 2, bytes is an alias for str.
+assert bytes is str
+assert isinstance('abc', bytes)
+assert bytes('abc') == 'abc'
+# Unlike Python 3, bytes(97) is the string representation of the int and not
+# 97 null bytes, and bytes([97]) is the repr of the list.
+assert bytes(97) == '97'
+assert bytes([97]) == '[97]'
+assert not isinstance(u'abc', bytes)
+
+# Test predicates. Each tuple holds the results of isalpha, isdigit, isalnum,
+# isspace, isupper, islower and istitle. Only ASCII characters are classified
+# so they are independent of the locale.
+predicates = [str.isalpha, str.isdigit, str.isalnum, str.isspace, str.isupper,
+              str.islower, str.istitle]
+F, T = False, True
+for s, want in [
+    ('', (F, F, F, F, F, F, F)),
+    ('a', (T, F, T, F, F, T, F)),
+    ('A', (T, F, T, F, T, F, T)),
+    ('1', (F, T, T, F, F, F, F)),
+    (' \t\n\r\v\f', (F, F, F, T, F, F, F)),
+    ('abc1', (F, F, T, F, F, T, F)),
+    ('ABC1', (F, F, T, F, T, F, F)),
+    ('aBc', (T, F, T, F, F, F, F)),
+    ('1A', (F, F, T, F, T, F, T)),
+    ('!a', (F, F, F, F, F, T, F)),
+    ('A B', (F, F, F, F, T, F, T)),
+    ('Abc Def', (F, F, F, F, F, F, T)),
+    ('Abc def', (F, F, F, F, F, F, F)),
+    ('ABc', (T, F, T, F, F, F, F)),
+    ("Isn'T", (F, F, F, F, F, F, T)),
+    ("Isn't", (F, F, F, F, F, F, F)),
+    ('A1b', (F, F, T, F, F, F, F)),
+    ('A\xc9', (F, F, F, F, T, F, T)),
+    ('1.5', (F, F, F, F, F, F, F))]:
+  got = tuple(p(s) for p in predicates)
+  assert got == want, (s, got, want)
+
+# Test title and capitalize. Any non-letter starts a new word for title, so an
+# apostrophe capitalizes the letter that follows it just like CPython.
+assert 'hello world'.title() == 'Hello World'
+assert "they're bill's friends".title() == "They'Re Bill'S Friends"
+assert 'hELLO wORLD 2nd-place'.title() == 'Hello World 2Nd-Place'
+assert ''.title() == ''
+assert 'hELLO'.capitalize() == 'Hello'
+assert 'mIxEd CaSe'.capitalize() == 'Mixed case'
+assert "they're".capitalize() == "They're"
+assert '1ABC'.capitalize() == '1abc'
+assert ''.capitalize() == ''
+
+# Test swapcase. Only ASCII letters change case.
+assert 'Hello World'.swapcase() == 'hELLO wORLD'
+assert 'HELLO'.swapcase() == 'hello'
+assert 'hello'.swapcase() == 'HELLO'
+assert 'a1!B2?c \t'.swapcase() == 'A1!b2?C \t'
+assert '\xe9\xc9'.swapcase() == '\xe9\xc9'
+assert ''.swapcase() == ''
@@ -0,0 +1,79 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import StringIO
+import sys
+import traceback
+
+
+def inner():
+  raise ValueError('boom')
+
+
+def outer():
+  inner()
+
+
+# format_exc renders the exception being handled.
+try:
+  outer()
+except ValueError:
+  s = traceback.format_exc()
+  lines = s.splitlines()
+  assert lines[0] == 'Traceback (most recent call last):', s
+  assert lines[-1] == 'ValueError: boom', s
+  assert lines[-2] == "    raise ValueError('boom')", s
+  assert lines[-3].endswith(', in inner'), s
+  assert lines[-5].endswith(', in outer'), s
+  assert 'traceback_test.py' in lines[-3], s
+
+# extract_tb yields (filename, lineno, name, line) from the outermost frame in.
+try:
+  outer()
+except ValueError:
+  entries = traceback.extract_tb(sys.exc_info()[2])
+  assert [e[2] for e in entries] == ['<module>', 'outer', 'inner'], entries
+  assert entries[-1][1] == 21, entries
+  assert entries[-1][3] == "raise ValueError('boom')", entries
+  assert entries[-2][3] == 'inner()', entries
+
+# Each traceback entry links to the next frame in, recording its line number.
+try:
+  outer()
+except ValueError:
+  tb = sys.exc_info()[2]
+  chain = []
+  while tb:
+    chain.append((tb.tb_frame.f_code.co_name, tb.tb_lineno))
+    tb = tb.tb_next
+  assert chain == [('<module>', 53), ('outer', 25), ('inner', 21)], chain
+
+# print_exc writes to the given file, defaulting to sys.stderr.
+try:
+  outer()
+except ValueError:
+  f = StringIO.StringIO()
+  traceback.print_exc(file=f)
+  assert f.getvalue() == traceback.format_exc()
+  saved = sys.stderr
+  sys.stderr = StringIO.StringIO()
+  try:
+    traceback.print_exc()
+    assert sys.stderr.getvalue() == traceback.format_exc()
+  finally:
+    sys.stderr = saved
+
+# format_exception_only renders just the final line.
+assert traceback.format_exception_only(
+    ValueError, ValueError('x')) == ['ValueError: x\n']
@@ -12,6 +12,28 @@
 # See the License for the specific language governing permissions and
 # limitations under the License.
 
+# Test concatenation and repetition
+assert (1, 2) + (3,) == (1, 2, 3)
+assert () + () == ()
+assert (0,) * 3 == (0, 0, 0)
+assert 3 * (0,) == (0, 0, 0)
+assert (0,) * 0 == ()
+assert (0,) * -2 == ()
+
+for other in ([3], 'a', None):
+  try:
+    (1, 2) + other
+  except TypeError:
+    pass
+  else:
+    raise AssertionError
+
+
+class Tuple(tuple):
+  pass
+
+assert type(Tuple((1,)) + (2,)) is tuple
+
 # Test count
 assert ().count(0) == 0
 assert (1, 2, 3).count(2) == 1
@@ -23,3 +45,29 @@ try:
   assert AssertionError
 except TypeError:
   pass
+
+# Test index
+assert (1, 2, 3, 2, 1).index(2) == 1
+assert (1, 2, 3, 2, 1).index(2, 2) == 3
+assert (1, 2, 3, 2, 1).index(1, -1) == 4
+assert (1, 2, 3, 2, 1).index(2, 0, 2) == 1
+
+for args in ((4,), (2, 4), (2, 0, 1), (1, 1, -1)):
+  try:
+    (1, 2, 3, 2, 1).index(*args)
+  except ValueError as e:
+    assert str(e) == 'tuple.index(x): x not in tuple'
+  else:
+    raise AssertionError
+
+# Test hash
+assert hash((1, 2)) == hash((1, 2))
+assert hash(('a', (1, 2))) == hash(('a',) + ((1, 2),))
+assert {(1, 'a'): 3}[(1, 'a')] == 3
+assert (1, 2) in set([(1, 2)])
+
+try:
+  hash((1, []))
+  assert AssertionError
+except TypeError:
+  pass
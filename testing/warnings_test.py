@@ -0,0 +1,89 @@
+# Copyright 2016 Google Inc. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import warnings
+
+
+def warn_deprecated():
+  warnings.warn('foo', DeprecationWarning)
+
+
+def warn_user(message):
+  warnings.warn(message)
+
+
+# The warning is attributed to the caller's file and line.
+with warnings.catch_warnings(record=True) as w:
+  warnings.simplefilter('always')
+  warn_deprecated()
+  warn_deprecated()
+  assert len(w) == 2, w
+  assert w[0].category is DeprecationWarning
+  assert str(w[0].message) == 'foo'
+  assert w[0].filename.endswith('warnings_test.py')
+  assert w[0].lineno == 19
+
+# The default category is UserWarning.
+with warnings.catch_warnings(record=True) as w:
+  warnings.simplefilter('always')
+  warn_user('bar')
+  assert len(w) == 1 and w[0].category is UserWarning
+
+# Test ignore.
+with warnings.catch_warnings(record=True) as w:
+  warnings.simplefilter('ignore')
+  warn_user('ignored')
+  assert not w
+
+# Test once.
+with warnings.catch_warnings(record=True) as w:
+  warnings.simplefilter('once')
+  warn_user('once')
+  warn_user('once')
+  warn_user('twice')
+  assert [str(x.message) for x in w] == ['once', 'twice']
+
+# Test error.
+with warnings.catch_warnings():
+  warnings.simplefilter('error')
+  try:
+    warn_user('error')
+  except UserWarning as e:
+    assert str(e) == 'error'
+  else:
+    raise AssertionError
+
+# Filters match on message, category and ordering.
+with warnings.catch_warnings(record=True) as w:
+  warnings.resetwarnings()
+  warnings.simplefilter('always')
+  warnings.filterwarnings('ignore', 'fo+', UserWarning)
+  warnings.filterwarnings('error', category=RuntimeWarning)
+  warn_user('food')
+  warn_user('bar')
+  warnings.warn('baz', FutureWarning)
+  try:
+    warnings.warn('qux', RuntimeWarning)
+  except RuntimeWarning:
+    pass
+  else:
+    raise AssertionError
+  assert [str(x.message) for x in w] == ['bar', 'baz']
+
+try:
+  warnings.simplefilter('bogus')
+except AssertionError:
+  pass
+else:
+  raise AssertionError
@@ -180,3 +180,19 @@ with EnterResult([1, (2, 3)]) as (h, (i, j)):
 assert h == 1
 assert i == 2
 assert j == 3
+
+
+# Special methods are looked up on the type, so instance attributes are ignored.
+k = ContextManager()
+k.__enter__ = lambda: 'instance enter'
+k.__exit__ = lambda *args: True
+try:
+  with k as l:
+    assert k.active
+    assert l is None
+    raise RuntimeError('not swallowed')
+except RuntimeError as e:
+  assert str(e) == 'not swallowed'
+else:
+  raise AssertionError
+assert not k.active
@@ -149,14 +149,20 @@ class deque(object):
     def remove(self, value):
         # Need to defend mutating or failing comparisons
         i = 0
+        # TODO: Return from inside the try once Grumpy runs finally blocks on
+        # return. The return statement compiles to a plain Go return that
+        # skips the finally body, so self.rotate(i) would never run.
+        found = False
         try:
             for i in range(len(self)):
                 if self[0] == value:
                     self.popleft()
-                    return
+                    found = True
+                    break
                 self.append(self.popleft())
-            i += 1
-            raise ValueError("deque.remove(x): x not in deque")
+            if not found:
+                i += 1
+                raise ValueError("deque.remove(x): x not in deque")
         finally:
             self.rotate(i)
 
@@ -212,13 +218,17 @@ class deque(object):
             return 'deque([...])'
         else:
             self.__dict__[threadlocalattr] = True
+            # TODO: Return from inside the try once Grumpy runs finally
+            # blocks on return. A plain Go return is emitted for it, which
+            # would leave threadlocalattr set.
             try:
                 if self.maxlen is not None:
-                    return 'deque(%r, maxlen=%s)' % (list(self), self.maxlen)
+                    result = 'deque(%r, maxlen=%s)' % (list(self), self.maxlen)
                 else:
-                    return 'deque(%r)' % (list(self),)
+                    result = 'deque(%r)' % (list(self),)
             finally:
                 del self.__dict__[threadlocalattr]
+            return result
 
     def __iter__(self):
         return deque_iterator(self, self._iter_impl)
@@ -55,7 +55,8 @@ bytes = str
 
 
 def unpack_int(data, index, size, le):
-  _bytes = [b for b in data[index:index + size]]
+  # _bytes = [b for b in data[index:index + size]]
+  _bytes = [ord(b) for b in data[index:index + size]]
   if le == 'little':
     _bytes.reverse()
   number = 0
@@ -124,7 +125,8 @@ def pack_float(x, size, le):
   unsigned = float_pack(x, size)
   result = []
   for i in range(8):
-    result.append((unsigned >> (i * 8)) & 0xFF)
+    # result.append((unsigned >> (i * 8)) & 0xFF)
+    result.append(int((unsigned >> (i * 8)) & 0xFF))
   if le == "big":
     result.reverse()
   return ''.join(chr(x) for x in result)
@@ -420,7 +422,8 @@ def unpack(fmt, data):
       result.append(data[j:j + num])
       j += num
     elif cur == 'p':
-      n = data[j]
+      # n = data[j]
+      n = ord(data[j])
       if n >= num:
         n = num - 1
       result.append(data[j + 1:j + n + 1])
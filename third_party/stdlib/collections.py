@@ -264,7 +264,7 @@ class {typename}(tuple):
 
     __slots__ = ()
 
-    _fields = {field_names!r}
+    _fields = {field_names}
 
     def __new__(_cls, {arg_list}):
         'Create new instance of {typename}({arg_list})'
@@ -274,8 +274,8 @@ class {typename}(tuple):
     def _make(cls, iterable, new=tuple.__new__, len=len):
         'Make a new {typename} object from a sequence or iterable'
         result = new(cls, iterable)
-        if len(result) != {num_fields:d}:
-            raise TypeError('Expected {num_fields:d} arguments, got %d' % len(result))
+        if len(result) != {num_fields}:
+            raise TypeError('Expected {num_fields} arguments, got %d' % len(result))
         return result
 
     def __repr__(self):
@@ -288,7 +288,7 @@ class {typename}(tuple):
 
     def _replace(_self, **kwds):
         'Return a new {typename} object replacing specified fields with new values'
-        result = _self._make(map(kwds.pop, {field_names!r}, _self))
+        result = _self._make(map(kwds.pop, {field_names}, _self))
         if kwds:
             raise ValueError('Got unexpected field names: %r' % kwds.keys())
         return result
@@ -306,108 +306,174 @@ class {typename}(tuple):
 {field_defs}
 '''
 
-_repr_template = '{name}=%r'
+_repr_template = '%s=%%r'
 
 _field_template = '''\
-    {name} = _property(_itemgetter({index:d}), doc='Alias for field number {index:d}')
+    {name} = _property(_itemgetter({index}), doc='Alias for field number {index}')
 '''
 
-#def namedtuple(typename, field_names, verbose=False, rename=False):
-#    """Returns a new subclass of tuple with named fields.
-#
-#    >>> Point = namedtuple('Point', ['x', 'y'])
-#    >>> Point.__doc__                   # docstring for the new class
-#    'Point(x, y)'
-#    >>> p = Point(11, y=22)             # instantiate with positional args or keywords
-#    >>> p[0] + p[1]                     # indexable like a plain tuple
-#    33
-#    >>> x, y = p                        # unpack like a regular tuple
-#    >>> x, y
-#    (11, 22)
-#    >>> p.x + p.y                       # fields also accessible by name
-#    33
-#    >>> d = p._asdict()                 # convert to a dictionary
-#    >>> d['x']
-#    11
-#    >>> Point(**d)                      # convert from a dictionary
-#    Point(x=11, y=22)
-#    >>> p._replace(x=100)               # _replace() is like str.replace() but targets named fields
-#    Point(x=100, y=22)
-#
-#    """
-#
-#    # Validate the field names.  At the user's option, either generate an error
-#    # message or automatically replace the field name with a valid name.
-#    if isinstance(field_names, basestring):
-#        field_names = field_names.replace(',', ' ').split()
-#    field_names = map(str, field_names)
-#    typename = str(typename)
-#    if rename:
-#        seen = set()
-#        for index, name in enumerate(field_names):
-#            if (not all(c.isalnum() or c=='_' for c in name)
-#                or _iskeyword(name)
-#                or not name
-#                or name[0].isdigit()
-#                or name.startswith('_')
-#                or name in seen):
-#                field_names[index] = '_%d' % index
-#            seen.add(name)
-#    for name in [typename] + field_names:
-#        if type(name) != str:
-#            raise TypeError('Type names and field names must be strings')
-#        if not all(c.isalnum() or c=='_' for c in name):
-#            raise ValueError('Type names and field names can only contain '
-#                             'alphanumeric characters and underscores: %r' % name)
-#        if _iskeyword(name):
-#            raise ValueError('Type names and field names cannot be a '
-#                             'keyword: %r' % name)
-#        if name[0].isdigit():
-#            raise ValueError('Type names and field names cannot start with '
-#                             'a number: %r' % name)
-#    seen = set()
-#    for name in field_names:
-#        if name.startswith('_') and not rename:
-#            raise ValueError('Field names cannot start with an underscore: '
-#                             '%r' % name)
-#        if name in seen:
-#            raise ValueError('Encountered duplicate field name: %r' % name)
-#        seen.add(name)
-#
-#    # Fill-in the class template
-#    class_definition = _class_template.format(
-#        typename = typename,
-#        field_names = tuple(field_names),
-#        num_fields = len(field_names),
-#        arg_list = repr(tuple(field_names)).replace("'", "")[1:-1],
-#        repr_fmt = ', '.join(_repr_template.format(name=name)
-#                             for name in field_names),
-#        field_defs = '\n'.join(_field_template.format(index=index, name=name)
-#                               for index, name in enumerate(field_names))
-#    )
-#    if verbose:
-#        print class_definition
-#
-#    # Execute the template string in a temporary namespace and support
-#    # tracing utilities by setting a value for frame.f_globals['__name__']
-#    namespace = dict(_itemgetter=_itemgetter, __name__='namedtuple_%s' % typename,
-#                     OrderedDict=OrderedDict, _property=property, _tuple=tuple)
-#    try:
-#        exec class_definition in namespace
-#    except SyntaxError as e:
-#        raise SyntaxError(e.message + ':\n' + class_definition)
-#    result = namespace[typename]
-#
-#    # For pickling to work, the __module__ variable needs to be set to the frame
-#    # where the named tuple is created.  Bypass this step in environments where
-#    # sys._getframe is not defined (Jython for example) or sys._getframe is not
-#    # defined for arguments greater than 0 (IronPython).
-#    try:
-#        result.__module__ = _sys._getframe(1).f_globals.get('__name__', '__main__')
-#    except (AttributeError, ValueError):
-#        pass
-#
-#    return result
+def _fill_template(template, **fields):
+    # Grumpy doesn't support str.format so substitute the {name} fields
+    # directly. The values must already be formatted as strings.
+    for name, value in fields.iteritems():
+        template = template.replace('{%s}' % name, value)
+    return template
+
+def namedtuple(typename, field_names, verbose=False, rename=False):
+    """Returns a new subclass of tuple with named fields.
+
+    >>> Point = namedtuple('Point', ['x', 'y'])
+    >>> Point.__doc__                   # docstring for the new class
+    'Point(x, y)'
+    >>> p = Point(11, y=22)             # instantiate with positional args or keywords
+    >>> p[0] + p[1]                     # indexable like a plain tuple
+    33
+    >>> x, y = p                        # unpack like a regular tuple
+    >>> x, y
+    (11, 22)
+    >>> p.x + p.y                       # fields also accessible by name
+    33
+    >>> d = p._asdict()                 # convert to a dictionary
+    >>> d['x']
+    11
+    >>> Point(**d)                      # convert from a dictionary
+    Point(x=11, y=22)
+    >>> p._replace(x=100)               # _replace() is like str.replace() but targets named fields
+    Point(x=100, y=22)
+
+    """
+
+    # Validate the field names.  At the user's option, either generate an error
+    # message or automatically replace the field name with a valid name.
+    if isinstance(field_names, basestring):
+        field_names = field_names.replace(',', ' ').split()
+    field_names = map(str, field_names)
+    typename = str(typename)
+    if rename:
+        seen = set()
+        for index, name in enumerate(field_names):
+            if (not all(c.isalnum() or c=='_' for c in name)
+                or _iskeyword(name)
+                or not name
+                or name[0].isdigit()
+                or name.startswith('_')
+                or name in seen):
+                field_names[index] = '_%d' % index
+            seen.add(name)
+    for name in [typename] + field_names:
+        if type(name) != str:
+            raise TypeError('Type names and field names must be strings')
+        if not all(c.isalnum() or c=='_' for c in name):
+            raise ValueError('Type names and field names can only contain '
+                             'alphanumeric characters and underscores: %r' % name)
+        if _iskeyword(name):
+            raise ValueError('Type names and field names cannot be a '
+                             'keyword: %r' % name)
+        if name[0].isdigit():
+            raise ValueError('Type names and field names cannot start with '
+                             'a number: %r' % name)
+    seen = set()
+    for name in field_names:
+        if name.startswith('_') and not rename:
+            raise ValueError('Field names cannot start with an underscore: '
+                             '%r' % name)
+        if name in seen:
+            raise ValueError('Encountered duplicate field name: %r' % name)
+        seen.add(name)
+
+    # Fill-in the class template
+    num_fields = len(field_names)
+    arg_list = repr(tuple(field_names)).replace("'", "")[1:-1]
+    repr_fmt = ', '.join(_repr_template % name for name in field_names)
+    if verbose:
+        # Grumpy's print statement always writes to the process's stdout so
+        # write to sys.stdout explicitly to honor redirection.
+        _sys.stdout.write(_fill_template(
+            _class_template,
+            typename = typename,
+            field_names = repr(tuple(field_names)),
+            num_fields = str(num_fields),
+            arg_list = arg_list,
+            repr_fmt = repr_fmt,
+            field_defs = '\n'.join(_fill_template(_field_template,
+                                                  index=str(index), name=name)
+                                   for index, name in enumerate(field_names))
+        ) + '\n')
+
+    # Grumpy compiles ahead of time so the class template can't be exec'd.
+    # Build the equivalent class from closures instead.
+    # TODO: Use the template once exec of source code is supported.
+    field_names = tuple(field_names)
+
+    def __new__(_cls, *args, **kwds):
+        given = len(args) + len(kwds) + 1
+        if len(args) > num_fields:
+            raise TypeError('__new__() takes exactly %d arguments (%d given)' %
+                            (num_fields + 1, given))
+        for name in kwds:
+            if name not in field_names:
+                raise TypeError("__new__() got an unexpected keyword argument "
+                                "'%s'" % name)
+            if name in field_names[:len(args)]:
+                raise TypeError("__new__() got multiple values for keyword "
+                                "argument '%s'" % name)
+        if given != num_fields + 1:
+            raise TypeError('__new__() takes exactly %d arguments (%d given)' %
+                            (num_fields + 1, given))
+        values = list(args)
+        values.extend(kwds[name] for name in field_names[len(args):])
+        return tuple.__new__(_cls, values)
+
+    def _make(cls, iterable, new=tuple.__new__, len=len):
+        result = new(cls, iterable)
+        if len(result) != num_fields:
+            raise TypeError('Expected %d arguments, got %d' % (num_fields, len(result)))
+        return result
+
+    def __repr__(self):
+        return '%s(%s)' % (self.__class__.__name__, repr_fmt % self)
+
+    def _asdict(self):
+        return OrderedDict(zip(self._fields, self))
+
+    def _replace(_self, **kwds):
+        result = _self._make(map(kwds.pop, field_names, _self))
+        if kwds:
+            raise ValueError('Got unexpected field names: %r' % kwds.keys())
+        return result
+
+    def __getnewargs__(self):
+        return tuple(self)
+
+    def __getstate__(self):
+        pass
+
+    namespace = {
+        '__doc__': '%s(%s)' % (typename, arg_list),
+        '__slots__': (),
+        '_fields': field_names,
+        '__new__': __new__,
+        '_make': classmethod(_make),
+        '__repr__': __repr__,
+        '_asdict': _asdict,
+        '_replace': _replace,
+        '__getnewargs__': __getnewargs__,
+        '__getstate__': __getstate__,
+    }
+    for index, name in enumerate(field_names):
+        namespace[name] = property(_itemgetter(index))
+    result = type(typename, (tuple,), namespace)
+
+    # For pickling to work, the __module__ variable needs to be set to the frame
+    # where the named tuple is created.  Bypass this step in environments where
+    # sys._getframe is not defined (Jython for example) or sys._getframe is not
+    # defined for arguments greater than 0 (IronPython).
+    try:
+        result.__module__ = _sys._getframe(1).f_globals.get('__name__', '__main__')
+    except (AttributeError, ValueError):
+        pass
+
+    return result
 
 
 ########################################################################
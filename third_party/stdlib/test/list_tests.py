@@ -4,11 +4,14 @@ Tests common to list and UserList.UserList
 
 import sys
 import os
+import unittest
 
 from test import test_support, seq_tests
 
 class CommonTest(seq_tests.CommonTest):
 
+    # TODO: Make list.__init__ clear the list. Grumpy fills lists in __new__.
+    @unittest.expectedFailure
     def test_init(self):
         # Iterable arg is optional
         self.assertEqual(self.type2test([]), self.type2test())
@@ -28,6 +31,8 @@ class CommonTest(seq_tests.CommonTest):
         self.assertNotEqual(id(a), id(b))
         self.assertEqual(a, b)
 
+    # TODO: Implement sys.getrecursionlimit.
+    @unittest.expectedFailure
     def test_repr(self):
         l0 = []
         l2 = [0, 1, 2]
@@ -50,6 +55,8 @@ class CommonTest(seq_tests.CommonTest):
             l0 = [l0]
         self.assertRaises(RuntimeError, repr, l0)
 
+    # TODO: Implement test_support.TESTFN and printing to files.
+    @unittest.expectedFailure
     def test_print(self):
         d = self.type2test(xrange(200))
         d.append(d)
@@ -140,6 +147,8 @@ class CommonTest(seq_tests.CommonTest):
 
         self.assertRaises(TypeError, a.__delitem__)
 
+    # TODO: Implement list.__setslice__.
+    @unittest.expectedFailure
     def test_setslice(self):
         l = [0, 1]
         a = self.type2test(l)
@@ -526,6 +535,8 @@ class CommonTest(seq_tests.CommonTest):
         a = self.type2test(range(10))
         del a[9::1<<333]
 
+    # TODO: Add the KeyboardInterrupt builtin.
+    @unittest.expectedFailure
     def test_constructor_exception_handling(self):
         # Bug #1242657
         class F(object):
@@ -174,6 +174,8 @@ class CommonTest(unittest.TestCase):
         self.assertRaises(IndexError, a.__getitem__, -3)
         self.assertRaises(IndexError, a.__getitem__, 3)
 
+    # TODO: Implement __getslice__.
+    @unittest.expectedFailure
     def test_getslice(self):
         l = [0, 1, 2, 3, 4]
         u = self.type2test(l)
@@ -258,6 +260,8 @@ class CommonTest(unittest.TestCase):
         self.assertEqual(min(u), 0)
         self.assertEqual(max(u), 2)
 
+    # TODO: Support repeating sequences by a long.
+    @unittest.expectedFailure
     def test_addmul(self):
         u1 = self.type2test([0])
         u2 = self.type2test([0, 1])
@@ -409,6 +413,8 @@ class CommonTest(unittest.TestCase):
         self.assertRaises(ValueError, a.index, 0, 4*sys.maxint,-4*sys.maxint)
         self.assertRaises(ValueError, a.index, 2, 0, -10)
 
+    # TODO: Implement test_support.check_free_after_iterating.
+    @unittest.expectedFailure
     def test_free_after_iterating(self):
         support.check_free_after_iterating(self, iter, self.type2test)
         support.check_free_after_iterating(self, reversed, self.type2test)
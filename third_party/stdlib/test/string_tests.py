@@ -333,6 +333,8 @@ class CommonTest(unittest.TestCase):
             self.checkraises(OverflowError,
                              '\ta\n\tb', 'expandtabs', sys.maxint)
 
+    # TODO: Support unicode separators in str.split.
+    @unittest.expectedFailure
     def test_split(self):
         self.checkequal(['this', 'is', 'the', 'split', 'function'],
             'this is the split function', 'split')
@@ -432,6 +434,8 @@ class CommonTest(unittest.TestCase):
         self.checkraises(ValueError, 'hello', 'split', '')
         self.checkraises(ValueError, 'hello', 'split', '', 0)
 
+    # TODO: Implement str.rsplit.
+    @unittest.expectedFailure
     def test_rsplit(self):
         self.checkequal(['this', 'is', 'the', 'rsplit', 'function'],
                          'this is the rsplit function', 'rsplit')
@@ -552,6 +556,8 @@ class CommonTest(unittest.TestCase):
         self.checkequal('   hello', '   hello   ', 'rstrip', None)
         self.checkequal('hello', 'hello', 'strip', None)
 
+    # TODO: Support the ascii codec.
+    @unittest.expectedFailure
     def test_strip(self):
         # strip/lstrip/rstrip with str arg
         self.checkequal('hello', 'xyzzyhelloxyzzy', 'strip', 'xyz')
@@ -621,6 +627,8 @@ class CommonTest(unittest.TestCase):
 
         self.checkraises(TypeError, 'hello', 'swapcase', 42)
 
+    # TODO: Support buffer arguments to str.replace.
+    @unittest.expectedFailure
     def test_replace(self):
         EQ = self.checkequal
 
@@ -299,7 +299,6 @@ class TestTimeDelta(HarmlessMixedComparison, unittest.TestCase):
         t2 = timedelta(microseconds=1)
         self.assertEqual(t1, t2)
 
-    @unittest.expectedFailure
     def test_hash_equality(self):
         t1 = timedelta(days=100,
                        weeks=-7,
@@ -659,7 +658,6 @@ class TestDate(HarmlessMixedComparison, unittest.TestCase):
         self.assertRaises(ValueError, self.theclass, 2000, 1, 0)
         self.assertRaises(ValueError, self.theclass, 2000, 1, 32)
 
-    @unittest.expectedFailure
     def test_hash_equality(self):
         d = self.theclass(2000, 12, 31)
         # same thing
@@ -1203,7 +1201,6 @@ class TestDateTime(TestDate):
                                 dt.microsecond)
             self.assertEqual(dt, dt2)
 
-    @unittest.expectedFailure
     def test_isoformat(self):
         t = self.theclass(2, 3, 2, 4, 5, 1, 123)
         self.assertEqual(t.isoformat(),    "0002-03-02T04:05:01.000123")
@@ -2136,6 +2133,8 @@ class TZInfoBase(object):
         self.assertRaises(ValueError, t.utcoffset)
         self.assertRaises(ValueError, t.dst)
 
+    # TODO: Make __new__ an implicit staticmethod so that replace() works.
+    @unittest.expectedFailure
     def test_aware_compare(self):
         cls = self.theclass
 
@@ -2277,7 +2276,6 @@ class TestTimeTZ(TestTime, TZInfoBase, unittest.TestCase):
         self.assertEqual(t.strftime("%H:%M:%S"), "02:03:04")
         self.assertRaises(TypeError, t.strftime, "%Z")
 
-    @unittest.expectedFailure
     def test_hash_edge_cases(self):
         # Offsets that overflow a basic time.
         t1 = self.theclass(0, 1, 2, 3, tzinfo=FixedOffset(1439, ""))
@@ -2552,7 +2550,6 @@ class TestDateTimeTZ(TestDateTime, TZInfoBase, unittest.TestCase):
         t = self.theclass(5, 5, 5, tzinfo=FixedOffset(-1440, ""))
         self.assertRaises(ValueError, hash, t)
 
-    @unittest.expectedFailure
     def test_zones(self):
         est = FixedOffset(-300, "EST")
         utc = FixedOffset(0, "UTC")
@@ -2856,7 +2853,6 @@ class TestDateTimeTZ(TestDateTime, TZInfoBase, unittest.TestCase):
         self.assertEqual(t.tm_yday, 1)
         self.assertEqual(t.tm_isdst, 0)
 
-    @unittest.expectedFailure
     def test_tzinfo_isoformat(self):
         zero = FixedOffset(0, "+00:00")
         plus = FixedOffset(220, "+03:40")
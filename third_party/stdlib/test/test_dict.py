@@ -29,7 +29,6 @@ class DictTest(unittest.TestCase):
         self.assertIs(bool({}), False)
         self.assertIs(bool({1: 2}), True)
 
-    @unittest.expectedFailure
     def test_keys(self):
         d = {}
         self.assertEqual(d.keys(), [])
@@ -59,7 +58,6 @@ class DictTest(unittest.TestCase):
 
         self.assertRaises(TypeError, d.items, None)
 
-    @unittest.expectedFailure
     def test_has_key(self):
         d = {}
         self.assertFalse(d.has_key('a'))
@@ -210,7 +208,6 @@ class DictTest(unittest.TestCase):
 
         self.assertRaises(ValueError, {}.update, [(1, 2, 3)])
 
-    @unittest.expectedFailure
     def test_fromkeys(self):
         self.assertEqual(dict.fromkeys('abc'), {'a':None, 'b':None, 'c':None})
         d = {}
@@ -269,7 +266,6 @@ class DictTest(unittest.TestCase):
         res.update(a=None, b=None, c=None)
         # self.assertEqual(baddict3.fromkeys({"a", "b", "c"}), res)
 
-    @unittest.expectedFailure
     def test_copy(self):
         d = {1:1, 2:2, 3:3}
         self.assertEqual(d.copy(), {1:1, 2:2, 3:3})
@@ -288,7 +284,6 @@ class DictTest(unittest.TestCase):
         self.assertRaises(TypeError, d.get)
         self.assertRaises(TypeError, d.get, None, None, None)
 
-    @unittest.expectedFailure
     def test_setdefault(self):
         # dict.setdefault()
         d = {}
@@ -337,7 +332,6 @@ class DictTest(unittest.TestCase):
         self.assertEqual(hashed2.hash_count, 1)
         self.assertEqual(hashed1.eq_count + hashed2.eq_count, 1)
 
-    @unittest.expectedFailure
     def test_popitem(self):
         # dict.popitem()
         # for copymode in -1, +1:
@@ -366,7 +360,6 @@ class DictTest(unittest.TestCase):
         d = {}
         self.assertRaises(KeyError, d.popitem)
 
-    @unittest.expectedFailure
     def test_pop(self):
         # Tests for pop with specified key
         d = {}
@@ -709,12 +702,32 @@ from test import mapping_tests
 class GeneralMappingTests(mapping_tests.BasicTestMappingProtocol):
     type2test = dict
 
+    # TODO: Implement dict.__cmp__. cmp() falls back to comparing addresses.
+    @unittest.expectedFailure
+    def test_read(self):
+        mapping_tests.BasicTestMappingProtocol.test_read(self)
+
+    # TODO: Make dict.update use keys() for mappings that aren't dicts.
+    @unittest.expectedFailure
+    def test_update(self):
+        mapping_tests.BasicTestMappingProtocol.test_update(self)
+
 class Dict(dict):
     pass
 
 class SubclassMappingTests(mapping_tests.BasicTestMappingProtocol):
     type2test = Dict
 
+    # TODO: Implement dict.__cmp__. cmp() falls back to comparing addresses.
+    @unittest.expectedFailure
+    def test_read(self):
+        mapping_tests.BasicTestMappingProtocol.test_read(self)
+
+    # TODO: Make dict.update use keys() for mappings that aren't dicts.
+    @unittest.expectedFailure
+    def test_update(self):
+        mapping_tests.BasicTestMappingProtocol.test_update(self)
+
 def test_main():
     with test_support.check_py3k_warnings(
         ('dict(.has_key..| inequality comparisons) not supported in 3.x',
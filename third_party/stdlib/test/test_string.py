@@ -29,7 +29,6 @@ class StringTest(
     def checkcall(self, object, methodname, *args):
         getattr(string, methodname)(object, *args)
 
-    @unittest.expectedFailure
     def test_join(self):
         # These are the same checks as in string_test.ObjectTest.test_join
         # but the argument order ist different
@@ -100,7 +99,6 @@ class ModuleTest(unittest.TestCase):
         self.assertEqual(string.maketrans('abc', 'xyz'), transtable)
         self.assertRaises(ValueError, string.maketrans, 'abc', 'xyzq')
 
-    @unittest.expectedFailure
     def test_capwords(self):
         self.assertEqual(string.capwords('abc def ghi'), 'Abc Def Ghi')
         self.assertEqual(string.capwords('abc\tdef\nghi'), 'Abc Def Ghi')
@@ -218,7 +216,6 @@ class ModuleTest(unittest.TestCase):
 
 class BytesAliasTest(unittest.TestCase):
 
-    @unittest.expectedFailure
     def test_builtin(self):
         self.assertTrue(str is bytes)
 